@@ -0,0 +1,146 @@
+package main
+
+import (
+	"booking-service/internal/admin"
+	"booking-service/internal/models"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/money"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// run dispatches a command and returns its result for printing.
+func run(ctx context.Context, a *admin.Admin, args []string) (interface{}, error) {
+	if len(args) > 0 && args[0] == "reconcile" {
+		flags := newFlagSet("reconcile")
+		repair := flags.Bool("repair", false, "apply the repair rules")
+		if err := parse(flags, args[1:], 0); err != nil {
+			return nil, err
+		}
+		return a.Reconcile(ctx, *repair)
+	}
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%w: expected a command and an action", errUsage)
+	}
+	command, action, rest := args[0], args[1], args[2:]
+
+	switch command + " " + action {
+	case "tickets generate":
+		return generateTickets(ctx, a, rest)
+	case "tickets release-orphans":
+		flags := newFlagSet("tickets release-orphans")
+		eventID := flags.Uint("event", 0, "only release tickets of this event")
+		if err := parse(flags, rest, 0); err != nil {
+			return nil, err
+		}
+		return a.ReleaseOrphanedTickets(ctx, *eventID)
+	case "booking show":
+		id, err := idArgument(rest)
+		if err != nil {
+			return nil, err
+		}
+		return a.Booking(ctx, id)
+	case "booking confirm", "booking cancel":
+		id, err := idArgument(rest)
+		if err != nil {
+			return nil, err
+		}
+		status := models.BookingStatusConfirmed
+		if action == "cancel" {
+			status = models.BookingStatusCanceled
+		}
+		return a.ForceBookingStatus(ctx, id, status)
+	case "ticket show":
+		id, err := idArgument(rest)
+		if err != nil {
+			return nil, err
+		}
+		return a.Ticket(ctx, id)
+	case "outbox replay":
+		flags := newFlagSet("outbox replay")
+		limit := flags.Int("limit", 100, "maximum number of failed messages to replay")
+		if err := flags.Parse(rest); err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		ids := make([]uint, 0, flags.NArg())
+		for _, arg := range flags.Args() {
+			id, err := parseID(arg)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		return a.ReplayOutbox(ctx, ids, *limit)
+	default:
+		return nil, fmt.Errorf("%w: unknown command %q", errUsage, command+" "+action)
+	}
+}
+
+func generateTickets(ctx context.Context, a *admin.Admin, args []string) (interface{}, error) {
+	flags := newFlagSet("tickets generate")
+	eventID := flags.Uint("event", 0, "event to create tickets for")
+	tierID := flags.Uint("tier", 0, "pricing tier to create tickets for, instead of -event and -price")
+	count := flags.Int("count", 0, "number of tickets")
+	amount := flags.String("price", "", "ticket price in major units, e.g. 12.50")
+	currency := flags.String("currency", "", "ISO 4217 currency code, defaults to the tenant's")
+	if err := parse(flags, args, 0); err != nil {
+		return nil, err
+	}
+
+	if *tierID != 0 {
+		return a.GenerateTickets(ctx, 0, *tierID, *count, money.Money{})
+	}
+	if *eventID == 0 || *amount == "" {
+		return nil, fmt.Errorf("%w: -event and -price, or -tier, are required", errUsage)
+	}
+	if *currency == "" {
+		*currency = tenantCurrency(ctx)
+	}
+	price, err := money.Parse(*amount, *currency)
+	if err != nil {
+		return nil, err
+	}
+	return a.GenerateTickets(ctx, uint(*eventID), 0, *count, price)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// parse parses flags and requires exactly nargs positional arguments.
+func parse(flags *flag.FlagSet, args []string, nargs int) error {
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != nargs {
+		return fmt.Errorf("%w: %s takes %d argument(s)", errUsage, flags.Name(), nargs)
+	}
+	return nil
+}
+
+// tenantCurrency returns the currency of the tenant being operated on.
+func tenantCurrency(ctx context.Context) string {
+	tenant, _ := tenancy.FromContext(ctx)
+	return tenant.Currency
+}
+
+// idArgument returns the single ID argument of a command.
+func idArgument(args []string) (uint, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%w: expected one ID", errUsage)
+	}
+	return parseID(args[0])
+}
+
+func parseID(arg string) (uint, error) {
+	id, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: invalid ID %q", errUsage, arg)
+	}
+	return uint(id), nil
+}
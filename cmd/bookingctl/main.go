@@ -0,0 +1,158 @@
+// Command bookingctl runs operational tasks against the booking database with
+// the same configuration and repositories as the service.
+package main
+
+import (
+	"booking-service/configs"
+	"booking-service/internal/admin"
+	"booking-service/internal/outbox"
+	"booking-service/internal/reconcile"
+	"booking-service/internal/repositories"
+	"booking-service/internal/services"
+	"booking-service/internal/settings"
+	"booking-service/internal/tenancy"
+	"booking-service/internal/webhooks"
+	"booking-service/pkg/db"
+	"booking-service/pkg/logging"
+	"booking-service/utils"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const usage = `usage: bookingctl [flags] <command> [arguments]
+
+commands:
+  tickets generate -event ID -count N -price AMOUNT [-currency CODE]
+  tickets generate -tier ID -count N
+                                  create tickets for an event or pricing tier
+  tickets release-orphans [-event ID]
+                                  put held tickets without a live booking back on sale
+  booking show ID                 print a booking with its tickets and prices
+  booking confirm ID              force a booking to CONFIRMED
+  booking cancel ID               force a booking to CANCELED
+  ticket show ID                  print a ticket
+  outbox replay [-limit N] [ID...]
+                                  requeue failed outbox messages, or the given ones
+  reconcile [-repair]             report tickets and bookings that disagree, and repair them
+
+flags:`
+
+// errUsage reports a malformed command line.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	flags := flag.NewFlagSet("bookingctl", flag.ContinueOnError)
+	configDir := flags.String("config", "configs", "directory containing config.yaml")
+	tenantID := flags.String("tenant", tenancy.DefaultTenantID, "tenant to operate on")
+	jsonOutput := flags.Bool("json", false, "print results as JSON")
+	dryRun := flags.Bool("dry-run", false, "report what would change without writing")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	config, err := configs.Load(*configDir, os.LookupEnv)
+	if err != nil {
+		exit(err)
+	}
+
+	// Logs go to stderr so stdout carries only results
+	if _, err := logging.Setup(logging.Config{
+		Level:      config.Log.Level,
+		Format:     config.Log.Format,
+		RedactKeys: config.Log.RedactKeys,
+	}, os.Stderr); err != nil {
+		exit(err)
+	}
+
+	tenants, err := tenancy.NewRegistry(config.Tenants)
+	if err != nil {
+		exit(err)
+	}
+	tenant, err := tenants.Get(*tenantID)
+	if err != nil {
+		exit(err)
+	}
+
+	database, err := db.Connect(
+		config.Database.Host,
+		config.Database.Port,
+		config.Database.User,
+		config.Database.Password,
+		config.Database.Name,
+	)
+	if err != nil {
+		exit(err)
+	}
+	if err := database.Use(tenancy.Plugin{}); err != nil {
+		exit(err)
+	}
+
+	bookingRepo := repositories.NewBookingRepository(database)
+	ticketRepo := repositories.NewTicketRepository(database)
+	outboxRepo := repositories.NewOutboxRepository(database)
+	ticketService := services.NewTicketService(ticketRepo)
+	pricingService := services.NewPricingService(repositories.NewPricingRepository(database), ticketRepo)
+	// Booking events go to Kafka through the outbox, for the service's relay to
+	// publish, and to subscribed webhooks
+	domainEvents := webhooks.NewProducer(outbox.NewProducer(outboxRepo), repositories.NewWebhookRepository(database))
+	bookingService := services.NewBookingService(bookingRepo, ticketService, pricingService, domainEvents, settings.NewStore(&config.Runtime.Settings))
+
+	a := admin.New(
+		bookingService,
+		ticketService,
+		services.NewEventService(repositories.NewEventRepository(database)),
+		pricingService,
+		ticketRepo,
+		outboxRepo,
+		reconcile.NewReconciler(bookingRepo, ticketRepo, reconcile.Config{
+			BatchSize: config.Reconciler.BatchSize,
+			MinAge:    config.Reconciler.MinAge,
+		}),
+		*dryRun,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx = tenancy.WithTenant(ctx, tenant)
+
+	result, err := run(ctx, a, flags.Args())
+	if errors.Is(err, errUsage) {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		exit(err)
+	}
+
+	p := printer{out: os.Stdout, json: *jsonOutput}
+	if err := p.print(result); err != nil {
+		exit(err)
+	}
+}
+
+// exit prints err and exits with a failure status.
+func exit(err error) {
+	var appErr *utils.AppError
+	if errors.As(err, &appErr) && appErr.Details != "" {
+		fmt.Fprintf(os.Stderr, "error: %s: %s\n", appErr.Message, appErr.Details)
+	} else if errors.As(err, &appErr) {
+		fmt.Fprintln(os.Stderr, "error:", appErr.Message)
+	} else {
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+	os.Exit(1)
+}
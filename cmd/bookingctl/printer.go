@@ -0,0 +1,114 @@
+package main
+
+import (
+	"booking-service/internal/admin"
+	"booking-service/internal/models"
+	"booking-service/internal/reconcile"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// printer writes command results as indented JSON or as text tables.
+type printer struct {
+	out  io.Writer
+	json bool
+}
+
+func (p printer) print(result interface{}) error {
+	if p.json {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	switch r := result.(type) {
+	case *models.Booking:
+		fmt.Fprintf(w, "Booking\t%d\n", r.ID)
+		fmt.Fprintf(w, "Tenant\t%s\n", r.TenantID)
+		fmt.Fprintf(w, "User\t%d\n", r.UserID)
+		fmt.Fprintf(w, "Event\t%d\n", r.EventID)
+		fmt.Fprintf(w, "Status\t%s\n", r.Status)
+		fmt.Fprintf(w, "Total\t%s (fee %d)\n", r.TotalAmount, r.FeeAmount)
+		fmt.Fprintf(w, "Created\t%s\n", r.CreatedAt.Format(time.RFC3339))
+		fmt.Fprintln(w)
+		writeTickets(w, r.Tickets)
+		if len(r.PriceRecords) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "TICKET\tSTRATEGY\tBASE\tPRICE\tEVALUATED")
+			for _, record := range r.PriceRecords {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", record.TicketID, record.Strategy, record.BasePrice, record.Price, record.EvaluatedAt.Format(time.RFC3339))
+			}
+		}
+	case *models.Ticket:
+		writeTickets(w, []models.Ticket{*r})
+	case *admin.GeneratedTickets:
+		fmt.Fprintf(w, "%s %d ticket(s) for event %d at %s\n", verb(r.DryRun, "Would create", "Created"), r.Count, r.EventID, r.Price)
+		if len(r.Tickets) > 0 {
+			fmt.Fprintln(w)
+			writeTickets(w, r.Tickets)
+		}
+	case *admin.BookingChange:
+		fmt.Fprintf(w, "%s booking %d from %s to %s (tickets %v)\n", verb(r.DryRun, "Would move", "Moved"), r.BookingID, r.From, r.To, r.TicketIDs)
+	case *admin.ReleasedTickets:
+		if r.DryRun {
+			fmt.Fprintf(w, "Would release %d orphaned ticket(s)\n", len(r.Tickets))
+		} else {
+			fmt.Fprintf(w, "Released %d of %d orphaned ticket(s)\n", r.Released, len(r.Tickets))
+		}
+		if len(r.Tickets) > 0 {
+			fmt.Fprintln(w)
+			writeTickets(w, r.Tickets)
+		}
+	case *admin.ReplayedMessages:
+		if r.DryRun {
+			fmt.Fprintf(w, "Would requeue %d outbox message(s)\n", len(r.Messages))
+		} else {
+			fmt.Fprintf(w, "Requeued %d outbox message(s)\n", r.Requeued)
+		}
+		if len(r.Messages) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "ID\tTOPIC\tSTATUS\tATTEMPTS\tLAST ERROR")
+			for _, message := range r.Messages {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", message.ID, message.Topic, message.Status, message.Attempts, message.LastError)
+			}
+		}
+	case *reconcile.Report:
+		fmt.Fprintf(w, "Scanned %d booking(s) of tenant %s, found %d inconsistencies, repaired %d\n", r.BookingsScanned, r.TenantID, len(r.Issues), r.Repaired)
+		if len(r.Issues) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "KIND\tBOOKING\tTICKET\tREPAIRED\tDETAIL")
+			for _, issue := range r.Issues {
+				fmt.Fprintf(w, "%s\t%d\t%d\t%t\t%s\n", issue.Kind, issue.BookingID, issue.TicketID, issue.Repaired, issue.Detail)
+			}
+		}
+	default:
+		return fmt.Errorf("cannot print %T", result)
+	}
+	return w.Flush()
+}
+
+func writeTickets(w io.Writer, tickets []models.Ticket) {
+	fmt.Fprintln(w, "TICKET\tEVENT\tTIER\tPRICE\tSTATUS\tUSER\tBOOKING")
+	for _, ticket := range tickets {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			ticket.ID, ticket.EventID, optional(ticket.TierID), ticket.Price, ticket.Status, optional(ticket.UserID), optional(ticket.BookingID))
+	}
+}
+
+func optional(id *uint) string {
+	if id == nil {
+		return "-"
+	}
+	return fmt.Sprint(*id)
+}
+
+func verb(dryRun bool, planned, done string) string {
+	if dryRun {
+		return planned
+	}
+	return done
+}
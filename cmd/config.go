@@ -0,0 +1,38 @@
+package main
+
+import (
+	"booking-service/configs"
+	"flag"
+	"fmt"
+	"io"
+)
+
+const configUsage = `usage: booking-service config print [-redacted]
+
+commands:
+  print  print the effective configuration after defaults and environment overrides`
+
+// runConfig implements the config subcommand. It prints the configuration
+// even when it is invalid, and then reports why.
+func runConfig(dir string, lookupEnv configs.LookupEnv, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("config", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.Usage = func() { fmt.Fprintln(out, configUsage) }
+	if len(args) == 0 || args[0] != "print" {
+		flags.Usage()
+		return fmt.Errorf("missing or unknown config command")
+	}
+	redact := flags.Bool("redacted", false, "mask secrets")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	config, err := configs.Read(dir, lookupEnv)
+	if err != nil {
+		return err
+	}
+	if err := config.Print(out, *redact); err != nil {
+		return err
+	}
+	return config.Validate()
+}
@@ -2,21 +2,72 @@ package main
 
 import (
 	"booking-service/configs"
+	"booking-service/internal/apiversion"
+	"booking-service/internal/authz"
 	"booking-service/internal/controllers"
-	"booking-service/internal/models"
+	"booking-service/internal/credentials"
+	"booking-service/internal/grpcapi"
+	"booking-service/internal/health"
+	"booking-service/internal/jobs"
+	"booking-service/internal/lifecycle"
+	"booking-service/internal/metrics"
+	"booking-service/internal/middlewares"
+	"booking-service/internal/openapi"
+	"booking-service/internal/outbox"
+	"booking-service/internal/reconcile"
 	"booking-service/internal/repositories"
 	"booking-service/internal/services"
+	"booking-service/internal/settings"
+	"booking-service/internal/tenancy"
+	"booking-service/internal/webhooks"
+	"booking-service/pkg/auth"
+	"booking-service/pkg/cache"
 	"booking-service/pkg/db"
 	"booking-service/pkg/kafka"
-	"log"
+	"booking-service/pkg/logging"
+	"booking-service/pkg/tracing"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
 )
 
 func main() {
-	// Load configuration
-	config := configs.LoadConfig("configs")
-	log.Printf("Starting app on port %s in %s mode", config.App.Port, config.App.Env)
+	// The config subcommand prints the configuration and exits
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfig("configs", os.LookupEnv, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Load configuration; errors go to stderr as logging is not set up yet
+	config, err := configs.Load("configs", os.LookupEnv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// Initialize structured logging before anything logs
+	logger, err := logging.Setup(logging.Config{
+		Level:      config.Log.Level,
+		Format:     config.Log.Format,
+		RedactKeys: config.Log.RedactKeys,
+	}, os.Stdout)
+	if err != nil {
+		fatal("Invalid logging configuration", err)
+	}
+	logger.Info("Starting app", "port", config.App.Port, "env", config.App.Env)
 
 	// Initialize database connection
 	database, err := db.Connect(
@@ -27,45 +78,291 @@ func main() {
 		config.Database.Name,
 	)
 	if err != nil {
-		log.Fatalf("Failed to connect to the database: %v", err)
+		fatal("Failed to connect to the database", err)
 	}
 
-	// Auto-migrate database models
-	err = database.AutoMigrate(
-		&models.Booking{},
-		&models.Ticket{},
-		&models.Event{},
-	)
+	// The migrate subcommand manages the schema and exits instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), database, config, os.Args[2:], os.Stdout); err != nil {
+			fatal("Migration failed", err)
+		}
+		return
+	}
+
+	// Initialize tracing before anything that creates spans
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: config.Tracing.ServiceName,
+		Exporter:    config.Tracing.Exporter,
+		Endpoint:    config.Tracing.Endpoint,
+		Insecure:    config.Tracing.Insecure,
+		SampleRatio: config.Tracing.SampleRatio,
+	})
+	if err != nil {
+		fatal("Failed to initialize tracing", err)
+	}
+
+	// Scope every query on tenant-owned models to the request's tenant
+	if err := database.Use(tenancy.Plugin{}); err != nil {
+		fatal("Failed to register tenancy plugin", err)
+	}
+
+	// Trace queries made while serving a traced request or message
+	if err := database.Use(otelgorm.NewPlugin(
+		otelgorm.WithTracerProvider(tracing.ChildOnly(otel.GetTracerProvider())),
+		otelgorm.WithoutMetrics(),
+		otelgorm.WithoutQueryVariables(),
+	)); err != nil {
+		fatal("Failed to register tracing plugin", err)
+	}
+
+	tenants, err := tenancy.NewRegistry(config.Tenants)
 	if err != nil {
-		log.Fatalf("Failed to auto-migrate database schemas: %v", err)
+		fatal("Invalid tenant configuration", err)
 	}
-	log.Println("Database auto-migration completed.")
+
+	// Apply pending migrations; other replicas wait on the lock meanwhile
+	if config.Database.MigrateOnStart {
+		if err := migrateOnStart(context.Background(), database, config); err != nil {
+			fatal("Failed to migrate the database", err)
+		}
+	}
+
+	// Initialize Redis client
+	redisClient := cache.NewClient(config.Redis.Host, config.Redis.Port)
 
 	// Initialize Kafka producer
 	kafkaProducer := kafka.NewProducer(config.Kafka.Broker)
 
+	// Reload runtime settings from the config file, then the database if enabled
+	settingsSources := []settings.Source{configs.SettingsSource("configs", os.LookupEnv)}
+	if config.Runtime.Database {
+		settingsSources = append(settingsSources, settings.DatabaseSource(repositories.NewSettingsRepository(database)))
+	}
+	runtimeSettings := settings.NewStore(&config.Runtime.Settings, settingsSources...)
+	if err := runtimeSettings.Reload(context.Background()); err != nil {
+		fatal("Failed to load runtime settings", err)
+	}
+
 	// Initialize repositories
 	bookingRepo := repositories.NewBookingRepository(database)
 	ticketRepo := repositories.NewTicketRepository(database)
+	pricingRepo := repositories.NewPricingRepository(database)
+	eventRepo := repositories.NewEventRepository(database)
+	outboxRepo := repositories.NewOutboxRepository(database)
+	webhookRepo := repositories.NewWebhookRepository(database)
+	transferRepo := repositories.NewTransferRepository(database)
+
+	// Initialize ticket credential signing
+	credentialIssuer, err := credentials.NewIssuer(credentials.Config{
+		Algorithm: config.Credentials.Algorithm,
+		ActiveKey: config.Credentials.ActiveKey,
+		Keys:      config.Credentials.Keys,
+	})
+	if err != nil {
+		fatal("Failed to initialize ticket credential issuer", err)
+	}
 
 	// Initialize services
 	ticketService := services.NewTicketService(ticketRepo)
-	bookingService := services.NewBookingService(bookingRepo, ticketService, kafkaProducer)
+	pricingService := services.NewPricingService(pricingRepo, ticketRepo)
+	eventService := services.NewEventService(eventRepo)
+	// Booking and ticket events go to Kafka through the outbox and to subscribed webhooks
+	domainEvents := webhooks.NewProducer(outbox.NewProducer(outboxRepo), webhookRepo)
+	bookingService := services.NewBookingService(bookingRepo, ticketService, pricingService, domainEvents, runtimeSettings)
+	webhookService := services.NewWebhookService(webhookRepo, webhooks.TargetPolicy{AllowPrivate: config.Webhooks.AllowPrivateTargets})
+	transferService := services.NewTransferService(transferRepo, ticketService, eventService, domainEvents, runtimeSettings)
+	credentialService := services.NewCredentialService(ticketRepo, credentialIssuer)
 
 	// Initialize controllers
 	bookingController := controllers.NewBookingController(bookingService)
-	ticketController := controllers.NewTicketController(ticketService)
+	ticketController := controllers.NewTicketController(ticketService, eventService)
+	pricingController := controllers.NewPricingController(pricingService, ticketService, eventService)
+	adminController := controllers.NewAdminController(bookingService)
+	webhookController := controllers.NewWebhookController(webhookService)
+	transferController := controllers.NewTransferController(transferService, ticketService)
+	credentialController := controllers.NewCredentialController(credentialService, ticketService, eventService)
+
+	// Initialize JWT verification
+	verifier, err := auth.NewVerifier(auth.Config{
+		Algorithm:  config.Auth.Algorithm,
+		HMACSecret: config.Auth.HMACSecret,
+		JWKSFile:   config.Auth.JWKSFile,
+		JWKSURL:    config.Auth.JWKSURL,
+		Issuer:     config.Auth.Issuer,
+		Audience:   config.Auth.Audience,
+	})
+	if err != nil {
+		fatal("Failed to initialize JWT verifier", err)
+	}
+
+	reconciler := reconcile.NewReconciler(bookingRepo, ticketRepo, reconcile.Config{
+		BatchSize: config.Reconciler.BatchSize,
+		MinAge:    config.Reconciler.MinAge,
+		Repair:    config.Reconciler.Repair,
+	})
+
+	lifecycleManager := lifecycle.NewManager(config.App.DrainDelay, config.App.ShutdownTimeout)
+
+	// Report ticket availability from the database on every scrape
+	prometheus.MustRegister(metrics.NewAvailableTicketsCollector(ticketRepo, config.Metrics.CollectTimeout))
 
 	// Set up Gin router
-	router := gin.Default()
-	apiRoutes := router.Group("/api")
+	// Server error details are only shown outside production
+	exposeErrorDetails := config.App.Env != "production"
+	router := gin.New()
+	// Rate limits key on the client IP, which only trusted proxies may forward
+	if err := router.SetTrustedProxies(config.App.TrustedProxies); err != nil {
+		fatal("Invalid trusted proxies", err)
+	}
+	router.Use(
+		middlewares.RequestID(),
+		middlewares.Logger(logger),
+		middlewares.Recovery(exposeErrorDetails),
+		middlewares.ErrorHandler(exposeErrorDetails),
+	)
+	router.Use(otelgin.Middleware(config.Tracing.ServiceName), metrics.Middleware())
+	router.GET("/metrics", metrics.Handler())
+
+	// Register health probes outside /api so they need no token
+	healthChecks := health.NewRegistry(config.Health.Timeout, config.Health.CacheTTL)
+	healthChecks.Register(
+		health.Postgres(database),
+		health.Redis(redisClient),
+		health.Kafka(config.Kafka.Broker),
+	)
+	controllers.NewHealthController(healthChecks, lifecycleManager.Ready).RegisterHealthRoutes(router)
 
-	// Register routes
-	controllers.RegisterBookingRoutes(apiRoutes, bookingController)
-	controllers.RegisterTicketRoutes(apiRoutes, ticketController)
+	apiDoc, err := openapi.Load()
+	if err != nil {
+		fatal("Failed to load OpenAPI document", err)
+	}
+	controllers.NewDocsController(apiDoc).RegisterDocsRoutes(router)
 
-	// Start the server
-	if err := router.Run(":" + config.App.Port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	apiRoutes := router.Group("/api",
+		middlewares.RateLimit(runtimeSettings),
+		middlewares.Timeout(config.App.RequestTimeout),
+		middlewares.Auth(verifier),
+		middlewares.Tenant(tenants),
+	)
+
+	// Register routes; a v2 mounted after v1 only registers the endpoints it changes
+	policy := authz.DefaultPolicy()
+	versions := apiversion.NewRouter(apiRoutes)
+	versions.Mount("v1", func(v1 *gin.RouterGroup) {
+		controllers.RegisterBookingRoutes(v1, bookingController)
+		controllers.RegisterTicketRoutes(v1, ticketController)
+		controllers.RegisterTransferRoutes(v1, transferController)
+		controllers.RegisterCredentialRoutes(v1, credentialController)
+		controllers.RegisterPricingRoutes(v1, pricingController)
+		controllers.RegisterAdminRoutes(v1, adminController)
+		controllers.RegisterWebhookRoutes(v1, webhookController)
+	},
+		middlewares.Authorize(policy.Mount("/api/v1")),
+		middlewares.ValidateRequest(apiDoc, "/api/v1"),
+	)
+	if config.API.LegacyRoutes {
+		versions.Alias("", "v1",
+			middlewares.Deprecated(middlewares.Deprecation{
+				Since:           config.API.LegacyDeprecatedAt,
+				Sunset:          config.API.LegacySunset,
+				Prefix:          "/api",
+				SuccessorPrefix: "/api/v1",
+			}),
+			middlewares.Authorize(policy.Mount("/api")),
+			middlewares.ValidateRequest(apiDoc, "/api"),
+		)
 	}
+
+	sqlDB, err := database.DB()
+	if err != nil {
+		fatal("Failed to get database instance", err)
+	}
+
+	// Registered in start order; shutdown runs in reverse so the HTTP server
+	// drains first and the outbox is flushed before Kafka and the DB close
+	lifecycleManager.Add(
+		lifecycle.ShutdownFunc("tracing", shutdownTracing),
+		lifecycle.Closer("database", sqlDB.Close),
+		lifecycle.Closer("redis", redisClient.Close),
+		lifecycle.Closer("kafka producer", kafkaProducer.Close),
+		outbox.NewRelay(outboxRepo, metrics.InstrumentProducer(kafkaProducer), outbox.RelayConfig{
+			Interval:    config.Kafka.Outbox.Interval,
+			BatchSize:   config.Kafka.Outbox.BatchSize,
+			MaxAttempts: config.Kafka.Outbox.MaxAttempts,
+		}),
+		webhooks.NewDispatcher(webhookRepo, webhooks.DispatcherConfig{
+			Workers:     config.Webhooks.Workers,
+			Interval:    config.Webhooks.Interval,
+			BatchSize:   config.Webhooks.BatchSize,
+			MaxAttempts: config.Webhooks.MaxAttempts,
+			Timeout:     config.Webhooks.Timeout,
+			MinBackoff:  config.Webhooks.MinBackoff,
+			MaxBackoff:  config.Webhooks.MaxBackoff,
+
+			AllowPrivateTargets: config.Webhooks.AllowPrivateTargets,
+		}),
+		lifecycle.Every("expire holds", config.Bookings.ExpiryInterval,
+			jobs.ExpireHolds(bookingService, tenants, runtimeSettings),
+			func(err error) { logger.Error("Failed to expire holds", "error", err) },
+		),
+		lifecycle.Every("reload settings", config.Runtime.ReloadInterval,
+			runtimeSettings.Reload,
+			func(err error) { logger.Error("Failed to reload runtime settings", "error", err) },
+		),
+		lifecycle.Every("reconcile", config.Reconciler.Interval,
+			jobs.Reconcile(reconciler, tenants),
+			func(err error) { logger.Error("Failed to reconcile bookings", "error", err) },
+		),
+	)
+	for _, tenant := range tenants.All() {
+		for _, eventType := range config.Kafka.ConsumeEvents {
+			lifecycleManager.Add(bookingEventConsumer(config.Kafka.Broker, config.Kafka.GroupID, tenant, eventType, ticketService))
+		}
+	}
+	lifecycleManager.Add(
+		lifecycle.HTTPServer(&http.Server{
+			Addr:    ":" + config.App.Port,
+			Handler: router,
+		}),
+		grpcapi.NewServer(grpcapi.Config{
+			Addr:          ":" + config.GRPC.Port,
+			Reflection:    config.GRPC.Reflection,
+			ExposeDetails: exposeErrorDetails,
+		}, grpcapi.Dependencies{
+			BookingService: bookingService,
+			TicketService:  ticketService,
+			EventService:   eventService,
+			Verifier:       verifier,
+			Tenants:        tenants,
+		}),
+	)
+
+	// Run until SIGINT or SIGTERM, then shut down gracefully
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := lifecycleManager.Run(ctx); err != nil {
+		fatal("Shutdown with errors", err)
+	}
+	logger.Info("Shutdown completed")
+}
+
+// fatal logs err and exits.
+func fatal(message string, err error) {
+	slog.Error(message, "error", err)
+	os.Exit(1)
+}
+
+// bookingEventConsumer consumes one booking event type from a tenant's topic
+// and applies it to tickets. Messages that fail are counted and skipped.
+func bookingEventConsumer(broker, groupID string, tenant tenancy.Tenant, eventType string, ticketService services.TicketService) lifecycle.Component {
+	topic := tenant.Topic(eventType)
+	consumer := kafka.NewConsumer(broker, topic, groupID)
+	return lifecycle.Worker("consumer "+topic, func(ctx context.Context) error {
+		ctx = tenancy.WithTenant(ctx, tenant)
+		return consumer.Consume(ctx, func(ctx context.Context, message []byte) error {
+			err := ticketService.HandleBookingEvent(ctx, eventType, kafka.ParseBookingEvent(message))
+			metrics.RecordConsumed(topic, err, consumer.Lag())
+			return err
+		})
+	})
 }
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"booking-service/configs"
+	"booking-service/pkg/db"
+	"booking-service/pkg/migrate"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const migrateUsage = `usage: booking-service migrate [-seeds] <command>
+
+commands:
+  up            apply all pending migrations
+  down [steps]  revert the last steps migrations (default 1)
+  status        list migrations and when they were applied`
+
+// migrateOnStart applies pending schema migrations, and seeds when enabled,
+// before the service starts serving.
+func migrateOnStart(ctx context.Context, database *gorm.DB, config *configs.Config) error {
+	migrator, err := db.NewMigrator(database, config.App.DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Schema migrations applied", "count", applied)
+
+	if !config.Database.Seed {
+		return nil
+	}
+	seeder, err := db.NewSeeder(database)
+	if err != nil {
+		return err
+	}
+	applied, err = seeder.Up(ctx)
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Seed migrations applied", "count", applied)
+	return nil
+}
+
+// runMigrate implements the migrate subcommand.
+func runMigrate(ctx context.Context, database *gorm.DB, config *configs.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(out)
+	seeds := flags.Bool("seeds", false, "operate on seed data instead of the schema")
+	flags.Usage = func() { fmt.Fprintln(out, migrateUsage) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("missing migrate command")
+	}
+
+	var migrator *migrate.Migrator
+	var err error
+	if *seeds {
+		migrator, err = db.NewSeeder(database)
+	} else {
+		migrator, err = db.NewMigrator(database, config.App.DefaultCurrency)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch command := flags.Arg(0); command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "applied %d migration(s)\n", applied)
+	case "down":
+		steps := 1
+		if flags.NArg() > 1 {
+			if steps, err = strconv.Atoi(flags.Arg(1)); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", flags.Arg(1))
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "reverted %d migration(s)\n", reverted)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		writeStatus(out, statuses)
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+	return nil
+}
+
+func writeStatus(out io.Writer, statuses []migrate.Status) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	_ = w.Flush()
+}
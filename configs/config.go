@@ -1,55 +1,238 @@
 package configs
 
 import (
-	"github.com/spf13/viper"
-	"log"
-	"sync"
+	"booking-service/internal/credentials"
+	"booking-service/internal/settings"
+	"booking-service/internal/tenancy"
+	"time"
 )
 
+// Config is the service configuration. Every key can be set in config.yaml;
+// keys with an env tag can be overridden by that environment variable, or by
+// a file named in the variable with a _FILE suffix. Keys with a secret tag
+// are masked when the configuration is printed redacted.
 type Config struct {
 	App struct {
-		Port string `mapstructure:"port"`
-		Env  string `mapstructure:"env"`
+		Port string `mapstructure:"port" env:"APP_PORT"`
+		Env  string `mapstructure:"env" env:"APP_ENV"`
+
+		// RequestTimeout bounds each API request, including its DB queries and Kafka writes.
+		RequestTimeout time.Duration `mapstructure:"request_timeout" env:"APP_REQUEST_TIMEOUT"`
+
+		// DrainDelay is how long readiness reports false before components stop,
+		// and ShutdownTimeout bounds stopping them all.
+		DrainDelay      time.Duration `mapstructure:"drain_delay" env:"APP_DRAIN_DELAY"`
+		ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" env:"APP_SHUTDOWN_TIMEOUT"`
+
+		// DefaultCurrency is the ISO 4217 code applied to legacy prices
+		// that were stored without a currency.
+		DefaultCurrency string `mapstructure:"default_currency" env:"APP_DEFAULT_CURRENCY"`
+
+		// TrustedProxies lists the IPs and CIDRs of reverse proxies whose
+		// X-Forwarded-For header names the client. Client IPs key rate limits,
+		// so with none trusted the peer address is used.
+		TrustedProxies []string `mapstructure:"trusted_proxies" env:"APP_TRUSTED_PROXIES"`
 	} `mapstructure:"app"`
 
+	GRPC struct {
+		Port       string `mapstructure:"port" env:"GRPC_PORT"`
+		Reflection bool   `mapstructure:"reflection" env:"GRPC_REFLECTION"` // let tools like grpcurl discover the services
+	} `mapstructure:"grpc"`
+
+	// REST routes are served under /api/v1. With LegacyRoutes the unversioned
+	// /api routes remain as deprecated aliases, and answer 410 Gone from
+	// LegacySunset on. Times are RFC 3339.
+	API struct {
+		LegacyRoutes       bool      `mapstructure:"legacy_routes" env:"API_LEGACY_ROUTES"`
+		LegacyDeprecatedAt time.Time `mapstructure:"legacy_deprecated_at" env:"API_LEGACY_DEPRECATED_AT"`
+		LegacySunset       time.Time `mapstructure:"legacy_sunset" env:"API_LEGACY_SUNSET"` // unset to keep serving them
+	} `mapstructure:"api"`
+
 	Database struct {
-		Host     string `mapstructure:"host"`
-		Port     string `mapstructure:"port"`
-		User     string `mapstructure:"user"`
-		Password string `mapstructure:"password"`
-		Name     string `mapstructure:"name"`
+		Host     string `mapstructure:"host" env:"DB_HOST"`
+		Port     string `mapstructure:"port" env:"DB_PORT"`
+		User     string `mapstructure:"user" env:"DB_USER"`
+		Password string `mapstructure:"password" env:"DB_PASSWORD" secret:"true"`
+		Name     string `mapstructure:"name" env:"DB_NAME"`
+
+		MigrateOnStart bool `mapstructure:"migrate_on_start" env:"DB_MIGRATE_ON_START"` // apply pending schema migrations at startup
+		Seed           bool `mapstructure:"seed" env:"DB_SEED"`                         // also apply seed migrations at startup
 	} `mapstructure:"database"`
 
 	Redis struct {
-		Host string `mapstructure:"host"`
-		Port string `mapstructure:"port"`
+		Host string `mapstructure:"host" env:"REDIS_HOST"`
+		Port string `mapstructure:"port" env:"REDIS_PORT"`
 	} `mapstructure:"redis"`
 
 	Kafka struct {
-		Broker string `mapstructure:"broker"`
+		Broker  string `mapstructure:"broker" env:"KAFKA_BROKER"`
+		GroupID string `mapstructure:"group_id" env:"KAFKA_GROUP_ID"`
+
+		// ConsumeEvents lists booking event types to consume from every tenant's topics.
+		ConsumeEvents []string `mapstructure:"consume_events" env:"KAFKA_CONSUME_EVENTS"`
+
+		Outbox struct {
+			Interval    time.Duration `mapstructure:"interval" env:"KAFKA_OUTBOX_INTERVAL"`
+			BatchSize   int           `mapstructure:"batch_size" env:"KAFKA_OUTBOX_BATCH_SIZE"`
+			MaxAttempts int           `mapstructure:"max_attempts" env:"KAFKA_OUTBOX_MAX_ATTEMPTS"`
+		} `mapstructure:"outbox"`
 	} `mapstructure:"kafka"`
+
+	Bookings struct {
+		ExpiryInterval time.Duration `mapstructure:"expiry_interval" env:"BOOKINGS_EXPIRY_INTERVAL"` // how often expired holds are swept
+	} `mapstructure:"bookings"`
+
+	// Webhooks are sent by Workers concurrently. Failed attempts are retried
+	// after MinBackoff, doubling up to MaxBackoff, until MaxAttempts.
+	Webhooks struct {
+		Workers     int           `mapstructure:"workers" env:"WEBHOOKS_WORKERS"`
+		Interval    time.Duration `mapstructure:"interval" env:"WEBHOOKS_INTERVAL"`     // how often due deliveries are polled
+		BatchSize   int           `mapstructure:"batch_size" env:"WEBHOOKS_BATCH_SIZE"` // deliveries claimed per poll
+		MaxAttempts int           `mapstructure:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS"`
+		Timeout     time.Duration `mapstructure:"timeout" env:"WEBHOOKS_TIMEOUT"` // per request
+		MinBackoff  time.Duration `mapstructure:"min_backoff" env:"WEBHOOKS_MIN_BACKOFF"`
+		MaxBackoff  time.Duration `mapstructure:"max_backoff" env:"WEBHOOKS_MAX_BACKOFF"`
+
+		// AllowPrivateTargets lets subscriptions reach loopback, private and
+		// link-local addresses. Only for development networks.
+		AllowPrivateTargets bool `mapstructure:"allow_private_targets" env:"WEBHOOKS_ALLOW_PRIVATE_TARGETS"`
+	} `mapstructure:"webhooks"`
+
+	// Runtime settings are reloaded every ReloadInterval from this file and,
+	// with Database, from the runtime_setting table, without a restart.
+	Runtime struct {
+		ReloadInterval time.Duration     `mapstructure:"reload_interval" env:"RUNTIME_RELOAD_INTERVAL"`
+		Database       bool              `mapstructure:"database" env:"RUNTIME_DATABASE"`
+		Settings       settings.Settings `mapstructure:"settings"`
+	} `mapstructure:"runtime"`
+
+	Reconciler struct {
+		Interval  time.Duration `mapstructure:"interval" env:"RECONCILER_INTERVAL"`
+		MinAge    time.Duration `mapstructure:"min_age" env:"RECONCILER_MIN_AGE"`       // skip bookings updated more recently than this
+		BatchSize int           `mapstructure:"batch_size" env:"RECONCILER_BATCH_SIZE"` // bookings loaded per query
+		Repair    bool          `mapstructure:"repair" env:"RECONCILER_REPAIR"`         // apply repair rules instead of only reporting
+	} `mapstructure:"reconciler"`
+
+	Log struct {
+		Level      string   `mapstructure:"level" env:"LOG_LEVEL"`             // debug, info, warn or error
+		Format     string   `mapstructure:"format" env:"LOG_FORMAT"`           // json or text
+		RedactKeys []string `mapstructure:"redact_keys" env:"LOG_REDACT_KEYS"` // extra attribute keys to mask
+	} `mapstructure:"log"`
+
+	Health struct {
+		Timeout  time.Duration `mapstructure:"timeout" env:"HEALTH_TIMEOUT"`     // per dependency check
+		CacheTTL time.Duration `mapstructure:"cache_ttl" env:"HEALTH_CACHE_TTL"` // how long a readiness report is reused
+	} `mapstructure:"health"`
+
+	Metrics struct {
+		CollectTimeout time.Duration `mapstructure:"collect_timeout" env:"METRICS_COLLECT_TIMEOUT"` // bounds database queries made during a scrape
+	} `mapstructure:"metrics"`
+
+	Tracing struct {
+		ServiceName string  `mapstructure:"service_name" env:"TRACING_SERVICE_NAME"`
+		Exporter    string  `mapstructure:"exporter" env:"TRACING_EXPORTER"` // none, stdout or otlp
+		Endpoint    string  `mapstructure:"endpoint" env:"TRACING_ENDPOINT"` // OTLP gRPC collector address
+		Insecure    bool    `mapstructure:"insecure" env:"TRACING_INSECURE"`
+		SampleRatio float64 `mapstructure:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	} `mapstructure:"tracing"`
+
+	Auth struct {
+		Algorithm  string `mapstructure:"algorithm" env:"AUTH_ALGORITHM"` // HS256 or RS256
+		HMACSecret string `mapstructure:"hmac_secret" env:"AUTH_HMAC_SECRET" secret:"true"`
+		JWKSFile   string `mapstructure:"jwks_file" env:"AUTH_JWKS_FILE"`
+		JWKSURL    string `mapstructure:"jwks_url" env:"AUTH_JWKS_URL"`
+		Issuer     string `mapstructure:"issuer" env:"AUTH_ISSUER"`
+		Audience   string `mapstructure:"audience" env:"AUTH_AUDIENCE"`
+	} `mapstructure:"auth"`
+
+	// Credentials sign the ticket credentials encoded in QR codes. To rotate,
+	// add a new key, make it active, and drop the old one once the credentials
+	// it signed are no longer needed.
+	Credentials struct {
+		Algorithm string   `mapstructure:"algorithm" env:"CREDENTIALS_ALGORITHM"` // EdDSA or HS256
+		ActiveKey string   `mapstructure:"active_key" env:"CREDENTIALS_ACTIVE_KEY"`
+		Keys      []string `mapstructure:"keys" env:"CREDENTIALS_KEYS" secret:"true"` // id:base64 pairs
+	} `mapstructure:"credentials"`
+
+	Tenants []tenancy.Tenant `mapstructure:"tenants"`
 }
 
-var (
-	instance *Config
-	once     sync.Once
-)
+// Defaults returns the configuration used for keys missing from config.yaml.
+// Secrets have no defaults.
+func Defaults() *Config {
+	c := &Config{}
+	c.App.Port = "8080"
+	c.App.Env = "development"
+	c.App.RequestTimeout = 10 * time.Second
+	c.App.DrainDelay = 5 * time.Second
+	c.App.ShutdownTimeout = 30 * time.Second
+	c.App.DefaultCurrency = "VND"
+	c.App.TrustedProxies = []string{}
+
+	c.GRPC.Port = "9090"
+	c.GRPC.Reflection = true
+
+	c.Database.Host = "localhost"
+	c.Database.Port = "5432"
+	c.Database.User = "postgres"
+	c.Database.Name = "booking"
+	c.Database.MigrateOnStart = true
+
+	c.Redis.Host = "localhost"
+	c.Redis.Port = "6379"
+
+	c.Kafka.Broker = "localhost:9092"
+	c.Kafka.GroupID = "booking-service"
+	c.Kafka.ConsumeEvents = []string{}
+	c.Kafka.Outbox.Interval = time.Second
+	c.Kafka.Outbox.BatchSize = 100
+	c.Kafka.Outbox.MaxAttempts = 10
+
+	c.Bookings.ExpiryInterval = time.Minute
+
+	c.Webhooks.Workers = 4
+	c.Webhooks.Interval = time.Second
+	c.Webhooks.BatchSize = 50
+	c.Webhooks.MaxAttempts = 8
+	c.Webhooks.Timeout = 10 * time.Second
+	c.Webhooks.MinBackoff = 30 * time.Second
+	c.Webhooks.MaxBackoff = time.Hour
+
+	c.Runtime.ReloadInterval = 10 * time.Second
+	c.Runtime.Settings.HoldTTL = 15 * time.Minute
+	c.Runtime.Settings.MaxTicketsPerBooking = 10
+	c.Runtime.Settings.TransferTTL = 48 * time.Hour
+	c.Runtime.Settings.TransferCutoff = 24 * time.Hour
+	c.Runtime.Settings.RateLimit.RequestsPerSecond = 20
+	c.Runtime.Settings.RateLimit.Burst = 40
+	c.Runtime.Settings.Features = map[string]bool{settings.FeatureBookings: true}
+
+	c.Reconciler.Interval = 15 * time.Minute
+	c.Reconciler.MinAge = 5 * time.Minute
+	c.Reconciler.BatchSize = 500
+
+	c.Log.Level = "info"
+	c.Log.Format = "json"
+	c.Log.RedactKeys = []string{}
+
+	c.Health.Timeout = 2 * time.Second
+	c.Health.CacheTTL = 5 * time.Second
+
+	c.Metrics.CollectTimeout = 2 * time.Second
+
+	c.Tracing.ServiceName = "booking-service"
+	c.Tracing.Exporter = "none"
+	c.Tracing.Endpoint = "localhost:4317"
+	c.Tracing.Insecure = true
+	c.Tracing.SampleRatio = 1
+
+	c.Auth.Algorithm = "HS256"
+	c.Auth.Issuer = "booking-service"
+	c.Auth.Audience = "booking-api"
+
+	c.Credentials.Algorithm = credentials.AlgorithmEdDSA
+	c.Credentials.Keys = []string{}
 
-func LoadConfig(configPath string) *Config {
-	once.Do(func() {
-		viper.SetConfigName("config")
-		viper.SetConfigType("yaml")
-		viper.AddConfigPath(configPath)
-		viper.AutomaticEnv()
-
-		if err := viper.ReadInConfig(); err != nil {
-			log.Fatalf("Error reading config file: %v", err)
-		}
-
-		instance = &Config{}
-		if err := viper.Unmarshal(instance); err != nil {
-			log.Fatalf("Error unmarshalling config: %v", err)
-		}
-	})
-	return instance
+	c.Tenants = []tenancy.Tenant{{ID: tenancy.DefaultTenantID, Hosts: []string{"localhost"}, Currency: c.App.DefaultCurrency}}
+	return c
 }
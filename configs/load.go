@@ -0,0 +1,146 @@
+package configs
+
+import (
+	"booking-service/internal/settings"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// LookupEnv looks up an environment variable, like os.LookupEnv.
+type LookupEnv func(key string) (string, bool)
+
+// Load reads config.yaml from dir over the defaults, applies environment
+// overrides and validates the result. It does not touch viper's global
+// instance, so it can be called repeatedly with different inputs.
+func Load(dir string, lookupEnv LookupEnv) (*Config, error) {
+	config, err := Read(dir, lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Read is Load without validation.
+func Read(dir string, lookupEnv LookupEnv) (*Config, error) {
+	v := viper.New()
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(dir)
+
+	walk(reflect.ValueOf(Defaults()).Elem(), "", func(key string, _ reflect.StructField, value reflect.Value) {
+		v.SetDefault(key, value.Interface())
+	})
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var envErrs []error
+	walk(reflect.ValueOf(&Config{}).Elem(), "", func(key string, field reflect.StructField, _ reflect.Value) {
+		name := field.Tag.Get("env")
+		if name == "" {
+			return
+		}
+		value, ok, err := lookup(lookupEnv, name)
+		if err != nil {
+			envErrs = append(envErrs, err)
+		} else if ok {
+			v.Set(key, value)
+		}
+	})
+	if err := errors.Join(envErrs...); err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	hooks := mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		stringToTime,
+	)
+	if err := v.Unmarshal(config, viper.DecodeHook(hooks)); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+	return config, nil
+}
+
+// lookup returns the value of the environment variable name, or the content
+// of the file named by name_FILE. Trailing newlines are trimmed from files,
+// which editors and secret mounts tend to add.
+func lookup(lookupEnv LookupEnv, name string) (string, bool, error) {
+	value, ok := lookupEnv(name)
+	path, fromFile := lookupEnv(name + "_FILE")
+	switch {
+	case ok && fromFile:
+		return "", false, fmt.Errorf("both %s and %s_FILE are set", name, name)
+	case ok:
+		return value, true, nil
+	case fromFile:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read %s_FILE: %w", name, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// stringToTime decodes RFC 3339 strings into times; an empty string is the
+// zero time, which means unset.
+func stringToTime(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to != timeType {
+		return data, nil
+	}
+	if data.(string) == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, data.(string))
+}
+
+// walk calls fn for every leaf key of a config struct, with its dotted key,
+// field and value. Nested structs other than times are descended into;
+// everything else, including slices of structs such as tenants, is a leaf.
+func walk(v reflect.Value, prefix string, fn func(key string, field reflect.StructField, value reflect.Value)) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			walk(v.Field(i), key+".", fn)
+			continue
+		}
+		fn(key, field, v.Field(i))
+	}
+}
+
+// SettingsSource re-reads the runtime settings from config.yaml in dir, with
+// environment overrides, so edits to the file apply on the next reload.
+func SettingsSource(dir string, lookupEnv LookupEnv) settings.Source {
+	return func(ctx context.Context, _ *settings.Settings) (*settings.Settings, error) {
+		config, err := Read(dir, lookupEnv)
+		if err != nil {
+			return nil, err
+		}
+		return &config.Runtime.Settings, nil
+	}
+}
@@ -0,0 +1,68 @@
+package configs
+
+import (
+	"io"
+	"reflect"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// Print writes the configuration as YAML, in the layout of config.yaml. With
+// redact, values of secret keys are masked.
+func (c *Config) Print(w io.Writer, redact bool) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(toNode(reflect.ValueOf(c).Elem(), redact, false)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// toNode converts a config value to YAML using its mapstructure keys.
+func toNode(v reflect.Value, redact, secret bool) *yaml.Node {
+	switch {
+	case secret && redact && !v.IsZero():
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: redacted}
+	case v.Type() == durationType:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: v.Interface().(time.Duration).String()}
+	case v.Type() == timeType:
+		value := ""
+		if t := v.Interface().(time.Time); !t.IsZero() {
+			value = t.Format(time.RFC3339)
+		}
+		node := &yaml.Node{}
+		_ = node.Encode(value)
+		return node
+	case v.Kind() == reflect.Struct:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name := field.Tag.Get("mapstructure")
+			if name == "" {
+				continue
+			}
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: name},
+				toNode(v.Field(i), redact, field.Tag.Get("secret") == "true"))
+		}
+		return node
+	case v.Kind() == reflect.Slice:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for i := 0; i < v.Len(); i++ {
+			element := toNode(v.Index(i), redact, secret)
+			if element.Kind != yaml.ScalarNode {
+				node.Style = 0
+			}
+			node.Content = append(node.Content, element)
+		}
+		return node
+	default:
+		// Strings, numbers and booleans always encode
+		node := &yaml.Node{}
+		_ = node.Encode(v.Interface())
+		return node
+	}
+}
@@ -0,0 +1,188 @@
+package configs
+
+import (
+	"booking-service/internal/credentials"
+	"booking-service/pkg/auth"
+	"booking-service/pkg/money"
+	"booking-service/pkg/tracing"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// insecureHMACSecret is the placeholder secret shipped in config.yaml.
+const insecureHMACSecret = "change-me-in-production"
+
+// insecureCredentialKey is the placeholder credential key shipped in
+// config.yaml.
+const insecureCredentialKey = "dev:7HoFAqUtiIwgPp0M5dzySIwlALPRtDJVmIAnk1hN6xA="
+
+// ValidationError lists every problem found in a configuration, so they can
+// all be fixed in one go.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// validator collects problems as keys are checked.
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, key, format string, args ...interface{}) {
+	if !ok {
+		v.problems = append(v.problems, key+": "+fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) required(key, value string) {
+	v.check(value != "", key, "is required")
+}
+
+func (v *validator) port(key, value string) {
+	port, err := strconv.Atoi(value)
+	v.check(err == nil && port > 0 && port <= 65535, key, "must be a port number, got %q", value)
+}
+
+func (v *validator) positive(key string, value time.Duration) {
+	v.check(value > 0, key, "must be positive, got %s", value)
+}
+
+func (v *validator) nonNegative(key string, value time.Duration) {
+	v.check(value >= 0, key, "cannot be negative, got %s", value)
+}
+
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+func isIPOrCIDR(value string) bool {
+	if net.ParseIP(value) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
+// Validate checks that the configuration can start the service. It returns
+// a *ValidationError listing every problem found.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.port("app.port", c.App.Port)
+	v.required("app.env", c.App.Env)
+	v.positive("app.request_timeout", c.App.RequestTimeout)
+	v.nonNegative("app.drain_delay", c.App.DrainDelay)
+	v.positive("app.shutdown_timeout", c.App.ShutdownTimeout)
+	v.check(money.IsSupported(c.App.DefaultCurrency), "app.default_currency", "unsupported currency %q", c.App.DefaultCurrency)
+	for _, proxy := range c.App.TrustedProxies {
+		v.check(isIPOrCIDR(proxy), "app.trusted_proxies", "must be IPs or CIDRs, got %q", proxy)
+	}
+
+	v.port("grpc.port", c.GRPC.Port)
+	v.check(c.GRPC.Port != c.App.Port, "grpc.port", "must differ from app.port")
+
+	if c.API.LegacyRoutes {
+		v.check(!c.API.LegacyDeprecatedAt.IsZero(), "api.legacy_deprecated_at", "is required with api.legacy_routes")
+		v.check(c.API.LegacySunset.IsZero() || c.API.LegacySunset.After(c.API.LegacyDeprecatedAt),
+			"api.legacy_sunset", "must be after api.legacy_deprecated_at")
+	}
+
+	v.required("database.host", c.Database.Host)
+	v.port("database.port", c.Database.Port)
+	v.required("database.user", c.Database.User)
+	v.required("database.name", c.Database.Name)
+
+	v.required("redis.host", c.Redis.Host)
+	v.port("redis.port", c.Redis.Port)
+
+	v.required("kafka.broker", c.Kafka.Broker)
+	v.required("kafka.group_id", c.Kafka.GroupID)
+	v.positive("kafka.outbox.interval", c.Kafka.Outbox.Interval)
+	v.check(c.Kafka.Outbox.BatchSize > 0, "kafka.outbox.batch_size", "must be positive, got %d", c.Kafka.Outbox.BatchSize)
+	v.check(c.Kafka.Outbox.MaxAttempts > 0, "kafka.outbox.max_attempts", "must be positive, got %d", c.Kafka.Outbox.MaxAttempts)
+
+	v.positive("bookings.expiry_interval", c.Bookings.ExpiryInterval)
+
+	v.check(c.Webhooks.Workers > 0, "webhooks.workers", "must be positive, got %d", c.Webhooks.Workers)
+	v.positive("webhooks.interval", c.Webhooks.Interval)
+	v.check(c.Webhooks.BatchSize > 0, "webhooks.batch_size", "must be positive, got %d", c.Webhooks.BatchSize)
+	v.check(c.Webhooks.MaxAttempts > 0, "webhooks.max_attempts", "must be positive, got %d", c.Webhooks.MaxAttempts)
+	v.positive("webhooks.timeout", c.Webhooks.Timeout)
+	v.positive("webhooks.min_backoff", c.Webhooks.MinBackoff)
+	v.check(c.Webhooks.MaxBackoff >= c.Webhooks.MinBackoff, "webhooks.max_backoff", "must be at least webhooks.min_backoff")
+
+	v.positive("runtime.reload_interval", c.Runtime.ReloadInterval)
+	for _, problem := range c.Runtime.Settings.Problems() {
+		v.problems = append(v.problems, "runtime.settings."+problem)
+	}
+
+	v.positive("reconciler.interval", c.Reconciler.Interval)
+	v.nonNegative("reconciler.min_age", c.Reconciler.MinAge)
+	v.check(c.Reconciler.BatchSize > 0, "reconciler.batch_size", "must be positive, got %d", c.Reconciler.BatchSize)
+
+	v.oneOf("log.level", strings.ToLower(c.Log.Level), "debug", "info", "warn", "error")
+	v.oneOf("log.format", c.Log.Format, "json", "text")
+
+	v.positive("health.timeout", c.Health.Timeout)
+	v.nonNegative("health.cache_ttl", c.Health.CacheTTL)
+	v.positive("metrics.collect_timeout", c.Metrics.CollectTimeout)
+
+	v.required("tracing.service_name", c.Tracing.ServiceName)
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP)
+	if c.Tracing.Exporter == tracing.ExporterOTLP {
+		v.required("tracing.endpoint", c.Tracing.Endpoint)
+	}
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	v.oneOf("auth.algorithm", c.Auth.Algorithm, auth.AlgorithmHS256, auth.AlgorithmRS256)
+	switch c.Auth.Algorithm {
+	case auth.AlgorithmHS256:
+		v.required("auth.hmac_secret", c.Auth.HMACSecret)
+		if c.App.Env == "production" {
+			v.check(c.Auth.HMACSecret != insecureHMACSecret, "auth.hmac_secret", "must be changed from the example value in production")
+		}
+	case auth.AlgorithmRS256:
+		v.check(c.Auth.JWKSFile != "" || c.Auth.JWKSURL != "", "auth.jwks_file", "or auth.jwks_url is required for RS256")
+	}
+
+	v.oneOf("credentials.algorithm", c.Credentials.Algorithm, credentials.AlgorithmEdDSA, credentials.AlgorithmHS256)
+	v.required("credentials.active_key", c.Credentials.ActiveKey)
+	v.check(len(c.Credentials.Keys) > 0, "credentials.keys", "at least one key is required")
+	if c.Credentials.ActiveKey != "" && len(c.Credentials.Keys) > 0 {
+		_, err := credentials.NewIssuer(credentials.Config{
+			Algorithm: c.Credentials.Algorithm,
+			ActiveKey: c.Credentials.ActiveKey,
+			Keys:      c.Credentials.Keys,
+		})
+		v.check(err == nil, "credentials.keys", "%v", err)
+	}
+	if c.App.Env == "production" {
+		for _, key := range c.Credentials.Keys {
+			v.check(key != insecureCredentialKey, "credentials.keys", "must be changed from the example value in production")
+		}
+	}
+
+	v.check(len(c.Tenants) > 0, "tenants", "at least one tenant is required")
+	for i, tenant := range c.Tenants {
+		key := fmt.Sprintf("tenants[%d]", i)
+		v.required(key+".id", tenant.ID)
+		v.check(money.IsSupported(tenant.Currency), key+".currency", "unsupported currency %q", tenant.Currency)
+		v.check(tenant.FeeBps >= 0, key+".fee_bps", "cannot be negative, got %d", tenant.FeeBps)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package admin
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/reconcile"
+	"booking-service/internal/repositories"
+	"booking-service/internal/services"
+	"booking-service/pkg/money"
+	"booking-service/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// Admin implements operator tasks on top of the services and repositories
+// the API uses. In dry-run mode every task reports what it would change
+// without writing anything.
+type Admin struct {
+	BookingService services.BookingService
+	TicketService  services.TicketService
+	EventService   services.EventService
+	PricingService services.PricingService
+	TicketRepo     repositories.TicketRepository
+	OutboxRepo     repositories.OutboxRepository
+	Reconciler     *reconcile.Reconciler
+	DryRun         bool
+	Logger         *slog.Logger
+}
+
+func New(
+	bookingService services.BookingService,
+	ticketService services.TicketService,
+	eventService services.EventService,
+	pricingService services.PricingService,
+	ticketRepo repositories.TicketRepository,
+	outboxRepo repositories.OutboxRepository,
+	reconciler *reconcile.Reconciler,
+	dryRun bool,
+) *Admin {
+	return &Admin{
+		BookingService: bookingService,
+		TicketService:  ticketService,
+		EventService:   eventService,
+		PricingService: pricingService,
+		TicketRepo:     ticketRepo,
+		OutboxRepo:     outboxRepo,
+		Reconciler:     reconciler,
+		DryRun:         dryRun,
+		Logger:         slog.Default(),
+	}
+}
+
+// GeneratedTickets is the outcome of GenerateTickets.
+type GeneratedTickets struct {
+	DryRun  bool            `json:"dry_run"`
+	EventID uint            `json:"event_id"`
+	TierID  uint            `json:"tier_id,omitempty"`
+	Count   int             `json:"count"`
+	Price   money.Money     `json:"price"`
+	Tickets []models.Ticket `json:"tickets,omitempty"`
+}
+
+// GenerateTickets creates count tickets for an event at a fixed price, or for
+// a pricing tier when tierID is set.
+func (a *Admin) GenerateTickets(ctx context.Context, eventID, tierID uint, count int, price money.Money) (*GeneratedTickets, error) {
+	if count <= 0 {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidTicketCount, "Invalid ticket count", fmt.Sprintf("Count %d must be positive", count))
+	}
+
+	result := &GeneratedTickets{DryRun: a.DryRun, EventID: eventID, TierID: tierID, Count: count, Price: price}
+	if tierID != 0 {
+		tier, err := a.PricingService.GetTierByID(ctx, tierID)
+		if err != nil {
+			return nil, err
+		}
+		result.EventID = tier.EventID
+		result.Price = tier.BasePrice
+		if a.DryRun {
+			return result, nil
+		}
+		result.Tickets, err = a.PricingService.CreateTicketsForTier(ctx, tierID, count)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := a.EventService.GetEventByID(ctx, eventID); err != nil {
+			return nil, err
+		}
+		if a.DryRun {
+			return result, nil
+		}
+		var err error
+		result.Tickets, err = a.TicketService.CreateTicketsForEvent(ctx, eventID, count, price)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	a.Logger.InfoContext(ctx, "Generated tickets", "event_id", result.EventID, "tier_id", tierID, "count", count)
+	return result, nil
+}
+
+// BookingChange is the outcome of a forced booking status change.
+type BookingChange struct {
+	DryRun    bool                 `json:"dry_run"`
+	BookingID uint                 `json:"booking_id"`
+	From      models.BookingStatus `json:"from"`
+	To        models.BookingStatus `json:"to"`
+	TicketIDs []uint               `json:"ticket_ids"`
+}
+
+// ForceBookingStatus confirms a pending booking or cancels a pending or
+// confirmed one, updating its tickets and publishing the booking event as the
+// API would.
+func (a *Admin) ForceBookingStatus(ctx context.Context, bookingID uint, status models.BookingStatus) (*BookingChange, error) {
+	if status != models.BookingStatusConfirmed && status != models.BookingStatusCanceled {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidBookingStatus, "Invalid booking status", fmt.Sprintf("Cannot force status %q", status))
+	}
+	booking, err := a.BookingService.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &BookingChange{DryRun: a.DryRun, BookingID: bookingID, From: booking.Status, To: status, TicketIDs: []uint{}}
+	for _, ticket := range booking.Tickets {
+		change.TicketIDs = append(change.TicketIDs, ticket.ID)
+	}
+	if a.DryRun {
+		return change, nil
+	}
+
+	if status == models.BookingStatusConfirmed {
+		err = a.BookingService.ConfirmBooking(ctx, bookingID)
+	} else {
+		err = a.BookingService.CancelBooking(ctx, bookingID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.Logger.WarnContext(ctx, "Forced booking status", "booking_id", bookingID, "from", change.From, "to", status)
+	return change, nil
+}
+
+// ReleasedTickets is the outcome of ReleaseOrphanedTickets.
+type ReleasedTickets struct {
+	DryRun   bool            `json:"dry_run"`
+	Released int64           `json:"released"`
+	Tickets  []models.Ticket `json:"tickets"`
+}
+
+// ReleaseOrphanedTickets puts held tickets back on sale when no live booking
+// accounts for them, in all events when eventID is zero.
+func (a *Admin) ReleaseOrphanedTickets(ctx context.Context, eventID uint) (*ReleasedTickets, error) {
+	tickets, err := a.TicketRepo.ListOrphanedTickets(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReleasedTickets{DryRun: a.DryRun, Tickets: tickets}
+	if a.DryRun || len(tickets) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uint, 0, len(tickets))
+	for _, ticket := range tickets {
+		ids = append(ids, ticket.ID)
+	}
+	if result.Released, err = a.TicketRepo.ReleaseOrphanedTickets(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	a.Logger.WarnContext(ctx, "Released orphaned tickets", "event_id", eventID, "count", result.Released)
+	return result, nil
+}
+
+// ReplayedMessages is the outcome of ReplayOutbox.
+type ReplayedMessages struct {
+	DryRun   bool                   `json:"dry_run"`
+	Requeued int64                  `json:"requeued"`
+	Messages []models.OutboxMessage `json:"messages"`
+}
+
+// ReplayOutbox requeues outbox messages so the relay publishes them again.
+// With no IDs it replays up to limit messages that exhausted their attempts,
+// which is where messages the broker kept rejecting end up.
+func (a *Admin) ReplayOutbox(ctx context.Context, ids []uint, limit int) (*ReplayedMessages, error) {
+	status := models.OutboxStatusFailed
+	if len(ids) > 0 {
+		status, limit = "", len(ids)
+	}
+	messages, err := a.OutboxRepo.List(ctx, status, ids, limit)
+	if err != nil {
+		return nil, utils.NewAppError(http.StatusInternalServerError, utils.CodeInternal, "Failed to list outbox messages", err.Error())
+	}
+
+	result := &ReplayedMessages{DryRun: a.DryRun, Messages: messages}
+	if a.DryRun || len(messages) == 0 {
+		return result, nil
+	}
+
+	found := make([]uint, 0, len(messages))
+	for _, message := range messages {
+		found = append(found, message.ID)
+	}
+	if result.Requeued, err = a.OutboxRepo.Requeue(ctx, found); err != nil {
+		return nil, utils.NewAppError(http.StatusInternalServerError, utils.CodeInternal, "Failed to requeue outbox messages", err.Error())
+	}
+
+	a.Logger.InfoContext(ctx, "Requeued outbox messages", "count", result.Requeued)
+	return result, nil
+}
+
+// Reconcile reports inconsistencies between tickets and bookings, repairing
+// them when repair is set and this is not a dry run.
+func (a *Admin) Reconcile(ctx context.Context, repair bool) (*reconcile.Report, error) {
+	reconciler := *a.Reconciler
+	reconciler.Config.Repair = repair && !a.DryRun
+	return reconciler.Run(ctx)
+}
+
+// Booking returns a booking with its tickets and locked prices.
+func (a *Admin) Booking(ctx context.Context, bookingID uint) (*models.Booking, error) {
+	return a.BookingService.GetBookingByID(ctx, bookingID)
+}
+
+// Ticket returns a ticket.
+func (a *Admin) Ticket(ctx context.Context, ticketID uint) (*models.Ticket, error) {
+	return a.TicketService.GetTicketByID(ctx, ticketID)
+}
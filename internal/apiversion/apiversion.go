@@ -0,0 +1,115 @@
+// Package apiversion serves REST API versions side by side, e.g. /api/v1 and
+// /api/v2, where each version only registers the endpoints it changes.
+package apiversion
+
+import (
+	"booking-service/utils"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Register adds routes relative to the root of a version.
+type Register func(router *gin.RouterGroup)
+
+type route struct {
+	method, path string
+	handler      gin.HandlerFunc
+}
+
+type version struct {
+	name   string
+	routes []route
+}
+
+// Router mounts versions below a common group such as /api.
+type Router struct {
+	group    *gin.RouterGroup
+	versions []version
+}
+
+func NewRouter(group *gin.RouterGroup) *Router {
+	return &Router{group: group}
+}
+
+// Mount serves a version at /<name> below the router's group. The routes
+// register adds replace those of the previously mounted version with the same
+// method and path; the others are inherited unchanged, so v2 only registers
+// the endpoints whose contract changed. middleware runs for every route of the
+// version, inherited or not.
+//
+// Only the final handler of an inherited route is kept, so per-route
+// middleware belongs in middleware rather than in register.
+func (r *Router) Mount(name string, register Register, middleware ...gin.HandlerFunc) *gin.RouterGroup {
+	routes := collect(register)
+	if len(r.versions) > 0 {
+		routes = inherit(r.versions[len(r.versions)-1].routes, routes)
+	}
+	r.versions = append(r.versions, version{name: name, routes: routes})
+	return r.serve("/"+name, routes, middleware)
+}
+
+// Alias serves the routes of a mounted version again at relativePath, for
+// clients of paths that predate versioning. middleware typically includes
+// middlewares.Deprecated.
+func (r *Router) Alias(relativePath, name string, middleware ...gin.HandlerFunc) *gin.RouterGroup {
+	for _, v := range r.versions {
+		if v.name == name {
+			return r.serve(relativePath, v.routes, middleware)
+		}
+	}
+	panic(fmt.Sprintf("apiversion: alias of unmounted version %q", name))
+}
+
+// Versions returns the names of the mounted versions, oldest first.
+func (r *Router) Versions() []string {
+	names := make([]string, len(r.versions))
+	for i, v := range r.versions {
+		names[i] = v.name
+	}
+	return names
+}
+
+func (r *Router) serve(relativePath string, routes []route, middleware []gin.HandlerFunc) *gin.RouterGroup {
+	group := r.group.Group(relativePath, middleware...)
+	for _, rt := range routes {
+		group.Handle(rt.method, rt.path, rt.handler)
+	}
+	return group
+}
+
+// collect registers into a scratch engine to learn the routes of register
+// without serving them.
+func collect(register Register) []route {
+	engine := gin.New()
+	register(&engine.RouterGroup)
+	infos := engine.Routes()
+	routes := make([]route, len(infos))
+	for i, info := range infos {
+		routes[i] = route{method: info.Method, path: info.Path, handler: info.HandlerFunc}
+	}
+	return routes
+}
+
+// inherit returns the routes of previous that changed does not redefine,
+// followed by changed.
+func inherit(previous, changed []route) []route {
+	redefined := make(map[string]bool, len(changed))
+	for _, rt := range changed {
+		redefined[rt.method+" "+rt.path] = true
+	}
+	var routes []route
+	for _, rt := range previous {
+		if !redefined[rt.method+" "+rt.path] {
+			routes = append(routes, rt)
+		}
+	}
+	return append(routes, changed...)
+}
+
+// Removed is a handler for routes a version drops; register it in place of
+// the old route so it is not inherited.
+func Removed(c *gin.Context) {
+	utils.AbortWithError(c, utils.NewAppError(http.StatusGone, utils.CodeEndpointRemoved, "Endpoint removed in this API version", ""))
+}
@@ -0,0 +1,199 @@
+package authz
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type Role string
+
+const (
+	RoleCustomer  Role = "customer"
+	RoleOrganizer Role = "organizer"
+	RoleSupport   Role = "support"
+	RoleAdmin     Role = "admin"
+)
+
+type Permission string
+
+const (
+	PermBookingCreate       Permission = "bookings:create"
+	PermBookingRead         Permission = "bookings:read"
+	PermBookingCancel       Permission = "bookings:cancel"
+	PermBookingUpdateStatus Permission = "bookings:update_status"
+	PermBookingSearch       Permission = "bookings:search"
+	PermTicketCreate        Permission = "tickets:create"
+	PermTicketRead          Permission = "tickets:read"
+	PermTicketTransfer      Permission = "tickets:transfer"
+	PermTicketCredential    Permission = "tickets:credential"
+	PermTicketVerify        Permission = "tickets:verify"
+	PermPricingManage       Permission = "pricing:manage"
+	PermPricingQuote        Permission = "pricing:quote"
+	PermWebhookManage       Permission = "webhooks:manage"
+)
+
+// Scope limits which resources a granted permission applies to.
+type Scope int
+
+const (
+	ScopeNone Scope = iota
+	// ScopeOwn restricts the permission to resources the caller owns: their
+	// bookings and tickets for customers, their events for organizers.
+	ScopeOwn
+	ScopeAll
+)
+
+var rolePermissions = map[Role]map[Permission]Scope{
+	RoleCustomer: {
+		PermBookingCreate:    ScopeOwn,
+		PermBookingRead:      ScopeOwn,
+		PermBookingCancel:    ScopeOwn,
+		PermTicketRead:       ScopeOwn,
+		PermTicketTransfer:   ScopeOwn,
+		PermTicketCredential: ScopeOwn,
+		PermPricingQuote:     ScopeAll,
+	},
+	RoleOrganizer: {
+		PermTicketCreate:  ScopeOwn,
+		PermTicketRead:    ScopeAll,
+		PermTicketVerify:  ScopeOwn,
+		PermPricingManage: ScopeOwn,
+		PermPricingQuote:  ScopeAll,
+	},
+	RoleSupport: {
+		PermBookingRead:         ScopeAll,
+		PermBookingCancel:       ScopeAll,
+		PermBookingUpdateStatus: ScopeAll,
+		PermBookingSearch:       ScopeAll,
+		PermTicketRead:          ScopeAll,
+		PermTicketTransfer:      ScopeAll,
+		PermTicketCredential:    ScopeAll,
+		PermTicketVerify:        ScopeAll,
+		PermPricingQuote:        ScopeAll,
+	},
+	RoleAdmin: {
+		PermBookingCreate:       ScopeAll,
+		PermBookingRead:         ScopeAll,
+		PermBookingCancel:       ScopeAll,
+		PermBookingUpdateStatus: ScopeAll,
+		PermBookingSearch:       ScopeAll,
+		PermTicketCreate:        ScopeAll,
+		PermTicketRead:          ScopeAll,
+		PermTicketTransfer:      ScopeAll,
+		PermTicketCredential:    ScopeAll,
+		PermTicketVerify:        ScopeAll,
+		PermPricingManage:       ScopeAll,
+		PermPricingQuote:        ScopeAll,
+		PermWebhookManage:       ScopeAll,
+	},
+}
+
+// ScopeFor returns the scope a role holds for a permission.
+func (r Role) ScopeFor(permission Permission) Scope {
+	return rolePermissions[r][permission]
+}
+
+// Principal is the authenticated caller together with the scope granted for
+// the permission of the current route.
+type Principal struct {
+	UserID uint
+	Role   Role
+	Scope  Scope
+}
+
+// Owns reports whether the principal may act on a resource owned by ownerID.
+func (p Principal) Owns(ownerID uint) bool {
+	return p.Scope == ScopeAll || (p.Scope == ScopeOwn && p.UserID == ownerID)
+}
+
+// OwnsOptional is Owns for resources whose owner may be unset; only ScopeAll
+// grants access to unowned resources.
+func (p Principal) OwnsOptional(ownerID *uint) bool {
+	if ownerID == nil {
+		return p.Scope == ScopeAll
+	}
+	return p.Owns(*ownerID)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the principal, for callers outside
+// gin such as the gRPC server.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored by WithPrincipal. Without
+// one the caller is treated as having no access.
+func PrincipalFromContext(ctx context.Context) Principal {
+	principal, _ := ctx.Value(principalKey{}).(Principal)
+	return principal
+}
+
+// Policy maps "METHOD /route/pattern" to the permission it requires.
+type Policy map[string]Permission
+
+// DefaultPolicy covers every REST route, relative to the root of an API
+// version. Mount it at the prefix the routes are served under.
+func DefaultPolicy() Policy {
+	return Policy{
+		http.MethodPost + " /bookings":                        PermBookingCreate,
+		http.MethodGet + " /bookings/:id":                     PermBookingRead,
+		http.MethodPut + " /bookings/:id/status":              PermBookingUpdateStatus,
+		http.MethodDelete + " /bookings/:id":                  PermBookingCancel,
+		http.MethodGet + " /bookings/user/:user_id":           PermBookingRead,
+		http.MethodPost + " /tickets/:event_id":               PermTicketCreate,
+		http.MethodPost + " /pricing/tiers":                   PermPricingManage,
+		http.MethodPost + " /pricing/tiers/:id/tickets":       PermPricingManage,
+		http.MethodGet + " /pricing/tickets/:ticket_id/quote": PermPricingQuote,
+		http.MethodGet + " /admin/bookings":                   PermBookingSearch,
+		http.MethodPost + " /transfers":                       PermTicketTransfer,
+		http.MethodPost + " /transfers/accept":                PermTicketTransfer,
+		http.MethodDelete + " /transfers/:id":                 PermTicketTransfer,
+		http.MethodGet + " /tickets/:id/transfers":            PermTicketRead,
+		http.MethodGet + " /tickets/:id/qr":                   PermTicketCredential,
+		http.MethodPost + " /credentials/reissue":             PermTicketCredential,
+		http.MethodPost + " /credentials/verify":              PermTicketVerify,
+
+		http.MethodPost + " /webhooks":                                       PermWebhookManage,
+		http.MethodGet + " /webhooks":                                        PermWebhookManage,
+		http.MethodDelete + " /webhooks/:id":                                 PermWebhookManage,
+		http.MethodGet + " /webhooks/:id/deliveries":                         PermWebhookManage,
+		http.MethodPost + " /webhooks/:id/deliveries/:delivery_id/redeliver": PermWebhookManage,
+	}
+}
+
+// GRPCPolicy covers every gRPC method. gRPC calls are HTTP/2 POSTs to
+// /package.Service/Method, so methods are keyed like routes.
+func GRPCPolicy() Policy {
+	const bookings, tickets = " /booking.v1.BookingService/", " /booking.v1.TicketService/"
+	return Policy{
+		http.MethodPost + bookings + "CreateBooking":       PermBookingCreate,
+		http.MethodPost + bookings + "GetBooking":          PermBookingRead,
+		http.MethodPost + bookings + "ConfirmBooking":      PermBookingUpdateStatus,
+		http.MethodPost + bookings + "CancelBooking":       PermBookingCancel,
+		http.MethodPost + bookings + "UpdateBookingStatus": PermBookingUpdateStatus,
+		http.MethodPost + bookings + "ListBookings":        PermBookingRead,
+		http.MethodPost + tickets + "CreateTickets":        PermTicketCreate,
+		http.MethodPost + tickets + "GetTicket":            PermTicketRead,
+		http.MethodPost + tickets + "ListAvailableTickets": PermTicketRead,
+	}
+}
+
+// Mount returns the policy with prefix prepended to every route, e.g.
+// DefaultPolicy().Mount("/api/v1").
+func (p Policy) Mount(prefix string) Policy {
+	mounted := make(Policy, len(p))
+	for key, permission := range p {
+		method, route, _ := strings.Cut(key, " ")
+		mounted[method+" "+prefix+route] = permission
+	}
+	return mounted
+}
+
+// PermissionFor returns the permission required by a route.
+func (p Policy) PermissionFor(method, route string) (Permission, bool) {
+	permission, ok := p[method+" "+route]
+	return permission, ok
+}
@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/services"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/money"
+	"booking-service/pkg/pagination"
+	"booking-service/utils"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxExportRows caps the rows of a CSV export; narrow the search for more.
+const MaxExportRows = 10000
+
+var bookingCSVHeader = []string{
+	"id", "user_id", "event_id", "status", "total", "currency", "fee", "ticket_ids", "created_at", "updated_at",
+}
+
+type AdminController interface {
+	SearchBookings(c *gin.Context)
+}
+
+type adminControllerImpl struct {
+	BookingService services.BookingService
+	Logger         *slog.Logger
+}
+
+func NewAdminController(bookingService services.BookingService) AdminController {
+	return &adminControllerImpl{
+		BookingService: bookingService,
+		Logger:         slog.Default(),
+	}
+}
+
+// SearchBookings finds bookings across all users of the tenant. With
+// format=csv, or Accept: text/csv, every matching page up to MaxExportRows is
+// exported instead of a single JSON page.
+func (ac *adminControllerImpl) SearchBookings(c *gin.Context) {
+	criteria, err := parseBookingCriteria(c)
+	if err != nil {
+		ac.Logger.WarnContext(c.Request.Context(), "Invalid search parameters", "error", err)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidSearchParameters, "Invalid search parameters", err.Error()))
+		return
+	}
+
+	if c.Query("format") == "csv" || (c.Query("format") == "" && c.NegotiateFormat(gin.MIMEJSON, "text/csv") == "text/csv") {
+		ac.exportBookings(c, criteria)
+		return
+	}
+
+	page, err := ac.BookingService.SearchBookings(c.Request.Context(), criteria)
+	if err != nil {
+		ac.Logger.ErrorContext(c.Request.Context(), "Failed to search bookings", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	ac.Logger.InfoContext(c.Request.Context(), "Bookings searched", "count", len(page.Data), "total", page.Total)
+	c.JSON(http.StatusOK, page)
+}
+
+// exportBookings writes the matching bookings as CSV, following cursors from
+// the requested page. The first page is fetched before anything is written so
+// that invalid searches still get a JSON error.
+func (ac *adminControllerImpl) exportBookings(c *gin.Context, criteria repositories.BookingCriteria) {
+	ctx := c.Request.Context()
+	if criteria.Limit == 0 {
+		criteria.Limit = pagination.MaxLimit
+	}
+
+	page, err := ac.BookingService.SearchBookings(ctx, criteria)
+	if err != nil {
+		ac.Logger.ErrorContext(ctx, "Failed to export bookings", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="bookings-%s.csv"`, time.Now().UTC().Format("20060102-150405")))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(bookingCSVHeader)
+	rows := 0
+	for {
+		for _, booking := range page.Data {
+			if rows == MaxExportRows {
+				break
+			}
+			_ = w.Write(bookingCSVRecord(booking))
+			rows++
+		}
+		if page.NextCursor == "" || rows == MaxExportRows {
+			break
+		}
+		criteria.After, _ = pagination.DecodeCursor(page.NextCursor)
+		if page, err = ac.BookingService.SearchBookings(ctx, criteria); err != nil {
+			// The status is already sent; a truncated file is all we can do
+			ac.Logger.ErrorContext(ctx, "Booking export truncated", "rows", rows, "error", err)
+			break
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		ac.Logger.WarnContext(ctx, "Failed to write booking export", "error", err)
+		return
+	}
+	ac.Logger.InfoContext(ctx, "Bookings exported", "rows", rows, "total", page.Total)
+}
+
+func bookingCSVRecord(booking models.Booking) []string {
+	ticketIDs := make([]string, len(booking.Tickets))
+	for i, ticket := range booking.Tickets {
+		ticketIDs[i] = strconv.FormatUint(uint64(ticket.ID), 10)
+	}
+	fee := money.Money{Amount: booking.FeeAmount, Currency: booking.TotalAmount.Currency}
+	return []string{
+		strconv.FormatUint(uint64(booking.ID), 10),
+		strconv.FormatUint(uint64(booking.UserID), 10),
+		strconv.FormatUint(uint64(booking.EventID), 10),
+		string(booking.Status),
+		decimalAmount(booking.TotalAmount),
+		booking.TotalAmount.Currency,
+		decimalAmount(fee),
+		strings.Join(ticketIDs, " "),
+		booking.CreatedAt.UTC().Format(time.RFC3339),
+		booking.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// decimalAmount formats an amount in major units without the currency code,
+// e.g. "12.50", which spreadsheets read as a number.
+func decimalAmount(m money.Money) string {
+	amount, _, _ := strings.Cut(m.String(), " ")
+	return amount
+}
+
+// parseBookingCriteria reads the filters of an admin booking search. status
+// takes a comma-separated list; min_total and max_total are decimal amounts
+// in currency, which defaults to the tenant's. Sorting, dates, limit and
+// cursor work as in parseBookingQuery.
+func parseBookingCriteria(c *gin.Context) (repositories.BookingCriteria, error) {
+	query, err := parseBookingQuery(c)
+	if err != nil {
+		return repositories.BookingCriteria{}, err
+	}
+	criteria := repositories.BookingCriteria{
+		EventID:     query.EventID,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		Descending:  query.Descending,
+		After:       query.After,
+		Limit:       query.Limit,
+	}
+
+	for param, target := range map[string]*uint{"booking_id": &criteria.BookingID, "user_id": &criteria.UserID, "ticket_id": &criteria.TicketID} {
+		if value := c.Query(param); value != "" {
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil || id == 0 {
+				return criteria, fmt.Errorf("%s must be a positive integer, got %q", param, value)
+			}
+			*target = uint(id)
+		}
+	}
+
+	if value := c.Query("status"); value != "" {
+		for _, status := range strings.Split(value, ",") {
+			criteria.Statuses = append(criteria.Statuses, models.BookingStatus(strings.ToUpper(strings.TrimSpace(status))))
+		}
+	}
+
+	currency := strings.ToUpper(c.Query("currency"))
+	if currency == "" {
+		tenant, _ := tenancy.FromContext(c.Request.Context())
+		currency = tenant.Currency
+	} else if !money.IsSupported(currency) {
+		return criteria, fmt.Errorf("%w: %q", money.ErrUnsupportedCurrency, currency)
+	}
+	for param, target := range map[string]**int64{"min_total": &criteria.MinTotal, "max_total": &criteria.MaxTotal} {
+		if value := c.Query(param); value != "" {
+			amount, err := money.Parse(value, currency)
+			if err != nil {
+				return criteria, fmt.Errorf("%s: %w", param, err)
+			}
+			*target = &amount.Amount
+		}
+	}
+	if criteria.MinTotal != nil || criteria.MaxTotal != nil || c.Query("currency") != "" {
+		criteria.TotalCurrency = currency
+	}
+	return criteria, nil
+}
+
+func RegisterAdminRoutes(router *gin.RouterGroup, controller AdminController) {
+	adminRoutes := router.Group("/admin")
+	{
+		adminRoutes.GET("/bookings", controller.SearchBookings)
+	}
+}
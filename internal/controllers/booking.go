@@ -1,11 +1,17 @@
 package controllers
 
 import (
+	"booking-service/internal/middlewares"
 	"booking-service/internal/models"
+	"booking-service/internal/repositories"
 	"booking-service/internal/services"
+	"booking-service/pkg/pagination"
 	"booking-service/utils"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,37 +26,43 @@ type BookingController interface {
 
 type bookingControllerImpl struct {
 	BookingService services.BookingService
-	Logger         *utils.Logger
+	Logger         *slog.Logger
 }
 
 func NewBookingController(bookingService services.BookingService) BookingController {
 	return &bookingControllerImpl{
 		BookingService: bookingService,
-		Logger:         utils.NewLogger(),
+		Logger:         slog.Default(),
 	}
 }
 
 func (bc *bookingControllerImpl) CreateBooking(c *gin.Context) {
 	var request struct {
-		UserID    uint   `json:"user_id" binding:"required"`
 		EventID   uint   `json:"event_id" binding:"required"`
 		TicketIDs []uint `json:"ticket_ids" binding:"required"`
 	}
 
+	userID, ok := middlewares.GetUserID(c)
+	if !ok {
+		bc.Logger.WarnContext(c.Request.Context(), "Missing authenticated user")
+		utils.AbortWithError(c, utils.NewAppError(http.StatusUnauthorized, utils.CodeUnauthorized, "Unauthorized", ""))
+		return
+	}
+
 	if err := c.ShouldBindJSON(&request); err != nil {
-		bc.Logger.Warn("Invalid request payload: " + err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload", "details": err.Error()})
+		bc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
 		return
 	}
 
-	booking, err := bc.BookingService.CreateBooking(request.UserID, request.EventID, request.TicketIDs)
+	booking, err := bc.BookingService.CreateBooking(c.Request.Context(), userID, request.EventID, request.TicketIDs)
 	if err != nil {
-		bc.Logger.Error("Failed to create booking: " + err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create booking", "details": err.Error()})
+		bc.Logger.ErrorContext(c.Request.Context(), "Failed to create booking", "error", err)
+		utils.AbortWithError(c, err)
 		return
 	}
 
-	bc.Logger.Info("Booking created successfully: " + strconv.Itoa(int(booking.ID)))
+	bc.Logger.InfoContext(c.Request.Context(), "Booking created", "booking_id", booking.ID)
 	c.JSON(http.StatusCreated, booking)
 }
 
@@ -58,24 +70,30 @@ func (bc *bookingControllerImpl) GetBookingByID(c *gin.Context) {
 	bookingIDStr := c.Param("id")
 	bookingID, err := strconv.Atoi(bookingIDStr)
 	if err != nil {
-		bc.Logger.Warn("Invalid booking ID: " + bookingIDStr)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID"})
+		bc.Logger.WarnContext(c.Request.Context(), "Invalid booking ID", "booking_id", bookingIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidBookingID, "Invalid booking ID", ""))
 		return
 	}
-	booking, err := bc.BookingService.GetBookingByID(uint(bookingID))
+	booking, err := bc.BookingService.GetBookingByID(c.Request.Context(), uint(bookingID))
 	if err != nil {
-		bc.Logger.Error("Failed to retrieve booking: " + err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve booking", "details": err.Error()})
+		bc.Logger.ErrorContext(c.Request.Context(), "Failed to retrieve booking", "error", err)
+		utils.AbortWithError(c, err)
 		return
 	}
 
 	if booking == nil {
-		bc.Logger.Warn("Booking not found: " + bookingIDStr)
-		c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+		bc.Logger.WarnContext(c.Request.Context(), "Booking not found", "booking_id", bookingIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusNotFound, utils.CodeBookingNotFound, "Booking not found", ""))
 		return
 	}
 
-	bc.Logger.Info("Booking retrieved successfully: " + bookingIDStr)
+	if !middlewares.GetPrincipal(c).Owns(booking.UserID) {
+		bc.Logger.WarnContext(c.Request.Context(), "Forbidden access to booking", "booking_id", bookingIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", ""))
+		return
+	}
+
+	bc.Logger.InfoContext(c.Request.Context(), "Booking retrieved", "booking_id", bookingIDStr)
 	c.JSON(http.StatusOK, booking)
 }
 
@@ -83,8 +101,8 @@ func (bc *bookingControllerImpl) UpdateBookingStatus(c *gin.Context) {
 	bookingIDStr := c.Param("id")
 	bookingID, err := strconv.Atoi(bookingIDStr)
 	if err != nil {
-		bc.Logger.Warn("Invalid booking ID: " + bookingIDStr)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID"})
+		bc.Logger.WarnContext(c.Request.Context(), "Invalid booking ID", "booking_id", bookingIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidBookingID, "Invalid booking ID", ""))
 		return
 	}
 	var request struct {
@@ -92,18 +110,18 @@ func (bc *bookingControllerImpl) UpdateBookingStatus(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		bc.Logger.Warn("Invalid request payload: " + err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload", "details": err.Error()})
+		bc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
 		return
 	}
 
-	if err := bc.BookingService.UpdateBookingStatus(uint(bookingID), request.Status); err != nil {
-		bc.Logger.Error("Failed to update booking status: " + err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update booking status", "details": err.Error()})
+	if err := bc.BookingService.UpdateBookingStatus(c.Request.Context(), uint(bookingID), request.Status); err != nil {
+		bc.Logger.ErrorContext(c.Request.Context(), "Failed to update booking status", "error", err)
+		utils.AbortWithError(c, err)
 		return
 	}
 
-	bc.Logger.Info("Booking status updated successfully: " + bookingIDStr)
+	bc.Logger.InfoContext(c.Request.Context(), "Booking status updated", "booking_id", bookingIDStr)
 	c.JSON(http.StatusOK, gin.H{"message": "Booking status updated successfully"})
 }
 
@@ -111,35 +129,116 @@ func (bc *bookingControllerImpl) CancelBooking(c *gin.Context) {
 	bookingIDStr := c.Param("id")
 	bookingID, err := strconv.Atoi(bookingIDStr)
 	if err != nil {
-		bc.Logger.Warn("Invalid booking ID: " + bookingIDStr)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID"})
+		bc.Logger.WarnContext(c.Request.Context(), "Invalid booking ID", "booking_id", bookingIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidBookingID, "Invalid booking ID", ""))
 		return
 	}
-	if err := bc.BookingService.CancelBooking(uint(bookingID)); err != nil {
-		bc.Logger.Error("Failed to cancel booking: " + err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel booking", "details": err.Error()})
+	booking, err := bc.BookingService.GetBookingByID(c.Request.Context(), uint(bookingID))
+	if err != nil {
+		bc.Logger.ErrorContext(c.Request.Context(), "Failed to retrieve booking", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+	if booking == nil {
+		bc.Logger.WarnContext(c.Request.Context(), "Booking not found", "booking_id", bookingIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusNotFound, utils.CodeBookingNotFound, "Booking not found", ""))
+		return
+	}
+	if !middlewares.GetPrincipal(c).Owns(booking.UserID) {
+		bc.Logger.WarnContext(c.Request.Context(), "Forbidden cancellation of booking", "booking_id", bookingIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", ""))
+		return
+	}
+
+	if err := bc.BookingService.CancelBooking(c.Request.Context(), uint(bookingID)); err != nil {
+		bc.Logger.ErrorContext(c.Request.Context(), "Failed to cancel booking", "error", err)
+		utils.AbortWithError(c, err)
 		return
 	}
 
-	bc.Logger.Info("Booking cancelled successfully: " + bookingIDStr)
+	bc.Logger.InfoContext(c.Request.Context(), "Booking cancelled", "booking_id", bookingIDStr)
 	c.JSON(http.StatusOK, gin.H{"message": "Booking cancelled successfully"})
 }
 
 func (bc *bookingControllerImpl) ListBookingsByUserID(c *gin.Context) {
 	userIDStr := c.Param("user_id")
 	userID, err := strconv.Atoi(userIDStr)
-	page := utils.ParseQueryParamAsInt(c, "page", 1)
-	pageSize := utils.ParseQueryParamAsInt(c, "page_size", 10)
+	if err != nil {
+		bc.Logger.WarnContext(c.Request.Context(), "Invalid user ID", "owner_id", userIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidUserID, "Invalid user ID", ""))
+		return
+	}
+	if !middlewares.GetPrincipal(c).Owns(uint(userID)) {
+		bc.Logger.WarnContext(c.Request.Context(), "Forbidden listing of bookings for user", "owner_id", userIDStr)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", ""))
+		return
+	}
+	query, err := parseBookingQuery(c)
+	if err != nil {
+		bc.Logger.WarnContext(c.Request.Context(), "Invalid booking list query", "error", err)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidQueryParameter, "Invalid query parameter", err.Error()))
+		return
+	}
 
-	bookings, err := bc.BookingService.ListBookingsByUserID(uint(userID), page, pageSize)
+	page, err := bc.BookingService.ListBookingsByUserID(c.Request.Context(), uint(userID), query)
 	if err != nil {
-		bc.Logger.Error("Failed to list bookings for user: " + err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list bookings", "details": err.Error()})
+		bc.Logger.ErrorContext(c.Request.Context(), "Failed to list bookings for user", "error", err)
+		utils.AbortWithError(c, err)
 		return
 	}
 
-	bc.Logger.Info("Bookings retrieved successfully for user: " + userIDStr)
-	c.JSON(http.StatusOK, bookings)
+	bc.Logger.InfoContext(c.Request.Context(), "Bookings retrieved", "owner_id", userIDStr, "count", len(page.Data))
+	c.JSON(http.StatusOK, page)
+}
+
+// parseBookingQuery reads the cursor, limit, sort and filters of a booking
+// listing. Bookings are sorted by creation time, newest first unless
+// sort=created_at; dates are RFC 3339.
+func parseBookingQuery(c *gin.Context) (repositories.BookingQuery, error) {
+	query := repositories.BookingQuery{
+		Status:     models.BookingStatus(c.Query("status")),
+		Descending: true,
+	}
+
+	switch sort := c.Query("sort"); sort {
+	case "", "-created_at":
+	case "created_at":
+		query.Descending = false
+	default:
+		return query, fmt.Errorf("sort must be created_at or -created_at, got %q", sort)
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return query, fmt.Errorf("limit must be a positive integer, got %q", value)
+		}
+		query.Limit = limit
+	}
+	if value := c.Query("event_id"); value != "" {
+		eventID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || eventID == 0 {
+			return query, fmt.Errorf("event_id must be a positive integer, got %q", value)
+		}
+		query.EventID = uint(eventID)
+	}
+	for param, target := range map[string]*time.Time{"from": &query.CreatedFrom, "to": &query.CreatedTo} {
+		if value := c.Query(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, fmt.Errorf("%s must be an RFC 3339 time, got %q", param, value)
+			}
+			*target = parsed
+		}
+	}
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := pagination.DecodeCursor(value)
+		if err != nil {
+			return query, err
+		}
+		query.After = cursor
+	}
+	return query, nil
 }
 
 func RegisterBookingRoutes(router *gin.RouterGroup, controller BookingController) {
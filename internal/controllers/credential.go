@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"booking-service/internal/credentials"
+	"booking-service/internal/services"
+	"booking-service/utils"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mimeSVG = "image/svg+xml"
+
+	defaultQRSize = 256
+	minQRSize     = 64
+	maxQRSize     = 1024
+)
+
+type CredentialController interface {
+	TicketQR(c *gin.Context)
+	ReissueCredential(c *gin.Context)
+	VerifyCredential(c *gin.Context)
+}
+
+type credentialControllerImpl struct {
+	CredentialService services.CredentialService
+	TicketService     services.TicketService
+	EventService      services.EventService
+	Logger            *slog.Logger
+}
+
+func NewCredentialController(credentialService services.CredentialService, ticketService services.TicketService, eventService services.EventService) CredentialController {
+	return &credentialControllerImpl{
+		CredentialService: credentialService,
+		TicketService:     ticketService,
+		EventService:      eventService,
+		Logger:            slog.Default(),
+	}
+}
+
+// TicketQR renders the credential of a ticket as a QR code for its owner to
+// show at the door: a PNG of size pixels by default, or an SVG with
+// format=svg or Accept: image/svg+xml.
+func (cc *credentialControllerImpl) TicketQR(c *gin.Context) {
+	ticketID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		cc.Logger.WarnContext(c.Request.Context(), "Invalid ticket ID", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidTicketID, "Invalid ticket ID")
+		return
+	}
+	size := defaultQRSize
+	if value := c.Query("size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < minQRSize || parsed > maxQRSize {
+			err := fmt.Errorf("size must be between %d and %d, got %q", minQRSize, maxQRSize, value)
+			cc.Logger.WarnContext(c.Request.Context(), "Invalid QR code query", "error", err)
+			utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidQueryParameter, "Invalid query parameter")
+			return
+		}
+		size = parsed
+	}
+
+	if !authorizeTicket(c, cc.TicketService, cc.Logger, uint(ticketID)) {
+		return
+	}
+
+	credential, err := cc.CredentialService.IssueCredential(c.Request.Context(), uint(ticketID))
+	if err != nil {
+		cc.Logger.WarnContext(c.Request.Context(), "Failed to issue ticket credential", "ticket_id", ticketID, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	contentType, image := "image/png", []byte(nil)
+	if c.Query("format") == "svg" || (c.Query("format") == "" && c.NegotiateFormat("image/png", mimeSVG) == mimeSVG) {
+		contentType = mimeSVG
+		image, err = credentials.SVG(credential)
+	} else {
+		image, err = credentials.PNG(credential, size)
+	}
+	if err != nil {
+		cc.Logger.ErrorContext(c.Request.Context(), "Failed to render QR code", "ticket_id", ticketID, "error", err)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusInternalServerError, utils.CodeInternal, "Failed to render QR code", err.Error()))
+		return
+	}
+
+	// The image admits its bearer, so keep it out of shared caches
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, contentType, image)
+}
+
+// ReissueCredential revokes a ticket's credential and returns the new one.
+func (cc *credentialControllerImpl) ReissueCredential(c *gin.Context) {
+	var request struct {
+		TicketID uint `json:"ticket_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		cc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
+		return
+	}
+
+	if !authorizeTicket(c, cc.TicketService, cc.Logger, request.TicketID) {
+		return
+	}
+
+	credential, err := cc.CredentialService.ReissueCredential(c.Request.Context(), request.TicketID)
+	if err != nil {
+		cc.Logger.ErrorContext(c.Request.Context(), "Failed to reissue ticket credential", "ticket_id", request.TicketID, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"credential": credential})
+}
+
+// VerifyCredential checks a credential scanned at the door of event_id and
+// answers with the ticket it admits. Organizers can only scan at their own
+// events.
+func (cc *credentialControllerImpl) VerifyCredential(c *gin.Context) {
+	var request struct {
+		Credential string `json:"credential" binding:"required"`
+		EventID    uint   `json:"event_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		cc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
+		return
+	}
+
+	if !authorizeEvent(c, cc.EventService, cc.Logger, request.EventID) {
+		return
+	}
+
+	ticket, err := cc.CredentialService.VerifyCredential(c.Request.Context(), request.Credential)
+	if err == nil && ticket.EventID != request.EventID {
+		err = utils.NewAppError(http.StatusConflict, utils.CodeCredentialNotAdmitted, "Credential not admitted",
+			fmt.Sprintf("Ticket %d is for event %d, not %d", ticket.ID, ticket.EventID, request.EventID))
+	}
+	if err != nil {
+		cc.Logger.WarnContext(c.Request.Context(), "Ticket credential rejected", "event_id", request.EventID, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	cc.Logger.InfoContext(c.Request.Context(), "Ticket credential admitted", "ticket_id", ticket.ID, "event_id", ticket.EventID)
+	c.JSON(http.StatusOK, gin.H{
+		"ticket_id":          ticket.ID,
+		"event_id":           ticket.EventID,
+		"user_id":            ticket.UserID,
+		"credential_version": ticket.CredentialVersion,
+	})
+}
+
+func RegisterCredentialRoutes(router *gin.RouterGroup, controller CredentialController) {
+	router.GET("/tickets/:id/qr", controller.TicketQR)
+	router.POST("/credentials/reissue", controller.ReissueCredential)
+	router.POST("/credentials/verify", controller.VerifyCredential)
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIPage renders /openapi.json with Swagger UI loaded from a CDN, so
+// no assets need to be bundled.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Booking Service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// DocsController serves the OpenAPI document and Swagger UI
+type DocsController struct {
+	Doc *openapi3.T
+}
+
+// NewDocsController initializes a new DocsController
+func NewDocsController(doc *openapi3.T) *DocsController {
+	return &DocsController{Doc: doc}
+}
+
+// RegisterDocsRoutes sets up the documentation routes
+func (dc *DocsController) RegisterDocsRoutes(router *gin.Engine) {
+	router.GET("/openapi.json", dc.Spec)
+	router.GET("/docs", dc.SwaggerUI)
+}
+
+// Spec returns the OpenAPI document as JSON.
+func (dc *DocsController) Spec(c *gin.Context) {
+	c.JSON(http.StatusOK, dc.Doc)
+}
+
+// SwaggerUI returns the interactive documentation page.
+func (dc *DocsController) SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
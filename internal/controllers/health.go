@@ -1,68 +1,58 @@
 package controllers
 
 import (
+	"booking-service/internal/health"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-redis/redis/v8"
-	"gorm.io/gorm"
 )
 
-// HealthController provides handlers for health checks
+// HealthController provides handlers for liveness and readiness probes
 type HealthController struct {
-	DB          *gorm.DB
-	RedisClient *redis.Client
+	Checks *health.Registry
+	Ready  func() bool // false while the application is starting or draining
 }
 
 // NewHealthController initializes a new HealthController
-func NewHealthController(db *gorm.DB, redisClient *redis.Client) *HealthController {
+func NewHealthController(checks *health.Registry, ready func() bool) *HealthController {
 	return &HealthController{
-		DB:          db,
-		RedisClient: redisClient,
+		Checks: checks,
+		Ready:  ready,
 	}
 }
 
 // RegisterHealthRoutes sets up the health check routes
 func (hc *HealthController) RegisterHealthRoutes(router *gin.Engine) {
-	router.GET("/health", hc.HealthCheck)
-	router.GET("/health/db", hc.DatabaseHealthCheck)
-	router.GET("/health/redis", hc.RedisHealthCheck)
+	router.GET("/health/live", hc.Live)
+	router.GET("/health/ready", hc.Readiness)
 }
 
-// HealthCheck returns the overall service health
-func (hc *HealthController) HealthCheck(c *gin.Context) {
+// Live reports that the process is running. It checks no dependencies so a
+// database outage does not get the pod restarted.
+func (hc *HealthController) Live(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
-		"status": "Booking Service is running",
+		"status": health.StatusUp,
 	})
 }
 
-// DatabaseHealthCheck verifies database connectivity
-func (hc *HealthController) DatabaseHealthCheck(c *gin.Context) {
-	sqlDB, err := hc.DB.DB()
-	if err != nil || sqlDB.Ping() != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "Database is down",
-			"error":  err.Error(),
-		})
-		return
-	}
+// Readiness reports whether the service can take traffic, with the result
+// and latency of each dependency check.
+func (hc *HealthController) Readiness(c *gin.Context) {
+	report := hc.Checks.Check(c.Request.Context())
 
-	c.JSON(http.StatusOK, gin.H{
-		"status": "Database is healthy",
-	})
-}
-
-// RedisHealthCheck verifies Redis connectivity
-func (hc *HealthController) RedisHealthCheck(c *gin.Context) {
-	if err := hc.RedisClient.Ping(c).Err(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "Redis is down",
-			"error":  err.Error(),
-		})
-		return
+	draining := hc.Ready != nil && !hc.Ready()
+	if draining {
+		report.Status = health.StatusDown
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status": "Redis is healthy",
+	status := http.StatusOK
+	if !report.Up() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{
+		"status":     report.Status,
+		"draining":   draining,
+		"checks":     report.Checks,
+		"checked_at": report.CheckedAt,
 	})
 }
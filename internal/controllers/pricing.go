@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/services"
+	"booking-service/pkg/money"
+	"booking-service/utils"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PricingController interface {
+	CreateTier(c *gin.Context)
+	CreateTicketsForTier(c *gin.Context)
+	QuoteTicket(c *gin.Context)
+}
+
+type pricingControllerImpl struct {
+	PricingService services.PricingService
+	TicketService  services.TicketService
+	EventService   services.EventService
+	Logger         *slog.Logger
+}
+
+func NewPricingController(
+	pricingService services.PricingService,
+	ticketService services.TicketService,
+	eventService services.EventService,
+) PricingController {
+	return &pricingControllerImpl{
+		PricingService: pricingService,
+		TicketService:  ticketService,
+		EventService:   eventService,
+		Logger:         slog.Default(),
+	}
+}
+
+func (pc *pricingControllerImpl) CreateTier(c *gin.Context) {
+	var request struct {
+		EventID        uint            `json:"event_id" binding:"required"`
+		Name           string          `json:"name" binding:"required"`
+		BasePrice      money.Money     `json:"base_price"`
+		Strategy       string          `json:"strategy"`
+		StrategyConfig json.RawMessage `json:"strategy_config"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		pc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
+		return
+	}
+
+	if !authorizeEvent(c, pc.EventService, pc.Logger, request.EventID) {
+		return
+	}
+
+	tier := &models.PricingTier{
+		EventID:        request.EventID,
+		Name:           request.Name,
+		BasePrice:      request.BasePrice,
+		Strategy:       request.Strategy,
+		StrategyConfig: string(request.StrategyConfig),
+	}
+	if err := pc.PricingService.CreateTier(c.Request.Context(), tier); err != nil {
+		pc.Logger.ErrorContext(c.Request.Context(), "Failed to create pricing tier", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	pc.Logger.InfoContext(c.Request.Context(), "Pricing tier created", "tier_id", tier.ID)
+	c.JSON(http.StatusCreated, tier)
+}
+
+func (pc *pricingControllerImpl) CreateTicketsForTier(c *gin.Context) {
+	var request struct {
+		NumTickets int `json:"num_tickets" binding:"required"`
+	}
+
+	tierIDStr := c.Param("id")
+	tierID, err := strconv.ParseUint(tierIDStr, 10, 64)
+	if err != nil {
+		pc.Logger.WarnContext(c.Request.Context(), "Invalid tier ID", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidTierID, "Invalid tier ID")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		pc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
+		return
+	}
+
+	tier, err := pc.PricingService.GetTierByID(c.Request.Context(), uint(tierID))
+	if err != nil {
+		pc.Logger.ErrorContext(c.Request.Context(), "Failed to retrieve pricing tier", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+	if !authorizeEvent(c, pc.EventService, pc.Logger, tier.EventID) {
+		return
+	}
+
+	tickets, err := pc.PricingService.CreateTicketsForTier(c.Request.Context(), tier.ID, request.NumTickets)
+	if err != nil {
+		pc.Logger.ErrorContext(c.Request.Context(), "Failed to create tickets", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	pc.Logger.InfoContext(c.Request.Context(), "Tickets created", "tier_id", tierIDStr)
+	c.JSON(http.StatusCreated, tickets)
+}
+
+func (pc *pricingControllerImpl) QuoteTicket(c *gin.Context) {
+	ticketIDStr := c.Param("ticket_id")
+	ticketID, err := strconv.ParseUint(ticketIDStr, 10, 64)
+	if err != nil {
+		pc.Logger.WarnContext(c.Request.Context(), "Invalid ticket ID", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidTicketID, "Invalid ticket ID")
+		return
+	}
+
+	ticket, err := pc.TicketService.GetTicketByID(c.Request.Context(), uint(ticketID))
+	if err != nil {
+		pc.Logger.ErrorContext(c.Request.Context(), "Failed to retrieve ticket", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	quote, err := pc.PricingService.QuoteTicket(c.Request.Context(), ticket)
+	if err != nil {
+		pc.Logger.ErrorContext(c.Request.Context(), "Failed to quote ticket", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+func RegisterPricingRoutes(router *gin.RouterGroup, controller PricingController) {
+	pricingRoutes := router.Group("/pricing")
+	{
+		pricingRoutes.POST("/tiers", controller.CreateTier)
+		pricingRoutes.POST("/tiers/:id/tickets", controller.CreateTicketsForTier)
+		pricingRoutes.GET("/tickets/:ticket_id/quote", controller.QuoteTicket)
+	}
+}
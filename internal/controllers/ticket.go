@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"booking-service/internal/middlewares"
 	"booking-service/internal/services"
+	"booking-service/pkg/money"
 	"booking-service/utils"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -15,44 +18,50 @@ type TicketController interface {
 
 type ticketControllerImpl struct {
 	TicketService services.TicketService
-	Logger        *utils.Logger
+	EventService  services.EventService
+	Logger        *slog.Logger
 }
 
-func NewTicketController(ticketService services.TicketService) TicketController {
+func NewTicketController(ticketService services.TicketService, eventService services.EventService) TicketController {
 	return &ticketControllerImpl{
 		TicketService: ticketService,
-		Logger:        utils.NewLogger(),
+		EventService:  eventService,
+		Logger:        slog.Default(),
 	}
 }
 
 func (tc *ticketControllerImpl) CreateTicketsForEvent(c *gin.Context) {
 	var request struct {
-		NumTickets int     `json:"num_tickets" binding:"required"`
-		Price      float64 `json:"price" binding:"required"`
+		NumTickets int         `json:"num_tickets" binding:"required"`
+		Price      money.Money `json:"price"`
 	}
 
 	eventIDStr := c.Param("event_id")
 	eventID, err := strconv.ParseUint(eventIDStr, 10, 64)
 	if err != nil {
-		tc.Logger.Warn("Invalid event ID: " + err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID", "details": err.Error()})
+		tc.Logger.WarnContext(c.Request.Context(), "Invalid event ID", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidEventID, "Invalid event ID")
 		return
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		tc.Logger.Warn("Invalid request payload: " + err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload", "details": err.Error()})
+		tc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
 		return
 	}
 
-	tickets, err := tc.TicketService.CreateTicketsForEvent(uint(eventID), request.NumTickets, request.Price)
+	if !authorizeEvent(c, tc.EventService, tc.Logger, uint(eventID)) {
+		return
+	}
+
+	tickets, err := tc.TicketService.CreateTicketsForEvent(c.Request.Context(), uint(eventID), request.NumTickets, request.Price)
 	if err != nil {
-		tc.Logger.Error("Failed to create tickets: " + err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create tickets", "details": err.Error()})
+		tc.Logger.ErrorContext(c.Request.Context(), "Failed to create tickets", "error", err)
+		utils.AbortWithError(c, err)
 		return
 	}
 
-	tc.Logger.Info("Tickets created successfully for event " + eventIDStr)
+	tc.Logger.InfoContext(c.Request.Context(), "Tickets created", "event_id", eventIDStr)
 	c.JSON(http.StatusCreated, tickets)
 }
 
@@ -62,3 +71,37 @@ func RegisterTicketRoutes(router *gin.RouterGroup, controller TicketController)
 		ticketRoutes.POST("/:event_id", controller.CreateTicketsForEvent)
 	}
 }
+
+// authorizeEvent writes an error response and returns false unless the
+// principal may manage the event.
+func authorizeEvent(c *gin.Context, eventService services.EventService, logger *slog.Logger, eventID uint) bool {
+	event, err := eventService.GetEventByID(c.Request.Context(), eventID)
+	if err != nil {
+		logger.WarnContext(c.Request.Context(), "Failed to retrieve event", "error", err)
+		utils.AbortWithError(c, err)
+		return false
+	}
+	if !middlewares.GetPrincipal(c).OwnsOptional(event.OrganizerID) {
+		logger.WarnContext(c.Request.Context(), "Forbidden management of event", "event_id", eventID)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", ""))
+		return false
+	}
+	return true
+}
+
+// authorizeTicket writes an error response and returns false unless the
+// principal owns the ticket.
+func authorizeTicket(c *gin.Context, ticketService services.TicketService, logger *slog.Logger, ticketID uint) bool {
+	ticket, err := ticketService.GetTicketByID(c.Request.Context(), ticketID)
+	if err != nil {
+		logger.WarnContext(c.Request.Context(), "Failed to retrieve ticket", "error", err)
+		utils.AbortWithError(c, err)
+		return false
+	}
+	if !middlewares.GetPrincipal(c).OwnsOptional(ticket.UserID) {
+		logger.WarnContext(c.Request.Context(), "Forbidden access to ticket", "ticket_id", ticketID)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", ""))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"booking-service/internal/middlewares"
+	"booking-service/internal/services"
+	"booking-service/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferController interface {
+	InitiateTransfer(c *gin.Context)
+	AcceptTransfer(c *gin.Context)
+	CancelTransfer(c *gin.Context)
+	ListTransfers(c *gin.Context)
+}
+
+type transferControllerImpl struct {
+	TransferService services.TransferService
+	TicketService   services.TicketService
+	Logger          *slog.Logger
+}
+
+func NewTransferController(transferService services.TransferService, ticketService services.TicketService) TransferController {
+	return &transferControllerImpl{
+		TransferService: transferService,
+		TicketService:   ticketService,
+		Logger:          slog.Default(),
+	}
+}
+
+// InitiateTransfer offers a ticket to another user. The response includes the
+// token the recipient accepts with, which is not shown again.
+func (tc *transferControllerImpl) InitiateTransfer(c *gin.Context) {
+	var request struct {
+		TicketID uint  `json:"ticket_id" binding:"required"`
+		ToUserID *uint `json:"to_user_id"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		tc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
+		return
+	}
+
+	if !authorizeTicket(c, tc.TicketService, tc.Logger, request.TicketID) {
+		return
+	}
+
+	transfer, token, err := tc.TransferService.InitiateTransfer(c.Request.Context(), request.TicketID, request.ToUserID)
+	if err != nil {
+		tc.Logger.ErrorContext(c.Request.Context(), "Failed to initiate transfer", "ticket_id", request.TicketID, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"transfer": transfer, "token": token})
+}
+
+// AcceptTransfer makes the caller the owner of the transferred ticket.
+func (tc *transferControllerImpl) AcceptTransfer(c *gin.Context) {
+	var request struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		tc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
+		return
+	}
+
+	ticket, err := tc.TransferService.AcceptTransfer(c.Request.Context(), request.Token, middlewares.GetPrincipal(c).UserID)
+	if err != nil {
+		tc.Logger.WarnContext(c.Request.Context(), "Failed to accept transfer", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+// CancelTransfer withdraws a pending transfer on behalf of its sender.
+func (tc *transferControllerImpl) CancelTransfer(c *gin.Context) {
+	transferID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		tc.Logger.WarnContext(c.Request.Context(), "Invalid transfer ID", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidTransferID, "Invalid transfer ID")
+		return
+	}
+
+	transfer, err := tc.TransferService.GetTransfer(c.Request.Context(), uint(transferID))
+	if err != nil {
+		tc.Logger.WarnContext(c.Request.Context(), "Failed to retrieve transfer", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+	if !middlewares.GetPrincipal(c).Owns(transfer.FromUserID) {
+		tc.Logger.WarnContext(c.Request.Context(), "Forbidden cancellation of transfer", "transfer_id", transferID)
+		utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", ""))
+		return
+	}
+
+	if err := tc.TransferService.CancelTransfer(c.Request.Context(), uint(transferID)); err != nil {
+		tc.Logger.ErrorContext(c.Request.Context(), "Failed to cancel transfer", "transfer_id", transferID, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Transfer canceled"})
+}
+
+// ListTransfers returns the ownership history of a ticket.
+func (tc *transferControllerImpl) ListTransfers(c *gin.Context) {
+	ticketID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		tc.Logger.WarnContext(c.Request.Context(), "Invalid ticket ID", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidTicketID, "Invalid ticket ID")
+		return
+	}
+
+	if !authorizeTicket(c, tc.TicketService, tc.Logger, uint(ticketID)) {
+		return
+	}
+
+	transfers, err := tc.TransferService.ListTransfers(c.Request.Context(), uint(ticketID))
+	if err != nil {
+		tc.Logger.ErrorContext(c.Request.Context(), "Failed to list transfers", "ticket_id", ticketID, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
+}
+
+func RegisterTransferRoutes(router *gin.RouterGroup, controller TransferController) {
+	transferRoutes := router.Group("/transfers")
+	{
+		transferRoutes.POST("", controller.InitiateTransfer)
+		transferRoutes.POST("/accept", controller.AcceptTransfer)
+		transferRoutes.DELETE("/:id", controller.CancelTransfer)
+	}
+	router.GET("/tickets/:id/transfers", controller.ListTransfers)
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/services"
+	"booking-service/pkg/pagination"
+	"booking-service/utils"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController interface {
+	CreateSubscription(c *gin.Context)
+	ListSubscriptions(c *gin.Context)
+	DeleteSubscription(c *gin.Context)
+	ListDeliveries(c *gin.Context)
+	Redeliver(c *gin.Context)
+}
+
+type webhookControllerImpl struct {
+	WebhookService services.WebhookService
+	Logger         *slog.Logger
+}
+
+func NewWebhookController(webhookService services.WebhookService) WebhookController {
+	return &webhookControllerImpl{
+		WebhookService: webhookService,
+		Logger:         slog.Default(),
+	}
+}
+
+// CreateSubscription registers an endpoint for booking events. The response
+// includes the signing secret, which is not shown again.
+func (wc *webhookControllerImpl) CreateSubscription(c *gin.Context) {
+	var request struct {
+		URL    string   `json:"url" binding:"required"`
+		Secret string   `json:"secret"`
+		Events []string `json:"events"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		wc.Logger.WarnContext(c.Request.Context(), "Invalid request payload", "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload")
+		return
+	}
+
+	subscription := &models.WebhookSubscription{
+		URL:    request.URL,
+		Secret: request.Secret,
+		Events: request.Events,
+	}
+	if err := wc.WebhookService.CreateSubscription(c.Request.Context(), subscription); err != nil {
+		wc.Logger.ErrorContext(c.Request.Context(), "Failed to create webhook subscription", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, struct {
+		*models.WebhookSubscription
+		Secret string `json:"secret"`
+	}{subscription, subscription.Secret})
+}
+
+func (wc *webhookControllerImpl) ListSubscriptions(c *gin.Context) {
+	subscriptions, err := wc.WebhookService.ListSubscriptions(c.Request.Context())
+	if err != nil {
+		wc.Logger.ErrorContext(c.Request.Context(), "Failed to list webhook subscriptions", "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+func (wc *webhookControllerImpl) DeleteSubscription(c *gin.Context) {
+	id, ok := wc.pathID(c, "id", utils.CodeInvalidSubscriptionID, "Invalid subscription ID")
+	if !ok {
+		return
+	}
+	if err := wc.WebhookService.DeleteSubscription(c.Request.Context(), id); err != nil {
+		wc.Logger.ErrorContext(c.Request.Context(), "Failed to delete webhook subscription", "subscription_id", id, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook subscription deleted"})
+}
+
+// ListDeliveries returns the delivery log of a subscription, newest first.
+// status filters by outcome and limit caps the entries returned.
+func (wc *webhookControllerImpl) ListDeliveries(c *gin.Context) {
+	id, ok := wc.pathID(c, "id", utils.CodeInvalidSubscriptionID, "Invalid subscription ID")
+	if !ok {
+		return
+	}
+	limit := pagination.DefaultLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > pagination.MaxLimit {
+			err := fmt.Errorf("limit must be between 1 and %d, got %q", pagination.MaxLimit, value)
+			wc.Logger.WarnContext(c.Request.Context(), "Invalid delivery log query", "error", err)
+			utils.HandleError(c, err, http.StatusBadRequest, utils.CodeInvalidQueryParameter, "Invalid query parameter")
+			return
+		}
+		limit = parsed
+	}
+	status := models.WebhookDeliveryStatus(strings.ToUpper(c.Query("status")))
+
+	deliveries, err := wc.WebhookService.ListDeliveries(c.Request.Context(), id, status, limit)
+	if err != nil {
+		wc.Logger.ErrorContext(c.Request.Context(), "Failed to list webhook deliveries", "subscription_id", id, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// Redeliver queues a delivery to be sent again, e.g. once a partner has fixed
+// their endpoint.
+func (wc *webhookControllerImpl) Redeliver(c *gin.Context) {
+	id, ok := wc.pathID(c, "id", utils.CodeInvalidSubscriptionID, "Invalid subscription ID")
+	if !ok {
+		return
+	}
+	deliveryID, ok := wc.pathID(c, "delivery_id", utils.CodeInvalidDeliveryID, "Invalid delivery ID")
+	if !ok {
+		return
+	}
+
+	delivery, err := wc.WebhookService.Redeliver(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		wc.Logger.ErrorContext(c.Request.Context(), "Failed to redeliver webhook", "delivery_id", deliveryID, "error", err)
+		utils.AbortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func (wc *webhookControllerImpl) pathID(c *gin.Context, param string, code utils.ErrorCode, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 64)
+	if err != nil {
+		wc.Logger.WarnContext(c.Request.Context(), message, "error", err)
+		utils.HandleError(c, err, http.StatusBadRequest, code, message)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func RegisterWebhookRoutes(router *gin.RouterGroup, controller WebhookController) {
+	webhookRoutes := router.Group("/webhooks")
+	{
+		webhookRoutes.POST("", controller.CreateSubscription)
+		webhookRoutes.GET("", controller.ListSubscriptions)
+		webhookRoutes.DELETE("/:id", controller.DeleteSubscription)
+		webhookRoutes.GET("/:id/deliveries", controller.ListDeliveries)
+		webhookRoutes.POST("/:id/deliveries/:delivery_id/redeliver", controller.Redeliver)
+	}
+}
@@ -0,0 +1,147 @@
+package credentials
+
+import (
+	"booking-service/internal/models"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmHS256 = "HS256"
+)
+
+// minHMACKeyLength matches the output size of SHA-256.
+const minHMACKeyLength = 32
+
+var ErrInvalidCredential = errors.New("invalid ticket credential")
+
+// Claims are carried by a ticket credential. A credential only admits while
+// Version matches the ticket's credential version.
+type Claims struct {
+	TicketID uint   `json:"tid"`
+	EventID  uint   `json:"eid"`
+	Version  uint   `json:"ver"`
+	TenantID string `json:"ten,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Admits reports whether the claims are current for ticket: the same ticket
+// and event, still sold, and not re-issued since.
+func (c *Claims) Admits(ticket *models.Ticket) bool {
+	return ticket.Status == models.TicketStatusSold &&
+		c.TicketID == ticket.ID &&
+		c.EventID == ticket.EventID &&
+		c.Version == ticket.CredentialVersion &&
+		c.TenantID == ticket.TenantID
+}
+
+// Config describes the signing keys. Keys are "id:base64" pairs, Ed25519
+// seeds for EdDSA and secrets of at least 32 bytes for HS256. Credentials are
+// signed with ActiveKey and verified with any key, so a new key can be
+// rolled out while credentials signed with the previous one still verify.
+type Config struct {
+	Algorithm string
+	ActiveKey string
+	Keys      []string
+}
+
+type key struct {
+	sign   interface{}
+	verify interface{}
+}
+
+// Issuer signs and verifies ticket credentials.
+type Issuer struct {
+	method    jwt.SigningMethod
+	activeKey string
+	keys      map[string]key
+	parser    *jwt.Parser
+}
+
+func NewIssuer(cfg Config) (*Issuer, error) {
+	var method jwt.SigningMethod
+	switch cfg.Algorithm {
+	case AlgorithmEdDSA:
+		method = jwt.SigningMethodEdDSA
+	case AlgorithmHS256:
+		method = jwt.SigningMethodHS256
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", cfg.Algorithm)
+	}
+
+	keys := make(map[string]key, len(cfg.Keys))
+	for _, entry := range cfg.Keys {
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("key must be \"id:base64\", got an entry without an ID")
+		}
+		if _, dup := keys[id]; dup {
+			return nil, fmt.Errorf("duplicate key ID %q", id)
+		}
+		material, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not base64: %w", id, err)
+		}
+		switch cfg.Algorithm {
+		case AlgorithmEdDSA:
+			if len(material) != ed25519.SeedSize {
+				return nil, fmt.Errorf("key %q must be a %d-byte Ed25519 seed, got %d bytes", id, ed25519.SeedSize, len(material))
+			}
+			private := ed25519.NewKeyFromSeed(material)
+			keys[id] = key{sign: private, verify: private.Public()}
+		case AlgorithmHS256:
+			if len(material) < minHMACKeyLength {
+				return nil, fmt.Errorf("key %q must be at least %d bytes, got %d", id, minHMACKeyLength, len(material))
+			}
+			keys[id] = key{sign: material, verify: material}
+		}
+	}
+	if _, ok := keys[cfg.ActiveKey]; !ok {
+		return nil, fmt.Errorf("active key %q is not among the keys", cfg.ActiveKey)
+	}
+
+	return &Issuer{
+		method:    method,
+		activeKey: cfg.ActiveKey,
+		keys:      keys,
+		parser:    jwt.NewParser(jwt.WithValidMethods([]string{method.Alg()})),
+	}, nil
+}
+
+// Issue returns the credential of a ticket's current version, signed with
+// the active key. It is the same for every call until the ticket is
+// re-issued or the active key changes.
+func (i *Issuer) Issue(ticket *models.Ticket) (string, error) {
+	token := jwt.NewWithClaims(i.method, &Claims{
+		TicketID: ticket.ID,
+		EventID:  ticket.EventID,
+		Version:  ticket.CredentialVersion,
+		TenantID: ticket.TenantID,
+	})
+	token.Header["kid"] = i.activeKey
+	return token.SignedString(i.keys[i.activeKey].sign)
+}
+
+// Verify checks the signature of a credential and returns its claims. Use
+// Claims.Admits to check them against the ticket.
+func (i *Issuer) Verify(credential string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := i.parser.ParseWithClaims(credential, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		k, ok := i.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		return k.verify, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredential, err)
+	}
+	return claims, nil
+}
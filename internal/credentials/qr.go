@@ -0,0 +1,39 @@
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// recoveryLevel tolerates a scuffed or partly glared screen.
+const recoveryLevel = qrcode.Medium
+
+// PNG renders content as a QR code PNG of size by size pixels.
+func PNG(content string, size int) ([]byte, error) {
+	return qrcode.Encode(content, recoveryLevel, size)
+}
+
+// SVG renders content as a QR code SVG, one unit per module, that scales
+// without blurring.
+func SVG(content string) ([]byte, error) {
+	code, err := qrcode.New(content, recoveryLevel)
+	if err != nil {
+		return nil, err
+	}
+	bitmap := code.Bitmap()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %[1]d %[1]d" shape-rendering="crispEdges">`, len(bitmap))
+	fmt.Fprintf(&buf, `<rect width="%[1]d" height="%[1]d" fill="#fff"/><path fill="#000" d="`, len(bitmap))
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package grpcapi
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/repositories"
+	"booking-service/internal/services"
+	"booking-service/pkg/pagination"
+	bookingv1 "booking-service/pkg/pb/booking/v1"
+	"booking-service/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// bookingServer serves BookingService on the same service layer as
+// BookingController, with the same ownership checks.
+type bookingServer struct {
+	bookingv1.UnimplementedBookingServiceServer
+	BookingService services.BookingService
+	Logger         *slog.Logger
+}
+
+func (s *bookingServer) CreateBooking(ctx context.Context, req *bookingv1.CreateBookingRequest) (*bookingv1.Booking, error) {
+	if req.EventId == 0 || len(req.TicketIds) == 0 {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidRequestPayload, "Invalid request payload", "event_id and ticket_ids are required")
+	}
+	ticketIDs := make([]uint, len(req.TicketIds))
+	for i, id := range req.TicketIds {
+		ticketIDs[i] = uint(id)
+	}
+
+	userID := authz.PrincipalFromContext(ctx).UserID
+	booking, err := s.BookingService.CreateBooking(ctx, userID, uint(req.EventId), ticketIDs)
+	if err != nil {
+		return nil, err
+	}
+	s.Logger.InfoContext(ctx, "Booking created", "booking_id", booking.ID)
+	return toBooking(booking), nil
+}
+
+func (s *bookingServer) GetBooking(ctx context.Context, req *bookingv1.GetBookingRequest) (*bookingv1.Booking, error) {
+	booking, err := s.BookingService.GetBookingByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, utils.NewAppError(http.StatusNotFound, utils.CodeBookingNotFound, "Booking not found", "")
+	}
+	if !authz.PrincipalFromContext(ctx).Owns(booking.UserID) {
+		s.Logger.WarnContext(ctx, "Forbidden access to booking", "booking_id", req.Id)
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", "")
+	}
+	return toBooking(booking), nil
+}
+
+func (s *bookingServer) ConfirmBooking(ctx context.Context, req *bookingv1.ConfirmBookingRequest) (*bookingv1.ConfirmBookingResponse, error) {
+	if err := s.BookingService.ConfirmBooking(ctx, uint(req.Id)); err != nil {
+		return nil, err
+	}
+	return &bookingv1.ConfirmBookingResponse{}, nil
+}
+
+func (s *bookingServer) CancelBooking(ctx context.Context, req *bookingv1.CancelBookingRequest) (*bookingv1.CancelBookingResponse, error) {
+	booking, err := s.BookingService.GetBookingByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, utils.NewAppError(http.StatusNotFound, utils.CodeBookingNotFound, "Booking not found", "")
+	}
+	if !authz.PrincipalFromContext(ctx).Owns(booking.UserID) {
+		s.Logger.WarnContext(ctx, "Forbidden cancellation of booking", "booking_id", req.Id)
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", "")
+	}
+	if err := s.BookingService.CancelBooking(ctx, uint(req.Id)); err != nil {
+		return nil, err
+	}
+	return &bookingv1.CancelBookingResponse{}, nil
+}
+
+func (s *bookingServer) UpdateBookingStatus(ctx context.Context, req *bookingv1.UpdateBookingStatusRequest) (*bookingv1.UpdateBookingStatusResponse, error) {
+	status, ok := fromBookingStatus(req.Status)
+	if !ok {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidBookingStatus, "Invalid booking status", fmt.Sprintf("Unknown status %s", req.Status))
+	}
+	if err := s.BookingService.UpdateBookingStatus(ctx, uint(req.Id), status); err != nil {
+		return nil, err
+	}
+	return &bookingv1.UpdateBookingStatusResponse{}, nil
+}
+
+func (s *bookingServer) ListBookings(ctx context.Context, req *bookingv1.ListBookingsRequest) (*bookingv1.ListBookingsResponse, error) {
+	if !authz.PrincipalFromContext(ctx).Owns(uint(req.UserId)) {
+		s.Logger.WarnContext(ctx, "Forbidden listing of bookings for user", "owner_id", req.UserId)
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", "")
+	}
+
+	query := repositories.BookingQuery{
+		EventID:     uint(req.EventId),
+		CreatedFrom: fromTimestamp(req.CreatedFrom),
+		CreatedTo:   fromTimestamp(req.CreatedTo),
+		Descending:  !req.Ascending,
+		Limit:       int(req.PageSize),
+	}
+	if req.Status != bookingv1.BookingStatus_BOOKING_STATUS_UNSPECIFIED {
+		status, ok := fromBookingStatus(req.Status)
+		if !ok {
+			return nil, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidBookingStatus, "Invalid booking status", fmt.Sprintf("Unknown status %s", req.Status))
+		}
+		query.Status = status
+	}
+	if req.PageToken != "" {
+		cursor, err := pagination.DecodeCursor(req.PageToken)
+		if err != nil {
+			return nil, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidQueryParameter, "Invalid query parameter", err.Error())
+		}
+		query.After = cursor
+	}
+
+	page, err := s.BookingService.ListBookingsByUserID(ctx, uint(req.UserId), query)
+	if err != nil {
+		return nil, err
+	}
+	resp := &bookingv1.ListBookingsResponse{
+		Bookings:      make([]*bookingv1.Booking, len(page.Data)),
+		NextPageToken: page.NextCursor,
+		TotalSize:     page.Total,
+	}
+	for i := range page.Data {
+		resp.Bookings[i] = toBooking(&page.Data[i])
+	}
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package grpcapi
+
+import (
+	"booking-service/internal/models"
+	"booking-service/pkg/money"
+	bookingv1 "booking-service/pkg/pb/booking/v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var bookingStatuses = map[models.BookingStatus]bookingv1.BookingStatus{
+	models.BookingStatusPending:   bookingv1.BookingStatus_BOOKING_STATUS_PENDING,
+	models.BookingStatusConfirmed: bookingv1.BookingStatus_BOOKING_STATUS_CONFIRMED,
+	models.BookingStatusCanceled:  bookingv1.BookingStatus_BOOKING_STATUS_CANCELED,
+}
+
+var ticketStatuses = map[models.TicketStatus]bookingv1.TicketStatus{
+	models.TicketStatusAvailable: bookingv1.TicketStatus_TICKET_STATUS_AVAILABLE,
+	models.TicketStatusReserved:  bookingv1.TicketStatus_TICKET_STATUS_RESERVED,
+	models.TicketStatusSold:      bookingv1.TicketStatus_TICKET_STATUS_SOLD,
+}
+
+// fromBookingStatus returns the model status of a protobuf status, or false
+// for BOOKING_STATUS_UNSPECIFIED and unknown values.
+func fromBookingStatus(status bookingv1.BookingStatus) (models.BookingStatus, bool) {
+	for model, pb := range bookingStatuses {
+		if pb == status {
+			return model, true
+		}
+	}
+	return "", false
+}
+
+func toBooking(booking *models.Booking) *bookingv1.Booking {
+	return &bookingv1.Booking{
+		Id:          uint64(booking.ID),
+		UserId:      uint64(booking.UserID),
+		EventId:     uint64(booking.EventID),
+		Status:      bookingStatuses[booking.Status],
+		TotalAmount: toMoney(booking.TotalAmount),
+		FeeAmount:   booking.FeeAmount,
+		Tickets:     toTickets(booking.Tickets),
+		CreatedAt:   toTimestamp(booking.CreatedAt),
+		UpdatedAt:   toTimestamp(booking.UpdatedAt),
+	}
+}
+
+func toTicket(ticket *models.Ticket) *bookingv1.Ticket {
+	return &bookingv1.Ticket{
+		Id:        uint64(ticket.ID),
+		EventId:   uint64(ticket.EventID),
+		Status:    ticketStatuses[ticket.Status],
+		Price:     toMoney(ticket.Price),
+		UserId:    toOptionalID(ticket.UserID),
+		BookingId: toOptionalID(ticket.BookingID),
+		CreatedAt: toTimestamp(ticket.CreatedAt),
+		UpdatedAt: toTimestamp(ticket.UpdatedAt),
+	}
+}
+
+func toTickets(tickets []models.Ticket) []*bookingv1.Ticket {
+	result := make([]*bookingv1.Ticket, len(tickets))
+	for i := range tickets {
+		result[i] = toTicket(&tickets[i])
+	}
+	return result
+}
+
+func toMoney(m money.Money) *bookingv1.Money {
+	return &bookingv1.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+// fromMoney converts an optional price; a missing one is the zero Money,
+// which the services reject or default like an omitted JSON price.
+func fromMoney(m *bookingv1.Money) money.Money {
+	if m == nil {
+		return money.Money{}
+	}
+	return money.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func toOptionalID(id *uint) *uint64 {
+	if id == nil {
+		return nil
+	}
+	value := uint64(*id)
+	return &value
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// fromTimestamp returns the zero time, which does not filter, for nil.
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
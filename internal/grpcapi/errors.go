@@ -0,0 +1,67 @@
+package grpcapi
+
+import (
+	"booking-service/internal/middlewares"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies this service in ErrorInfo details.
+const errorDomain = "booking-service"
+
+// toStatus maps an error returned by a handler to a gRPC status the same way
+// the REST API maps it to a problem response: the message is the problem
+// title and an ErrorInfo detail carries its stable code. Errors that already
+// are statuses pass through.
+func toStatus(err error, exposeDetails bool) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	problem := middlewares.NewProblem(err, exposeDetails)
+	message := problem.Title
+	if problem.Detail != "" {
+		message += ": " + problem.Detail
+	}
+	st := status.New(codeFor(problem.Status), message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: problem.Code, Domain: errorDomain}); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// codeFor maps an HTTP status to the gRPC code of the same meaning, following
+// the mapping in google/rpc/code.proto.
+func codeFor(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	switch {
+	case httpStatus >= http.StatusInternalServerError:
+		return codes.Internal
+	case httpStatus >= http.StatusBadRequest:
+		return codes.FailedPrecondition
+	default:
+		return codes.Unknown
+	}
+}
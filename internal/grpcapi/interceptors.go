@@ -0,0 +1,128 @@
+package grpcapi
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/auth"
+	"booking-service/pkg/logging"
+	"booking-service/utils"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// isPublic reports whether a method is served without a token: the health
+// and reflection services, which carry no tenant data.
+func isPublic(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.") || strings.HasPrefix(fullMethod, "/grpc.reflection.")
+}
+
+// logUnary writes one structured log line per call, like middlewares.Logger.
+// It runs first so it sees the status the client receives.
+func logUnary(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		startTime := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(ctx, level, "gRPC request",
+			slog.String("method", info.FullMethod),
+			slog.String("code", code.String()),
+			slog.Duration("duration", time.Since(startTime)),
+		)
+		return resp, err
+	}
+}
+
+// errorUnary converts handler errors to statuses and panics to Internal.
+func errorUnary(exposeDetails bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = fmt.Errorf("panic: %v", recovered)
+			}
+			if err != nil {
+				err = toStatus(err, exposeDetails).Err()
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// authUnary does for gRPC what Auth, Tenant and Authorize do for REST: it
+// verifies the bearer token in the authorization metadata, scopes the
+// context to the caller's tenant and checks the method's permission, leaving
+// the principal in the context. Methods missing from the policy are denied.
+func authUnary(verifier *auth.Verifier, tenants *tenancy.Registry, policy authz.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, verifier, tenants, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authenticate(ctx context.Context, verifier *auth.Verifier, tenants *tenancy.Registry, policy authz.Policy, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token, found := strings.CutPrefix(first(md, "authorization"), "Bearer ")
+	if !found || token == "" {
+		return nil, utils.NewAppError(http.StatusUnauthorized, utils.CodeMissingBearerToken, "Missing bearer token", "")
+	}
+	claims, err := verifier.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	userID, _ := claims.UserID()
+	ctx = logging.WithUserID(ctx, userID)
+
+	tenant, err := tenants.Resolve(claims.TenantID, first(md, ":authority"))
+	if errors.Is(err, tenancy.ErrTenantMismatch) {
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeTenantMismatch, "Tenant mismatch", "Token was issued for another tenant")
+	}
+	if errors.Is(err, tenancy.ErrTenantRequired) {
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeTenantRequired, "Tenant required", "Token has no tenant_id claim")
+	}
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenancy.WithTenant(ctx, tenant)
+
+	permission, ok := policy.PermissionFor(http.MethodPost, fullMethod)
+	if !ok {
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", "Method has no access policy")
+	}
+	role := authz.Role(claims.Role)
+	scope := role.ScopeFor(permission)
+	if scope == authz.ScopeNone {
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", "Role "+claims.Role+" lacks permission "+string(permission))
+	}
+	return authz.WithPrincipal(ctx, authz.Principal{UserID: userID, Role: role, Scope: scope}), nil
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+// Package grpcapi serves the booking.v1 gRPC API for internal services on
+// the same service layer as the REST controllers.
+package grpcapi
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/services"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/auth"
+	bookingv1 "booking-service/pkg/pb/booking/v1"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Config configures the gRPC server.
+type Config struct {
+	Addr          string
+	Reflection    bool // register the reflection service
+	ExposeDetails bool // include details of server errors in statuses, as outside production
+}
+
+// Dependencies are the services and authentication the API is served with.
+type Dependencies struct {
+	BookingService services.BookingService
+	TicketService  services.TicketService
+	EventService   services.EventService
+	Verifier       *auth.Verifier
+	Tenants        *tenancy.Registry
+}
+
+// Server is the gRPC server as a lifecycle component. Its health service
+// reports SERVING until shutdown starts.
+type Server struct {
+	config   Config
+	server   *grpc.Server
+	health   *health.Server
+	listener net.Listener
+}
+
+func NewServer(config Config, deps Dependencies) *Server {
+	logger := slog.Default()
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		logUnary(logger),
+		errorUnary(config.ExposeDetails),
+		authUnary(deps.Verifier, deps.Tenants, authz.GRPCPolicy()),
+	))
+
+	bookingv1.RegisterBookingServiceServer(server, &bookingServer{
+		BookingService: deps.BookingService,
+		Logger:         logger,
+	})
+	bookingv1.RegisterTicketServiceServer(server, &ticketServer{
+		TicketService: deps.TicketService,
+		EventService:  deps.EventService,
+		Logger:        logger,
+	})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	for service := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	if config.Reflection {
+		reflection.Register(server)
+	}
+	return &Server{config: config, server: server, health: healthServer}
+}
+
+func (s *Server) Name() string { return "grpc server" }
+
+// Start listens on the configured address.
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.Addr, err)
+	}
+	s.listener = listener
+	return nil
+}
+
+// Run serves on the listener bound by Start, listening first if Start was
+// not called, until shut down.
+func (s *Server) Run(ctx context.Context) error {
+	if s.listener == nil {
+		if err := s.Start(ctx); err != nil {
+			return err
+		}
+	}
+	// Serve reports ErrServerStopped when shutdown began before it was called
+	if err := s.Serve(s.listener); !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Serve serves on listener until shut down.
+func (s *Server) Serve(listener net.Listener) error {
+	return s.server.Serve(listener)
+}
+
+// Shutdown reports NOT_SERVING and lets in-flight calls finish, cutting them
+// off when ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,61 @@
+package grpcapi
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/models"
+	"booking-service/internal/services"
+	bookingv1 "booking-service/pkg/pb/booking/v1"
+	"booking-service/utils"
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// ticketServer serves TicketService on the same service layer as
+// TicketController.
+type ticketServer struct {
+	bookingv1.UnimplementedTicketServiceServer
+	TicketService services.TicketService
+	EventService  services.EventService
+	Logger        *slog.Logger
+}
+
+func (s *ticketServer) CreateTickets(ctx context.Context, req *bookingv1.CreateTicketsRequest) (*bookingv1.CreateTicketsResponse, error) {
+	event, err := s.EventService.GetEventByID(ctx, uint(req.EventId))
+	if err != nil {
+		return nil, err
+	}
+	if !authz.PrincipalFromContext(ctx).OwnsOptional(event.OrganizerID) {
+		s.Logger.WarnContext(ctx, "Forbidden management of event", "event_id", req.EventId)
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", "")
+	}
+
+	tickets, err := s.TicketService.CreateTicketsForEvent(ctx, uint(req.EventId), int(req.Count), fromMoney(req.Price))
+	if err != nil {
+		return nil, err
+	}
+	s.Logger.InfoContext(ctx, "Tickets created", "event_id", req.EventId)
+	return &bookingv1.CreateTicketsResponse{Tickets: toTickets(tickets)}, nil
+}
+
+// GetTicket returns a ticket. Customers only see available tickets and the
+// ones they hold.
+func (s *ticketServer) GetTicket(ctx context.Context, req *bookingv1.GetTicketRequest) (*bookingv1.Ticket, error) {
+	ticket, err := s.TicketService.GetTicketByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status != models.TicketStatusAvailable && !authz.PrincipalFromContext(ctx).OwnsOptional(ticket.UserID) {
+		s.Logger.WarnContext(ctx, "Forbidden access to ticket", "ticket_id", req.Id)
+		return nil, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", "")
+	}
+	return toTicket(ticket), nil
+}
+
+func (s *ticketServer) ListAvailableTickets(ctx context.Context, req *bookingv1.ListAvailableTicketsRequest) (*bookingv1.ListAvailableTicketsResponse, error) {
+	tickets, err := s.TicketService.ListAvailableTickets(ctx, uint(req.EventId))
+	if err != nil {
+		return nil, err
+	}
+	return &bookingv1.ListAvailableTicketsResponse{Tickets: toTickets(tickets)}, nil
+}
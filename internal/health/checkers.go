@@ -0,0 +1,34 @@
+package health
+
+import (
+	"booking-service/pkg/kafka"
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+// Postgres pings the database connection pool.
+func Postgres(db *gorm.DB) Checker {
+	return CheckerFunc("postgres", func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+}
+
+// Redis pings the Redis server.
+func Redis(client *redis.Client) Checker {
+	return CheckerFunc("redis", func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
+}
+
+// Kafka checks that the broker accepts connections and answers metadata requests.
+func Kafka(broker string) Checker {
+	return CheckerFunc("kafka", func(ctx context.Context) error {
+		return kafka.Ping(ctx, broker)
+	})
+}
@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker probes one dependency.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to a Checker.
+func CheckerFunc(name string, check func(ctx context.Context) error) Checker {
+	return checkerFunc{name: name, check: check}
+}
+
+type checkerFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (c checkerFunc) Name() string                    { return c.name }
+func (c checkerFunc) Check(ctx context.Context) error { return c.check(ctx) }
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report aggregates the results of every registered check.
+type Report struct {
+	Status    string            `json:"status"`
+	Checks    map[string]Result `json:"checks"`
+	CheckedAt time.Time         `json:"checked_at"`
+}
+
+// Up reports whether every check passed.
+func (r Report) Up() bool {
+	return r.Status == StatusUp
+}
+
+// Registry runs the registered checkers concurrently, each bounded by a
+// timeout, and caches the report so probes cannot overload dependencies.
+type Registry struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	checkers []Checker
+
+	mu     sync.Mutex
+	cached *Report
+}
+
+func NewRegistry(timeout, cacheTTL time.Duration) *Registry {
+	return &Registry{
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// Register adds checkers. It must be called before the first Check.
+func (r *Registry) Register(checkers ...Checker) {
+	r.checkers = append(r.checkers, checkers...)
+}
+
+// Check returns the cached report if it is fresh, otherwise runs all checks.
+// Concurrent callers wait for a single run.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cached != nil && time.Since(r.cached.CheckedAt) < r.cacheTTL {
+		return *r.cached
+	}
+
+	report := r.run(ctx)
+	r.cached = &report
+	return report
+}
+
+func (r *Registry) run(ctx context.Context) Report {
+	results := make([]Result, len(r.checkers))
+	var wg sync.WaitGroup
+	for i, checker := range r.checkers {
+		wg.Add(1)
+		go func(i int, checker Checker) {
+			defer wg.Done()
+			results[i] = r.runOne(ctx, checker)
+		}(i, checker)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:    StatusUp,
+		Checks:    make(map[string]Result, len(r.checkers)),
+		CheckedAt: time.Now(),
+	}
+	for i, checker := range r.checkers {
+		report.Checks[checker.Name()] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (r *Registry) runOne(ctx context.Context, checker Checker) Result {
+	// Detach from the probe request so a client hanging up does not cache a failure
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Check(ctx)
+	result := Result{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"booking-service/internal/services"
+	"booking-service/internal/settings"
+	"booking-service/internal/tenancy"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ExpireHolds returns a job that cancels pending bookings older than the
+// current hold TTL in every tenant so their tickets go back on sale.
+func ExpireHolds(bookingService services.BookingService, tenants *tenancy.Registry, settingsStore *settings.Store) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		holdTTL := settingsStore.Current().HoldTTL
+		var errs []error
+		for _, tenant := range tenants.All() {
+			if _, err := bookingService.ExpirePendingBookings(tenancy.WithTenant(ctx, tenant), holdTTL); err != nil {
+				errs = append(errs, fmt.Errorf("tenant %s: %w", tenant.ID, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"booking-service/internal/reconcile"
+	"booking-service/internal/tenancy"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Reconcile returns a job that reconciles tickets and bookings in every
+// tenant. Findings are logged and exported as metrics by the reconciler.
+func Reconcile(reconciler *reconcile.Reconciler, tenants *tenancy.Registry) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var errs []error
+		for _, tenant := range tenants.All() {
+			if _, err := reconciler.Run(tenancy.WithTenant(ctx, tenant)); err != nil {
+				errs = append(errs, fmt.Errorf("tenant %s: %w", tenant.ID, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,138 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HTTPServer serves HTTP until shut down, letting in-flight requests finish.
+// It has started once its address is bound.
+func HTTPServer(server *http.Server) Component {
+	return &httpServer{server: server}
+}
+
+type httpServer struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+func (s *httpServer) Name() string { return "http server" }
+
+func (s *httpServer) Start(ctx context.Context) error {
+	addr := s.server.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	return nil
+}
+
+func (s *httpServer) Run(ctx context.Context) error {
+	if err := s.server.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *httpServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// Worker runs fn until it returns or the worker is shut down, which cancels
+// the context passed to fn and waits for it to return.
+func Worker(name string, fn func(ctx context.Context) error) Component {
+	return &worker{name: name, fn: fn, stop: make(chan struct{}), done: make(chan struct{})}
+}
+
+type worker struct {
+	name     string
+	fn       func(ctx context.Context) error
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func (w *worker) Name() string { return w.name }
+
+// Run detaches from the cancellation of ctx so the worker stops when the
+// Manager reaches it during shutdown, not as soon as the signal arrives.
+func (w *worker) Run(ctx context.Context) error {
+	defer close(w.done)
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return w.fn(ctx)
+}
+
+func (w *worker) Shutdown(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stop) })
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Every runs fn every interval until shut down. Errors are passed to onError
+// and do not stop the loop.
+func Every(name string, interval time.Duration, fn func(ctx context.Context) error, onError func(error)) Component {
+	return Worker(name, func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if err := fn(ctx); err != nil && ctx.Err() == nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	})
+}
+
+// Closer holds a resource open while the application runs and closes it on
+// shutdown, e.g. a connection pool or a Kafka writer.
+func Closer(name string, close func() error) Component {
+	return ShutdownFunc(name, func(context.Context) error { return close() })
+}
+
+// ShutdownFunc runs fn on shutdown with the drain deadline, e.g. to flush
+// buffered telemetry.
+func ShutdownFunc(name string, fn func(ctx context.Context) error) Component {
+	return &shutdownFunc{name: name, fn: fn, stopped: make(chan struct{})}
+}
+
+type shutdownFunc struct {
+	name    string
+	fn      func(ctx context.Context) error
+	stopped chan struct{}
+}
+
+func (c *shutdownFunc) Name() string { return c.name }
+
+func (c *shutdownFunc) Run(ctx context.Context) error {
+	<-c.stopped
+	return nil
+}
+
+func (c *shutdownFunc) Shutdown(ctx context.Context) error {
+	close(c.stopped)
+	return c.fn(ctx)
+}
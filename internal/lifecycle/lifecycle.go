@@ -0,0 +1,153 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Component is a long-running part of the application.
+type Component interface {
+	Name() string
+	// Run blocks until the component is shut down or fails.
+	Run(ctx context.Context) error
+	// Shutdown stops the component gracefully, giving up when ctx expires.
+	Shutdown(ctx context.Context) error
+}
+
+// Starter is implemented by components that must finish starting, e.g. bind
+// a listener, before the application is ready. The Manager calls Start
+// before Run and reports ready once every component has started.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Manager starts components in registration order and shuts them down in
+// reverse order, so anything registered later may rely on what was
+// registered before it until it has stopped.
+type Manager struct {
+	components   []Component
+	drainDelay   time.Duration
+	drainTimeout time.Duration
+	ready        atomic.Bool
+	Logger       *slog.Logger
+}
+
+// NewManager creates a Manager. On shutdown it reports not ready, waits
+// drainDelay so load balancers stop routing traffic, then gives components
+// drainTimeout in total to stop.
+func NewManager(drainDelay, drainTimeout time.Duration) *Manager {
+	return &Manager{
+		drainDelay:   drainDelay,
+		drainTimeout: drainTimeout,
+		Logger:       slog.Default(),
+	}
+}
+
+// Add registers a component. Components must be added before Run.
+func (m *Manager) Add(components ...Component) {
+	m.components = append(m.components, components...)
+}
+
+// Ready reports whether every component has started and the application is
+// not draining.
+func (m *Manager) Ready() bool {
+	return m.ready.Load()
+}
+
+// Run starts all components and blocks until ctx is canceled or a component
+// fails, then shuts everything down.
+func (m *Manager) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	started := make(chan struct{}, len(m.components))
+	failed := make(chan error, len(m.components))
+
+	for _, component := range m.components {
+		component := component
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Logger.Info("Starting component", "component", component.Name())
+			if starter, ok := component.(Starter); ok {
+				if err := starter.Start(ctx); err != nil {
+					failed <- fmt.Errorf("start %s: %w", component.Name(), err)
+					return
+				}
+			}
+			started <- struct{}{}
+			if err := component.Run(ctx); err != nil {
+				failed <- fmt.Errorf("%s: %w", component.Name(), err)
+			}
+		}()
+	}
+
+	runErr := m.awaitStarted(ctx, started, failed)
+	if runErr == nil && ctx.Err() == nil {
+		m.ready.Store(true)
+		m.Logger.Info("All components started")
+		select {
+		case <-ctx.Done():
+		case runErr = <-failed:
+		}
+	}
+	m.ready.Store(false)
+	if runErr != nil {
+		m.Logger.Error("Component failed, shutting down", "error", runErr)
+	} else {
+		m.Logger.Info("Shutdown requested, draining")
+		time.Sleep(m.drainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.drainTimeout)
+	defer cancel()
+
+	errs := []error{runErr}
+	for i := len(m.components) - 1; i >= 0; i-- {
+		component := m.components[i]
+		m.Logger.Info("Stopping component", "component", component.Name())
+		if err := component.Shutdown(shutdownCtx); err != nil {
+			m.Logger.Error("Failed to stop component", "component", component.Name(), "error", err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", component.Name(), err))
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		errs = append(errs, errors.New("drain timeout exceeded"))
+	}
+
+	// Report components that failed after shutdown began too
+	for {
+		select {
+		case err := <-failed:
+			errs = append(errs, err)
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// awaitStarted waits until every component has started and returns the first
+// failure before that. It returns nil early when ctx is canceled.
+func (m *Manager) awaitStarted(ctx context.Context, started <-chan struct{}, failed <-chan error) error {
+	for range m.components {
+		select {
+		case <-started:
+		case err := <-failed:
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/kafka"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "booking_service"
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests by route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	BookingTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "booking_transitions_total",
+		Help:      "Bookings that moved into a status.",
+	}, []string{"tenant", "status"})
+
+	ReservationConflicts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservation_conflicts_total",
+		Help:      "Attempts to hold a ticket that was no longer available.",
+	}, []string{"tenant"})
+
+	KafkaPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_messages_published_total",
+		Help:      "Messages written to Kafka by topic and result.",
+	}, []string{"topic", "result"})
+
+	KafkaConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_messages_consumed_total",
+		Help:      "Messages handled from Kafka by topic and result.",
+	}, []string{"topic", "result"})
+
+	KafkaConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kafka_consumer_lag",
+		Help:      "Messages behind the end of the partition, sampled after each message.",
+	}, []string{"topic"})
+
+	ReconcilerInconsistencies = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "reconciler_inconsistencies",
+		Help:      "Ticket and booking inconsistencies found by the last reconciler run, by kind.",
+	}, []string{"tenant", "kind"})
+
+	ReconcilerRepairs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconciler_repairs_total",
+		Help:      "Inconsistencies repaired by the reconciler, by kind.",
+	}, []string{"tenant", "kind"})
+)
+
+// Handler serves the default registry in the Prometheus text format.
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
+
+// Middleware records the duration of each request under its route template,
+// so /bookings/1 and /bookings/2 share a series.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// RecordTransition counts a booking entering status.
+func RecordTransition(ctx context.Context, status models.BookingStatus) {
+	BookingTransitions.WithLabelValues(tenantLabel(ctx), string(status)).Inc()
+}
+
+// RecordReservationConflict counts a failed attempt to hold a ticket.
+func RecordReservationConflict(ctx context.Context) {
+	ReservationConflicts.WithLabelValues(tenantLabel(ctx)).Inc()
+}
+
+// RecordInconsistencies sets how many inconsistencies of a kind the last
+// reconciler run found.
+func RecordInconsistencies(ctx context.Context, kind string, found int) {
+	ReconcilerInconsistencies.WithLabelValues(tenantLabel(ctx), kind).Set(float64(found))
+}
+
+// RecordRepair counts an inconsistency the reconciler repaired.
+func RecordRepair(ctx context.Context, kind string) {
+	ReconcilerRepairs.WithLabelValues(tenantLabel(ctx), kind).Inc()
+}
+
+// RecordConsumed counts a handled message and samples the consumer lag.
+func RecordConsumed(topic string, err error, lag int64) {
+	KafkaConsumed.WithLabelValues(topic, result(err)).Inc()
+	KafkaConsumerLag.WithLabelValues(topic).Set(float64(lag))
+}
+
+// InstrumentProducer counts the outcome of every publish.
+func InstrumentProducer(producer kafka.Producer) kafka.Producer {
+	return &instrumentedProducer{Producer: producer}
+}
+
+type instrumentedProducer struct {
+	kafka.Producer
+}
+
+func (p *instrumentedProducer) Publish(ctx context.Context, topic string, message interface{}) error {
+	err := p.Producer.Publish(ctx, topic, message)
+	KafkaPublished.WithLabelValues(topic, result(err)).Inc()
+	return err
+}
+
+func tenantLabel(ctx context.Context) string {
+	if tenant, ok := tenancy.FromContext(ctx); ok {
+		return tenant.ID
+	}
+	return ""
+}
+
+func result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// availableTickets reads ticket availability from the database at scrape
+// time, so the gauge cannot drift from the inventory.
+type availableTickets struct {
+	repo    repositories.TicketRepository
+	timeout time.Duration
+	desc    *prometheus.Desc
+}
+
+// NewAvailableTicketsCollector reports the tickets on sale per event.
+func NewAvailableTicketsCollector(repo repositories.TicketRepository, timeout time.Duration) prometheus.Collector {
+	return &availableTickets{
+		repo:    repo,
+		timeout: timeout,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "available_tickets"),
+			"Tickets currently available for sale per event.",
+			[]string{"tenant", "event_id"}, nil,
+		),
+	}
+}
+
+func (c *availableTickets) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *availableTickets) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(tenancy.WithSystem(context.Background()), c.timeout)
+	defer cancel()
+
+	counts, err := c.repo.CountAvailableByEvent(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	for _, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count.Available),
+			count.TenantID, strconv.FormatUint(uint64(count.EventID), 10))
+	}
+}
@@ -1,27 +1,65 @@
 package middlewares
 
 import (
+	"booking-service/pkg/auth"
+	"booking-service/pkg/logging"
+	"booking-service/utils"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Auth() gin.HandlerFunc {
+const (
+	claimsKey   = "claims"
+	userIDKey   = "userID"
+	userRoleKey = "userRole"
+)
+
+// Auth validates the bearer token and stores its claims, user ID and role in
+// the gin context.
+func Auth(verifier *auth.Verifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userID := c.GetHeader("X-User-ID")
-		userRole := c.GetHeader("X-User-Role")
-
-		if userID == "" || userRole == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Unauthorized - missing authentication headers",
-			})
-			c.Abort()
+		header := c.GetHeader("Authorization")
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			utils.AbortWithError(c, utils.NewAppError(http.StatusUnauthorized, utils.CodeMissingBearerToken, "Missing bearer token", ""))
 			return
 		}
 
-		c.Set("userID", userID)
-		c.Set("userRole", userRole)
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			utils.HandleError(c, err, http.StatusUnauthorized, utils.CodeInvalidToken, "Invalid token")
+			return
+		}
 
+		SetClaims(c, claims)
 		c.Next()
 	}
 }
+
+// SetClaims stores verified claims in the gin context and tags the request
+// context with the user ID for logging.
+func SetClaims(c *gin.Context, claims *auth.Claims) {
+	userID, _ := claims.UserID()
+	c.Set(claimsKey, claims)
+	c.Set(userIDKey, userID)
+	c.Set(userRoleKey, claims.Role)
+	c.Request = c.Request.WithContext(logging.WithUserID(c.Request.Context(), userID))
+}
+
+// GetClaims returns the claims stored by Auth.
+func GetClaims(c *gin.Context) (*auth.Claims, bool) {
+	value, ok := c.Get(claimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := value.(*auth.Claims)
+	return claims, ok
+}
+
+// GetUserID returns the authenticated user's ID.
+func GetUserID(c *gin.Context) (uint, bool) {
+	userID := c.GetUint(userIDKey)
+	return userID, userID != 0
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const principalKey = "principal"
+
+// Authorize looks up the permission for the matched route and checks the
+// caller's role grants it. Routes missing from the policy are denied. It must
+// run after Auth.
+func Authorize(policy authz.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			utils.AbortWithError(c, utils.NewAppError(http.StatusUnauthorized, utils.CodeUnauthorized, "Unauthorized", ""))
+			return
+		}
+
+		permission, ok := policy.PermissionFor(c.Request.Method, c.FullPath())
+		if !ok {
+			utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden", "Route has no access policy"))
+			return
+		}
+
+		role := authz.Role(claims.Role)
+		scope := role.ScopeFor(permission)
+		if scope == authz.ScopeNone {
+			utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeForbidden, "Forbidden",
+				"Role "+claims.Role+" lacks permission "+string(permission)))
+			return
+		}
+
+		userID, _ := GetUserID(c)
+		c.Set(principalKey, authz.Principal{UserID: userID, Role: role, Scope: scope})
+		c.Next()
+	}
+}
+
+// GetPrincipal returns the principal stored by Authorize. Without one the
+// caller is treated as having no access.
+func GetPrincipal(c *gin.Context) authz.Principal {
+	if value, ok := c.Get(principalKey); ok {
+		if principal, ok := value.(authz.Principal); ok {
+			return principal
+		}
+	}
+	return authz.Principal{}
+}
@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"booking-service/utils"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation describes routes being retired in favour of a newer version.
+type Deprecation struct {
+	Since  time.Time // when the routes were deprecated
+	Sunset time.Time // when they stop being served; zero if not scheduled yet
+
+	// Prefix is replaced by SuccessorPrefix in the request path to link the
+	// replacing route, e.g. /api to /api/v1.
+	Prefix, SuccessorPrefix string
+}
+
+// Deprecated announces the retirement of the routes it guards with
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers and a successor-version
+// link. From the sunset on, requests are answered 410 Gone.
+func Deprecated(d Deprecation) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", d.Since.Unix())
+	retired := utils.NewAppError(http.StatusGone, utils.CodeAPIVersionRetired, "API version retired", "")
+	if d.SuccessorPrefix != "" {
+		retired.Details = "Use " + d.SuccessorPrefix + " instead"
+	}
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		if !d.Sunset.IsZero() {
+			c.Header("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.SuccessorPrefix != "" {
+			successor := d.SuccessorPrefix + strings.TrimPrefix(c.Request.URL.Path, d.Prefix)
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		}
+
+		if !d.Sunset.IsZero() && !time.Now().Before(d.Sunset) {
+			utils.AbortWithError(c, retired)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,118 @@
+package middlewares
+
+import (
+	"booking-service/internal/pricing"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/auth"
+	"booking-service/pkg/money"
+	"booking-service/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:booking-service:problem:"
+)
+
+// Problem is an RFC 7807 error response, extended with a stable error code
+// and the request ID.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// sentinels maps errors that reach handlers without an AppError to a status.
+var sentinels = []struct {
+	err    error
+	status int
+	code   utils.ErrorCode
+	title  string
+}{
+	{auth.ErrInvalidToken, http.StatusUnauthorized, utils.CodeInvalidToken, "Invalid token"},
+	{tenancy.ErrUnknownTenant, http.StatusForbidden, utils.CodeUnknownTenant, "Unknown tenant"},
+	{money.ErrUnsupportedCurrency, http.StatusBadRequest, utils.CodeUnsupportedCurrency, "Unsupported currency"},
+	{money.ErrCurrencyMismatch, http.StatusBadRequest, utils.CodeCurrencyMismatch, "Currency mismatch"},
+	{money.ErrInvalidAmount, http.StatusBadRequest, utils.CodeInvalidAmount, "Invalid amount"},
+	{pricing.ErrUnknownStrategy, http.StatusBadRequest, utils.CodeUnknownPricingStrategy, "Unknown pricing strategy"},
+	{gorm.ErrRecordNotFound, http.StatusNotFound, utils.CodeNotFound, "Not found"},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, utils.CodeRequestTimedOut, "Request timed out"},
+}
+
+// ErrorHandler renders the last error attached with c.Error as a problem
+// response, unless the handler already wrote one. With exposeDetails false,
+// as in production, the detail of server errors is withheld.
+func ErrorHandler(exposeDetails bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		WriteProblem(c, NewProblem(c.Errors.Last().Err, exposeDetails))
+	}
+}
+
+// Recovery turns panics into a 500 problem response.
+func Recovery(exposeDetails bool) gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		WriteProblem(c, NewProblem(fmt.Errorf("panic: %v", recovered), exposeDetails))
+		c.Abort()
+	})
+}
+
+// NewProblem maps an AppError or a known sentinel error to a problem. Any
+// other error is an internal server error.
+func NewProblem(err error, exposeDetails bool) Problem {
+	problem := Problem{
+		Status: http.StatusInternalServerError,
+		Title:  "Internal server error",
+		Detail: err.Error(),
+		Code:   string(utils.CodeInternal),
+	}
+
+	var appErr *utils.AppError
+	if errors.As(err, &appErr) {
+		problem.Title = appErr.Message
+		problem.Detail = appErr.Details
+		if appErr.Code >= http.StatusBadRequest && appErr.Code < 600 {
+			problem.Status = appErr.Code
+		}
+		if appErr.ErrorCode != "" {
+			problem.Code = string(appErr.ErrorCode)
+		}
+	} else {
+		for _, sentinel := range sentinels {
+			if errors.Is(err, sentinel.err) {
+				problem.Status = sentinel.status
+				problem.Code = string(sentinel.code)
+				problem.Title = sentinel.title
+				break
+			}
+		}
+	}
+
+	if !exposeDetails && problem.Status >= http.StatusInternalServerError {
+		problem.Detail = ""
+	}
+	problem.Type = problemTypePrefix + problem.Code
+	return problem
+}
+
+// WriteProblem writes the problem with the request's path and ID.
+func WriteProblem(c *gin.Context, problem Problem) {
+	problem.Instance = c.Request.URL.Path
+	problem.RequestID = GetRequestID(c)
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
@@ -1,22 +1,36 @@
 package middlewares
 
 import (
-	"log"
+	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Logger() gin.HandlerFunc {
+// Logger writes one structured access log line per request. Server errors are
+// logged at error level and client errors at warn level.
+func Logger(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
 
 		c.Next()
 
-		log.Printf("%s %s %d %s",
-			c.Request.Method,
-			c.Request.URL.Path,
-			c.Writer.Status(),
-			time.Since(startTime))
+		level := slog.LevelInfo
+		switch status := c.Writer.Status(); {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		logger.LogAttrs(c.Request.Context(), level, "HTTP request",
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("duration", time.Since(startTime)),
+			slog.String("client_ip", c.ClientIP()),
+		)
 	}
 }
@@ -0,0 +1,91 @@
+package middlewares
+
+import (
+	"booking-service/internal/settings"
+	"booking-service/utils"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitSweepInterval is how often buckets of idle clients are dropped.
+const rateLimitSweepInterval = time.Minute
+
+// RateLimit limits each client IP to the current settings' token bucket and
+// answers 429 with Retry-After when it is empty. Limit changes apply to the
+// next request. The client IP is taken from X-Forwarded-For only when the
+// request comes through one of the engine's trusted proxies.
+func RateLimit(settingsStore *settings.Store) gin.HandlerFunc {
+	limiter := newRateLimiter(time.Now)
+	return func(c *gin.Context) {
+		ok, retryAfter := limiter.allow(c.ClientIP(), settingsStore.Current().RateLimit)
+		if ok {
+			c.Next()
+			return
+		}
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		utils.AbortWithError(c, utils.NewAppError(http.StatusTooManyRequests, utils.CodeTooManyRequests, "Too many requests", ""))
+	}
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func newRateLimiter(now func() time.Time) *rateLimiter {
+	return &rateLimiter{buckets: make(map[string]*bucket), now: now, lastSweep: now()}
+}
+
+// allow takes a token from key's bucket, or reports how long until one is
+// available.
+func (l *rateLimiter) allow(key string, limit settings.RateLimit) (bool, time.Duration) {
+	if limit.RequestsPerSecond <= 0 {
+		return true, 0
+	}
+	burst := float64(limit.Burst)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		l.sweep(now, limit)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.RequestsPerSecond)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / limit.RequestsPerSecond * float64(time.Second))
+}
+
+// sweep drops buckets that have refilled completely, which behave the same
+// as new ones.
+func (l *rateLimiter) sweep(now time.Time, limit settings.RateLimit) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*limit.RequestsPerSecond >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"booking-service/internal/tenancy"
+	"booking-service/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const tenantIDKey = "tenantID"
+
+// Tenant resolves the tenant from the token's tenant_id claim, falling back to
+// the tenant of the host if it resolves by host, else the default tenant, and
+// scopes the request context to it. A token is rejected on another tenant's
+// host. It must run after Auth.
+func Tenant(registry *tenancy.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var claimTenantID string
+		if claims, ok := GetClaims(c); ok {
+			claimTenantID = claims.TenantID
+		}
+		tenant, err := registry.Resolve(claimTenantID, c.Request.Host)
+		if errors.Is(err, tenancy.ErrTenantMismatch) {
+			utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeTenantMismatch, "Tenant mismatch", "Token was issued for another tenant"))
+			return
+		}
+		if errors.Is(err, tenancy.ErrTenantRequired) {
+			utils.AbortWithError(c, utils.NewAppError(http.StatusForbidden, utils.CodeTenantRequired, "Tenant required", "Token has no tenant_id claim"))
+			return
+		}
+		if err != nil {
+			utils.AbortWithError(c, err)
+			return
+		}
+
+		c.Set(tenantIDKey, tenant.ID)
+		c.Request = c.Request.WithContext(tenancy.WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
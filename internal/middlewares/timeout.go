@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"booking-service/utils"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout bounds the request context so that database queries and Kafka writes
+// issued by the handler are canceled when the deadline passes or the client
+// disconnects. Handlers that give up without responding get a 504, rendered
+// by ErrorHandler. A non-positive timeout leaves only client disconnects to
+// cancel the request.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			utils.AbortWithError(c, utils.NewAppError(http.StatusGatewayTimeout, utils.CodeRequestTimedOut, "Request timed out", ""))
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"booking-service/internal/openapi"
+	"booking-service/utils"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+// ValidateRequest checks the parameters and body of requests to documented
+// routes against the OpenAPI document and answers 400 when they do not
+// conform. Document paths are relative to prefix, the root of the API version
+// being served. Undocumented routes pass through. Authentication is left to
+// Auth.
+func ValidateRequest(doc *openapi3.T, prefix string) gin.HandlerFunc {
+	options := &openapi3filter.Options{
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		SkipSettingDefaults: true,
+	}
+	return func(c *gin.Context) {
+		route := strings.TrimPrefix(c.FullPath(), prefix)
+		item, operation := openapi.Operation(doc, c.Request.Method, route)
+		if operation == nil {
+			c.Next()
+			return
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route: &routers.Route{
+				Spec:      doc,
+				Path:      openapi.PathTemplate(route),
+				PathItem:  item,
+				Method:    c.Request.Method,
+				Operation: operation,
+			},
+			Options: options,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			utils.AbortWithError(c, utils.NewAppError(http.StatusBadRequest, utils.CodeInvalidRequest, "Invalid request", describeValidationError(err)))
+			return
+		}
+		c.Next()
+	}
+}
+
+// describeValidationError names the offending parameter or body field
+// without dumping the schema, which kin-openapi includes by default.
+func describeValidationError(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+
+	reason := requestErr.Reason
+	var schemaErr *openapi3.SchemaError
+	if errors.As(requestErr.Err, &schemaErr) {
+		reason = schemaErr.Reason
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			reason = strings.Join(pointer, ".") + ": " + reason
+		}
+	} else if reason == "" && requestErr.Err != nil {
+		reason = requestErr.Err.Error()
+	}
+
+	switch {
+	case requestErr.Parameter != nil:
+		return requestErr.Parameter.In + " parameter " + requestErr.Parameter.Name + ": " + reason
+	case requestErr.RequestBody != nil:
+		return "request body: " + reason
+	default:
+		return reason
+	}
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"booking-service/pkg/money"
+	"time"
+)
 
 type BookingStatus string
 
@@ -10,15 +13,37 @@ const (
 	BookingStatusCanceled  BookingStatus = "CANCELED"
 )
 
+// bookingTransitions lists the statuses a booking can move to each status
+// from. Nothing moves back to pending.
+var bookingTransitions = map[BookingStatus][]BookingStatus{
+	BookingStatusConfirmed: {BookingStatusPending},
+	BookingStatusCanceled:  {BookingStatusPending, BookingStatusConfirmed},
+}
+
+// Sources returns the statuses a booking can move to s from.
+func (s BookingStatus) Sources() []BookingStatus {
+	return bookingTransitions[s]
+}
+
+// Booking lifecycle events, published to Kafka and delivered to webhooks.
+const (
+	EventBookingCreated   = "booking.created"
+	EventBookingConfirmed = "booking.confirmed"
+	EventBookingCanceled  = "booking.canceled"
+)
+
 type Booking struct {
 	ID          uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID    string        `gorm:"not null;default:default;index" json:"tenant_id"`
 	UserID      uint          `gorm:"not null" json:"user_id"`
 	EventID     uint          `gorm:"not null" json:"event_id"`
-	TotalAmount float64       `gorm:"not null" json:"total_amount"`
+	FeeAmount   int64         `gorm:"not null;default:0" json:"fee_amount"` // Tenant booking fee in TotalAmount's currency, included in the total
+	TotalAmount money.Money   `gorm:"embedded;embeddedPrefix:total_" json:"total_amount"`
 	Status      BookingStatus `gorm:"not null" json:"status"`
 	CreatedAt   time.Time     `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
 
-	Tickets []Ticket `gorm:"foreignKey:BookingID" json:"tickets"`
-	Event   Event    `gorm:"foreignKey:EventID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"event"` // Add relationship with Event
+	Tickets      []Ticket      `gorm:"foreignKey:BookingID" json:"tickets"`
+	PriceRecords []PriceRecord `gorm:"foreignKey:BookingID" json:"price_records,omitempty"`                           // Prices locked at hold time
+	Event        Event         `gorm:"foreignKey:EventID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"event"` // Add relationship with Event
 }
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "PENDING"
+	OutboxStatusSent    OutboxStatus = "SENT"
+	OutboxStatusFailed  OutboxStatus = "FAILED" // Gave up after the maximum attempts
+)
+
+// OutboxMessage is a Kafka message persisted before publishing so it survives
+// broker outages and restarts. Topics already carry the tenant prefix.
+type OutboxMessage struct {
+	ID        uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Topic     string       `gorm:"not null" json:"topic"`
+	Payload   string       `gorm:"type:text;not null" json:"payload"`
+	Headers   string       `gorm:"type:text" json:"headers,omitempty"` // JSON trace context captured when the message was enqueued
+	Status    OutboxStatus `gorm:"not null;index" json:"status"`
+	Attempts  int          `gorm:"not null;default:0" json:"attempts"`
+	LastError string       `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	SentAt    *time.Time   `json:"sent_at,omitempty"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"booking-service/pkg/money"
+	"time"
+)
+
+// PricingTier groups tickets of an event that share a base price and a
+// pricing strategy.
+type PricingTier struct {
+	ID             uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID       string      `gorm:"not null;default:default;index" json:"tenant_id"`
+	EventID        uint        `gorm:"not null;index" json:"event_id"`
+	Name           string      `gorm:"not null" json:"name"`
+	BasePrice      money.Money `gorm:"embedded;embeddedPrefix:base_price_" json:"base_price"`
+	Strategy       string      `gorm:"not null;default:fixed" json:"strategy"`
+	StrategyConfig string      `gorm:"type:text" json:"strategy_config,omitempty"`
+	CreatedAt      time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Event Event `gorm:"foreignKey:EventID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
+
+// PriceRecord is the audit trail of a price evaluated at hold time and locked
+// into a booking.
+type PriceRecord struct {
+	ID          uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID    string      `gorm:"not null;default:default;index" json:"tenant_id"`
+	BookingID   uint        `gorm:"not null;index" json:"booking_id"`
+	TicketID    uint        `gorm:"not null;index" json:"ticket_id"`
+	TierID      *uint       `json:"tier_id,omitempty"`
+	Strategy    string      `gorm:"not null" json:"strategy"`
+	BasePrice   money.Money `gorm:"embedded;embeddedPrefix:base_price_" json:"base_price"`
+	Price       money.Money `gorm:"embedded;embeddedPrefix:price_" json:"price"`
+	TierTotal   int64       `json:"tier_total"`
+	TierHeld    int64       `json:"tier_held"`
+	EvaluatedAt time.Time   `gorm:"not null" json:"evaluated_at"`
+}
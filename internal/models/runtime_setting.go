@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// RuntimeSetting overrides one runtime setting service-wide while the service
+// runs. Keys are named like settings.Settings.Set, e.g. "hold_ttl" or
+// "features.bookings".
+type RuntimeSetting struct {
+	Key       string    `gorm:"primaryKey" json:"key"`
+	Value     string    `gorm:"not null" json:"value"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"booking-service/pkg/money"
+	"time"
+)
 
 type TicketStatus string
 
@@ -10,15 +13,24 @@ const (
 	TicketStatusSold      TicketStatus = "SOLD"
 )
 
+// EventTicketTransferred is published when a ticket changes owner.
+const EventTicketTransferred = "ticket.transferred"
+
 type Ticket struct {
 	ID        uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID  string       `gorm:"not null;default:default;index" json:"tenant_id"`
 	EventID   uint         `gorm:"not null" json:"event_id"`
-	Price     float64      `gorm:"not null" json:"price"`
+	TierID    *uint        `gorm:"index" json:"tier_id,omitempty"` // Nullable, set when priced by a tier
+	Price     money.Money  `gorm:"embedded;embeddedPrefix:price_" json:"price"`
 	Status    TicketStatus `gorm:"not null" json:"status"`
 	UserID    *uint        `json:"user_id,omitempty"`    // Nullable, only set when reserved
 	BookingID *uint        `json:"booking_id,omitempty"` // Nullable, only set when booked
-	CreatedAt time.Time    `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+	// CredentialVersion is bumped whenever the ticket changes hands, is
+	// released or is reserved again, so credentials issued for an earlier
+	// version no longer admit.
+	CredentialVersion uint      `gorm:"not null;default:1" json:"credential_version"`
+	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
 	Event Event `gorm:"foreignKey:EventID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"event"` // Add relationship with Event
 }
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type TicketTransferStatus string
+
+const (
+	TicketTransferPending  TicketTransferStatus = "PENDING"
+	TicketTransferAccepted TicketTransferStatus = "ACCEPTED"
+	TicketTransferCanceled TicketTransferStatus = "CANCELED" // By the sender, or superseded by a newer transfer
+	TicketTransferExpired  TicketTransferStatus = "EXPIRED"
+)
+
+// TicketTransfer is an offer to hand a sold ticket to another user, accepted
+// with a token the sender shares out of band. Transfers are kept after they
+// settle as the ticket's ownership history.
+type TicketTransfer struct {
+	ID         uint                 `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID   string               `gorm:"not null;default:default;index" json:"tenant_id"`
+	TicketID   uint                 `gorm:"not null;index" json:"ticket_id"`
+	FromUserID uint                 `gorm:"not null" json:"from_user_id"`
+	ToUserID   *uint                `json:"to_user_id,omitempty"` // Only this user may accept when set at creation; the recipient once accepted
+	TokenHash  string               `gorm:"not null;uniqueIndex" json:"-"`
+	Status     TicketTransferStatus `gorm:"not null" json:"status"`
+	ExpiresAt  time.Time            `gorm:"not null" json:"expires_at"`
+	AcceptedAt *time.Time           `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time            `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
+}
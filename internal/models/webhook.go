@@ -0,0 +1,83 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WebhookEventTypes lists the events a webhook can subscribe to.
+var WebhookEventTypes = []string{EventBookingCreated, EventBookingConfirmed, EventBookingCanceled, EventTicketTransferred}
+
+// EventFilter lists the event types a subscription receives, stored
+// comma-separated. An empty filter receives every event.
+type EventFilter []string
+
+// Matches reports whether the filter lets eventType through.
+func (f EventFilter) Matches(eventType string) bool {
+	return len(f) == 0 || slices.Contains(f, eventType)
+}
+
+func (f EventFilter) Value() (driver.Value, error) {
+	return strings.Join(f, ","), nil
+}
+
+func (f *EventFilter) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EventFilter", value)
+	}
+	*f = nil
+	if s != "" {
+		*f = strings.Split(s, ",")
+	}
+	return nil
+}
+
+// WebhookSubscription is a partner endpoint receiving booking events over
+// HTTP. Payloads are signed with Secret, which is only shown on creation.
+type WebhookSubscription struct {
+	ID        uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID  string      `gorm:"not null;default:default;index" json:"tenant_id"`
+	URL       string      `gorm:"not null" json:"url"`
+	Secret    string      `gorm:"not null" json:"-"`
+	Events    EventFilter `gorm:"type:text" json:"events"`
+	Active    bool        `gorm:"not null;default:true" json:"active"`
+	CreatedAt time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED" // Gave up after the maximum attempts
+)
+
+// WebhookDelivery is one event to send to one subscription, and the log of
+// attempts to send it. Retries send the same payload.
+type WebhookDelivery struct {
+	ID             uint                  `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID       string                `gorm:"not null;default:default;index" json:"tenant_id"`
+	SubscriptionID uint                  `gorm:"not null;index" json:"subscription_id"`
+	EventType      string                `gorm:"not null" json:"event_type"`
+	Payload        string                `gorm:"type:text;not null" json:"payload"`
+	Status         WebhookDeliveryStatus `gorm:"not null" json:"status"`
+	Attempts       int                   `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time             `gorm:"not null" json:"next_attempt_at"`
+	ResponseStatus int                   `json:"response_status,omitempty"` // HTTP status of the last attempt
+	LastError      string                `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt      time.Time             `gorm:"autoCreateTime" json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+
+	Subscription WebhookSubscription `gorm:"foreignKey:SubscriptionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
@@ -0,0 +1,48 @@
+// Package openapi embeds the OpenAPI 3 document describing the HTTP API.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// Load parses and validates the embedded document.
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	return doc, nil
+}
+
+// PathTemplate converts a gin route such as /api/bookings/:id to the OpenAPI
+// path template /api/bookings/{id}.
+func PathTemplate(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Operation returns the path item and operation documented for a gin route.
+// The operation is nil for undocumented routes.
+func Operation(doc *openapi3.T, method, route string) (*openapi3.PathItem, *openapi3.Operation) {
+	item := doc.Paths.Value(PathTemplate(route))
+	if item == nil {
+		return nil, nil
+	}
+	return item, item.GetOperation(method)
+}
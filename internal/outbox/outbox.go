@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/pkg/kafka"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// producer stores messages in the outbox instead of writing them to Kafka,
+// so a broker outage or a restart cannot lose them. The Relay publishes them.
+type producer struct {
+	repo repositories.OutboxRepository
+}
+
+// NewProducer returns a kafka.Producer that enqueues into the outbox.
+func NewProducer(repo repositories.OutboxRepository) kafka.Producer {
+	return &producer{repo: repo}
+}
+
+func (p *producer) Publish(ctx context.Context, topic string, message interface{}) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to serialize message: %w", err)
+	}
+
+	// Keep the caller's trace so the relayed message continues it
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	headers, err := json.Marshal(carrier)
+	if err != nil {
+		return fmt.Errorf("failed to serialize trace headers: %w", err)
+	}
+
+	return p.repo.Enqueue(ctx, &models.OutboxMessage{
+		Topic:   topic,
+		Payload: string(payload),
+		Headers: string(headers),
+	})
+}
+
+// Close is a no-op; the outbox shares the application's database pool.
+func (p *producer) Close() error {
+	return nil
+}
+
+// RelayConfig tunes how the relay drains the outbox.
+type RelayConfig struct {
+	Interval    time.Duration // how often to poll for pending messages
+	BatchSize   int           // messages claimed per transaction
+	MaxAttempts int           // publish attempts before a message is marked failed
+}
+
+// Relay publishes pending outbox messages to Kafka.
+type Relay struct {
+	repo     repositories.OutboxRepository
+	producer kafka.Producer
+	config   RelayConfig
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	Logger   *slog.Logger
+}
+
+func NewRelay(repo repositories.OutboxRepository, producer kafka.Producer, config RelayConfig) *Relay {
+	return &Relay{
+		repo:     repo,
+		producer: producer,
+		config:   config,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		Logger:   slog.Default(),
+	}
+}
+
+func (r *Relay) Name() string { return "outbox relay" }
+
+// Run polls the outbox until Shutdown is called.
+func (r *Relay) Run(ctx context.Context) error {
+	defer close(r.done)
+	ctx = context.WithoutCancel(ctx)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return nil
+		case <-ticker.C:
+			if _, err := r.Flush(ctx); err != nil {
+				r.Logger.ErrorContext(ctx, "Failed to relay outbox messages", "error", err)
+			}
+		}
+	}
+}
+
+// Shutdown stops polling and makes a last pass so messages written while
+// draining still reach Kafka before the producer is closed.
+func (r *Relay) Shutdown(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	_, err := r.Flush(ctx)
+	return err
+}
+
+// Flush publishes pending messages in batches until none are left or a batch
+// makes no progress, and returns how many were published.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		sent, err := r.repo.ProcessPending(ctx, r.config.BatchSize, r.config.MaxAttempts, func(message models.OutboxMessage) error {
+			return r.producer.Publish(messageContext(ctx, message), message.Topic, json.RawMessage(message.Payload))
+		})
+		total += sent
+		if err != nil || sent < r.config.BatchSize {
+			return total, err
+		}
+	}
+}
+
+// messageContext restores the trace context stored with the message.
+func messageContext(ctx context.Context, message models.OutboxMessage) context.Context {
+	carrier := propagation.MapCarrier{}
+	if message.Headers == "" || json.Unmarshal([]byte(message.Headers), &carrier) != nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
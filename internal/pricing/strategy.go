@@ -0,0 +1,165 @@
+package pricing
+
+import (
+	"booking-service/pkg/money"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	StrategyFixed       = "fixed"
+	StrategySellThrough = "sell_through"
+	StrategyTimeToEvent = "time_to_event"
+
+	// basisPoints is the multiplier that leaves a price unchanged.
+	basisPoints = 10000
+)
+
+var ErrUnknownStrategy = errors.New("unknown pricing strategy")
+
+// Input is the demand snapshot a strategy prices against.
+type Input struct {
+	Base      money.Money
+	Total     int64 // tickets in the tier
+	Held      int64 // tickets reserved or sold
+	Now       time.Time
+	EventDate time.Time
+}
+
+// SoldPercent returns the share of inventory already held, from 0 to 100.
+func (in Input) SoldPercent() float64 {
+	if in.Total <= 0 {
+		return 0
+	}
+	return float64(in.Held) * 100 / float64(in.Total)
+}
+
+// Strategy computes the current price of a ticket.
+type Strategy interface {
+	Name() string
+	Price(in Input) (money.Money, error)
+}
+
+// Build creates a strategy from its name and JSON configuration.
+func Build(name string, config []byte) (Strategy, error) {
+	if len(config) == 0 {
+		config = []byte("{}")
+	}
+
+	switch name {
+	case "", StrategyFixed:
+		return Fixed{}, nil
+	case StrategySellThrough:
+		var s SellThroughSteps
+		if err := json.Unmarshal(config, &s); err != nil {
+			return nil, fmt.Errorf("invalid %s config: %w", name, err)
+		}
+		return s, s.validate()
+	case StrategyTimeToEvent:
+		var s TimeToEventSteps
+		if err := json.Unmarshal(config, &s); err != nil {
+			return nil, fmt.Errorf("invalid %s config: %w", name, err)
+		}
+		return s, s.validate()
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+	}
+}
+
+// Fixed always charges the base price.
+type Fixed struct{}
+
+func (Fixed) Name() string { return StrategyFixed }
+
+func (Fixed) Price(in Input) (money.Money, error) {
+	return in.Base, nil
+}
+
+// SellThroughStep raises the price once ThresholdPercent of the tier is held.
+type SellThroughStep struct {
+	ThresholdPercent float64 `json:"threshold_percent"`
+	MultiplierBps    int64   `json:"multiplier_bps"`
+}
+
+// SellThroughSteps applies the multiplier of the highest threshold reached.
+type SellThroughSteps struct {
+	Steps []SellThroughStep `json:"steps"`
+}
+
+func (SellThroughSteps) Name() string { return StrategySellThrough }
+
+func (s SellThroughSteps) Price(in Input) (money.Money, error) {
+	sold := in.SoldPercent()
+	multiplier := int64(basisPoints)
+	reached := -1.0
+	for _, step := range s.Steps {
+		if sold >= step.ThresholdPercent && step.ThresholdPercent > reached {
+			reached = step.ThresholdPercent
+			multiplier = step.MultiplierBps
+		}
+	}
+	return in.Base.MultiplyBps(multiplier)
+}
+
+func (s SellThroughSteps) validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("sell_through requires at least one step")
+	}
+	for _, step := range s.Steps {
+		if step.ThresholdPercent < 0 || step.ThresholdPercent > 100 {
+			return fmt.Errorf("threshold_percent %v must be between 0 and 100", step.ThresholdPercent)
+		}
+		if step.MultiplierBps <= 0 {
+			return fmt.Errorf("multiplier_bps %d must be positive", step.MultiplierBps)
+		}
+	}
+	return nil
+}
+
+// TimeToEventStep raises the price once the event is WithinHours away.
+type TimeToEventStep struct {
+	WithinHours   int64 `json:"within_hours"`
+	MultiplierBps int64 `json:"multiplier_bps"`
+}
+
+// TimeToEventSteps applies the multiplier of the closest window entered.
+type TimeToEventSteps struct {
+	Steps []TimeToEventStep `json:"steps"`
+}
+
+func (TimeToEventSteps) Name() string { return StrategyTimeToEvent }
+
+func (s TimeToEventSteps) Price(in Input) (money.Money, error) {
+	if in.EventDate.IsZero() {
+		return in.Base, nil
+	}
+
+	steps := append([]TimeToEventStep(nil), s.Steps...)
+	sort.Slice(steps, func(i, j int) bool { return steps[i].WithinHours < steps[j].WithinHours })
+
+	remaining := in.EventDate.Sub(in.Now)
+	for _, step := range steps {
+		if remaining <= time.Duration(step.WithinHours)*time.Hour {
+			return in.Base.MultiplyBps(step.MultiplierBps)
+		}
+	}
+	return in.Base, nil
+}
+
+func (s TimeToEventSteps) validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("time_to_event requires at least one step")
+	}
+	for _, step := range s.Steps {
+		if step.WithinHours <= 0 {
+			return fmt.Errorf("within_hours %d must be positive", step.WithinHours)
+		}
+		if step.MultiplierBps <= 0 {
+			return fmt.Errorf("multiplier_bps %d must be positive", step.MultiplierBps)
+		}
+	}
+	return nil
+}
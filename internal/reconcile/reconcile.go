@@ -0,0 +1,274 @@
+package reconcile
+
+import (
+	"booking-service/internal/metrics"
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/money"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Kind classifies an inconsistency between tickets and bookings.
+type Kind string
+
+const (
+	KindConfirmedTicketNotSold   Kind = "confirmed_ticket_not_sold"
+	KindCanceledTicketHeld       Kind = "canceled_ticket_held"
+	KindPendingTicketNotReserved Kind = "pending_ticket_not_reserved"
+	KindTicketMissingBooking     Kind = "ticket_missing_booking"
+	KindHeldTicketWithoutBooking Kind = "held_ticket_without_booking"
+	KindTotalMismatch            Kind = "total_mismatch"
+)
+
+// Kinds lists every inconsistency the reconciler looks for.
+var Kinds = []Kind{
+	KindConfirmedTicketNotSold,
+	KindCanceledTicketHeld,
+	KindPendingTicketNotReserved,
+	KindTicketMissingBooking,
+	KindHeldTicketWithoutBooking,
+	KindTotalMismatch,
+}
+
+// Issue is one inconsistency found by a run.
+type Issue struct {
+	Kind      Kind   `json:"kind"`
+	BookingID uint   `json:"booking_id,omitempty"`
+	TicketID  uint   `json:"ticket_id,omitempty"`
+	Detail    string `json:"detail"`
+	Repaired  bool   `json:"repaired"`
+}
+
+// Report summarizes a run for one tenant.
+type Report struct {
+	TenantID        string       `json:"tenant_id"`
+	StartedAt       time.Time    `json:"started_at"`
+	FinishedAt      time.Time    `json:"finished_at"`
+	Repair          bool         `json:"repair"`
+	BookingsScanned int          `json:"bookings_scanned"`
+	Counts          map[Kind]int `json:"counts"`
+	Repaired        int          `json:"repaired"`
+	Issues          []Issue      `json:"issues"`
+}
+
+func (r *Report) add(issue Issue) {
+	r.Issues = append(r.Issues, issue)
+	r.Counts[issue.Kind]++
+	if issue.Repaired {
+		r.Repaired++
+	}
+}
+
+// Config tunes a Reconciler.
+type Config struct {
+	BatchSize int           // bookings loaded per query
+	MinAge    time.Duration // bookings updated more recently may be mid-transition and are skipped
+	Repair    bool          // apply the repair rules instead of only reporting
+}
+
+// Reconciler finds tickets whose status disagrees with their booking and
+// bookings whose total disagrees with their prices. ConfirmBooking and
+// CancelBooking only log ticket update failures, so these drift apart.
+//
+// With Repair enabled it applies these rules:
+//   - a RESERVED ticket of a CONFIRMED booking is marked SOLD
+//   - a held ticket of a CANCELED booking, of a missing booking or of no
+//     booking at all is released back on sale
+//
+// Other tickets of CONFIRMED or PENDING bookings and mismatched totals need a
+// human decision and are only reported.
+type Reconciler struct {
+	BookingRepo repositories.BookingRepository
+	TicketRepo  repositories.TicketRepository
+	Config      Config
+	Logger      *slog.Logger
+}
+
+func NewReconciler(bookingRepo repositories.BookingRepository, ticketRepo repositories.TicketRepository, config Config) *Reconciler {
+	return &Reconciler{
+		BookingRepo: bookingRepo,
+		TicketRepo:  ticketRepo,
+		Config:      config,
+		Logger:      slog.Default(),
+	}
+}
+
+// Run reconciles the tenant in ctx and reports what it found.
+func (r *Reconciler) Run(ctx context.Context) (*Report, error) {
+	tenant, _ := tenancy.FromContext(ctx)
+	report := &Report{
+		TenantID:  tenant.ID,
+		StartedAt: time.Now(),
+		Repair:    r.Config.Repair,
+		Counts:    make(map[Kind]int, len(Kinds)),
+		Issues:    []Issue{},
+	}
+
+	scanned := make(map[uint]bool)
+	err := r.BookingRepo.ScanBookings(ctx, report.StartedAt.Add(-r.Config.MinAge), r.Config.BatchSize, func(bookings []models.Booking) error {
+		for _, booking := range bookings {
+			scanned[booking.ID] = true
+			report.BookingsScanned++
+			if err := r.checkBooking(ctx, report, booking); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan bookings: %w", err)
+	}
+
+	if err := r.checkOrphans(ctx, report, scanned); err != nil {
+		return nil, err
+	}
+
+	report.FinishedAt = time.Now()
+	for _, kind := range Kinds {
+		metrics.RecordInconsistencies(ctx, string(kind), report.Counts[kind])
+	}
+	if len(report.Issues) > 0 {
+		r.Logger.WarnContext(ctx, "Reconciler found inconsistencies", "count", len(report.Issues), "repaired", report.Repaired)
+	} else {
+		r.Logger.DebugContext(ctx, "Reconciler found no inconsistencies", "bookings", report.BookingsScanned)
+	}
+	return report, nil
+}
+
+func (r *Reconciler) checkBooking(ctx context.Context, report *Report, booking models.Booking) error {
+	for _, ticket := range booking.Tickets {
+		issue := Issue{BookingID: booking.ID, TicketID: ticket.ID}
+		switch {
+		case booking.Status == models.BookingStatusConfirmed && ticket.Status != models.TicketStatusSold:
+			issue.Kind = KindConfirmedTicketNotSold
+			issue.Detail = fmt.Sprintf("ticket is %s on a confirmed booking", ticket.Status)
+			if ticket.Status == models.TicketStatusReserved {
+				repaired, err := r.repair(ctx, issue.Kind, func() (bool, error) {
+					return true, r.TicketRepo.UpdateTicketStatus(ctx, ticket.ID, models.TicketStatusSold)
+				})
+				if err != nil {
+					return err
+				}
+				issue.Repaired = repaired
+			}
+		case booking.Status == models.BookingStatusCanceled && ticket.Status != models.TicketStatusAvailable:
+			issue.Kind = KindCanceledTicketHeld
+			issue.Detail = fmt.Sprintf("ticket is %s on a canceled booking", ticket.Status)
+			repaired, err := r.release(ctx, issue.Kind, ticket.ID)
+			if err != nil {
+				return err
+			}
+			issue.Repaired = repaired
+		case booking.Status == models.BookingStatusPending && ticket.Status != models.TicketStatusReserved:
+			issue.Kind = KindPendingTicketNotReserved
+			issue.Detail = fmt.Sprintf("ticket is %s on a pending booking", ticket.Status)
+		default:
+			continue
+		}
+		report.add(issue)
+	}
+
+	if detail := checkTotal(booking); detail != "" {
+		report.add(Issue{Kind: KindTotalMismatch, BookingID: booking.ID, Detail: detail})
+	}
+	return nil
+}
+
+// checkTotal compares the total with the locked prices plus the fee, or with
+// the ticket prices for bookings made before prices were locked. It returns a
+// description of the mismatch, if any.
+func checkTotal(booking models.Booking) string {
+	var prices []money.Money
+	switch {
+	case len(booking.PriceRecords) > 0:
+		for _, record := range booking.PriceRecords {
+			prices = append(prices, record.Price)
+		}
+	case booking.Status != models.BookingStatusCanceled:
+		// Tickets of canceled bookings may have been resold since
+		for _, ticket := range booking.Tickets {
+			prices = append(prices, ticket.Price)
+		}
+	default:
+		return ""
+	}
+
+	currency := booking.TotalAmount.Currency
+	subtotal, err := money.Sum(currency, prices...)
+	if err != nil {
+		return fmt.Sprintf("prices cannot be summed: %v", err)
+	}
+	expected, err := subtotal.Add(money.Money{Amount: booking.FeeAmount, Currency: currency})
+	if err != nil {
+		return fmt.Sprintf("fee cannot be added: %v", err)
+	}
+	if expected != booking.TotalAmount {
+		return fmt.Sprintf("total is %s but prices and fee add up to %s", booking.TotalAmount, expected)
+	}
+	return ""
+}
+
+// checkOrphans reports held tickets whose booking is missing or was never
+// set. Tickets of scanned canceled bookings were already reported, and
+// bookings too recent to scan are left for the next run.
+func (r *Reconciler) checkOrphans(ctx context.Context, report *Report, scanned map[uint]bool) error {
+	tickets, err := r.TicketRepo.ListOrphanedTickets(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, ticket := range tickets {
+		issue := Issue{TicketID: ticket.ID}
+		if ticket.BookingID == nil {
+			issue.Kind = KindHeldTicketWithoutBooking
+			issue.Detail = fmt.Sprintf("ticket is %s without a booking", ticket.Status)
+		} else {
+			if scanned[*ticket.BookingID] {
+				continue
+			}
+			booking, err := r.BookingRepo.GetBookingByID(ctx, *ticket.BookingID)
+			if err != nil {
+				return err
+			}
+			if booking != nil {
+				continue
+			}
+			issue.Kind = KindTicketMissingBooking
+			issue.BookingID = *ticket.BookingID
+			issue.Detail = fmt.Sprintf("ticket is %s for a booking that does not exist", ticket.Status)
+		}
+
+		if issue.Repaired, err = r.release(ctx, issue.Kind, ticket.ID); err != nil {
+			return err
+		}
+		report.add(issue)
+	}
+	return nil
+}
+
+// release puts a ticket back on sale unless it was booked since it was read.
+func (r *Reconciler) release(ctx context.Context, kind Kind, ticketID uint) (bool, error) {
+	return r.repair(ctx, kind, func() (bool, error) {
+		released, err := r.TicketRepo.ReleaseOrphanedTickets(ctx, []uint{ticketID})
+		return released > 0, err
+	})
+}
+
+// repair applies fix when repairs are enabled and records the outcome.
+func (r *Reconciler) repair(ctx context.Context, kind Kind, fix func() (bool, error)) (bool, error) {
+	if !r.Config.Repair {
+		return false, nil
+	}
+	repaired, err := fix()
+	if err != nil {
+		return false, fmt.Errorf("failed to repair %s: %w", kind, err)
+	}
+	if repaired {
+		metrics.RecordRepair(ctx, string(kind))
+	}
+	return repaired, nil
+}
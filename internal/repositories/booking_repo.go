@@ -2,18 +2,56 @@ package repositories
 
 import (
 	"booking-service/internal/models"
+	"booking-service/pkg/pagination"
+	"context"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// BookingQuery filters and pages a booking listing. Bookings are ordered by
+// creation time and then ID, so a page continues exactly after its cursor.
+// Zero values do not filter.
+type BookingQuery struct {
+	Status      models.BookingStatus
+	EventID     uint
+	CreatedFrom time.Time // inclusive
+	CreatedTo   time.Time // exclusive
+	Descending  bool
+	After       *pagination.Cursor
+	Limit       int
+}
+
+// BookingCriteria selects bookings for support staff. Every set field must
+// match; zero values do not filter. Totals are compared in minor units of
+// TotalCurrency. Results are paged like BookingQuery.
+type BookingCriteria struct {
+	BookingID     uint
+	UserID        uint
+	EventID       uint
+	TicketID      uint
+	Statuses      []models.BookingStatus
+	CreatedFrom   time.Time // inclusive
+	CreatedTo     time.Time // exclusive
+	TotalCurrency string
+	MinTotal      *int64 // inclusive
+	MaxTotal      *int64 // inclusive
+	Descending    bool
+	After         *pagination.Cursor
+	Limit         int
+}
+
 type BookingRepository interface {
-	CreateBooking(booking *models.Booking) error
-	GetBookingByID(bookingID uint) (*models.Booking, error)
-	UpdateBookingStatus(bookingID uint, status models.BookingStatus) error
-	DeleteBooking(bookingID uint) error
-	ListBookingsByUserID(userID uint, page, pageSize int) ([]models.Booking, error)
-	GetPendingBookingsOlderThan(duration time.Duration) ([]models.Booking, error)
+	CreateBooking(ctx context.Context, booking *models.Booking) error
+	GetBookingByID(ctx context.Context, bookingID uint) (*models.Booking, error)
+	TransitionBookingStatus(ctx context.Context, bookingID uint, from []models.BookingStatus, to models.BookingStatus) (bool, error)
+	DeleteBooking(ctx context.Context, bookingID uint) error
+	ListBookingsByUserID(ctx context.Context, userID uint, query BookingQuery) ([]models.Booking, error)
+	CountBookingsByUserID(ctx context.Context, userID uint, query BookingQuery) (int64, error)
+	Search(ctx context.Context, criteria BookingCriteria) ([]models.Booking, error)
+	CountSearch(ctx context.Context, criteria BookingCriteria) (int64, error)
+	GetPendingBookingsOlderThan(ctx context.Context, duration time.Duration) ([]models.Booking, error)
+	ScanBookings(ctx context.Context, updatedBefore time.Time, batchSize int, fn func(bookings []models.Booking) error) error
 }
 
 type bookingRepositoryImpl struct {
@@ -26,16 +64,16 @@ func NewBookingRepository(db *gorm.DB) BookingRepository {
 	}
 }
 
-func (r *bookingRepositoryImpl) CreateBooking(booking *models.Booking) error {
-	if err := r.db.Create(booking).Error; err != nil {
+func (r *bookingRepositoryImpl) CreateBooking(ctx context.Context, booking *models.Booking) error {
+	if err := r.db.WithContext(ctx).Create(booking).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *bookingRepositoryImpl) GetBookingByID(bookingID uint) (*models.Booking, error) {
+func (r *bookingRepositoryImpl) GetBookingByID(ctx context.Context, bookingID uint) (*models.Booking, error) {
 	var booking models.Booking
-	if err := r.db.Preload("Tickets").First(&booking, "id = ?", bookingID).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Tickets").Preload("PriceRecords").First(&booking, "id = ?", bookingID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -44,35 +82,143 @@ func (r *bookingRepositoryImpl) GetBookingByID(bookingID uint) (*models.Booking,
 	return &booking, nil
 }
 
-func (r *bookingRepositoryImpl) UpdateBookingStatus(bookingID uint, status models.BookingStatus) error {
-	if err := r.db.Model(&models.Booking{}).Where("id = ?", bookingID).Update("status", status).Error; err != nil {
-		return err
+// TransitionBookingStatus moves a booking to status to if it is in one of
+// the from statuses. It reports false if the booking was not found or has
+// moved on, e.g. when a hold expired while the booking was being confirmed.
+func (r *bookingRepositoryImpl) TransitionBookingStatus(ctx context.Context, bookingID uint, from []models.BookingStatus, to models.BookingStatus) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Booking{}).
+		Where("id = ? AND status IN ?", bookingID, from).
+		Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
 	}
-	return nil
+	return result.RowsAffected > 0, nil
 }
 
-func (r *bookingRepositoryImpl) DeleteBooking(bookingID uint) error {
-	if err := r.db.Delete(&models.Booking{}, "id = ?", bookingID).Error; err != nil {
+func (r *bookingRepositoryImpl) DeleteBooking(ctx context.Context, bookingID uint) error {
+	if err := r.db.WithContext(ctx).Delete(&models.Booking{}, "id = ?", bookingID).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *bookingRepositoryImpl) ListBookingsByUserID(userID uint, page, pageSize int) ([]models.Booking, error) {
+func (r *bookingRepositoryImpl) ListBookingsByUserID(ctx context.Context, userID uint, query BookingQuery) ([]models.Booking, error) {
 	var bookings []models.Booking
-	offset := (page - 1) * pageSize
-	if err := r.db.Where("user_id = ?", userID).Offset(offset).Limit(pageSize).Find(&bookings).Error; err != nil {
+	db := filterBookings(r.db.WithContext(ctx).Where("user_id = ?", userID), query)
+	if err := pageBookings(db, query.Descending, query.After, query.Limit).Find(&bookings).Error; err != nil {
 		return nil, err
 	}
 	return bookings, nil
 }
 
-func (r *bookingRepositoryImpl) GetPendingBookingsOlderThan(duration time.Duration) ([]models.Booking, error) {
+func (r *bookingRepositoryImpl) CountBookingsByUserID(ctx context.Context, userID uint, query BookingQuery) (int64, error) {
+	var count int64
+	db := filterBookings(r.db.WithContext(ctx).Model(&models.Booking{}).Where("user_id = ?", userID), query)
+	if err := db.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Search returns a page of bookings matching criteria, with their tickets.
+func (r *bookingRepositoryImpl) Search(ctx context.Context, criteria BookingCriteria) ([]models.Booking, error) {
+	var bookings []models.Booking
+	db := matchCriteria(r.db.WithContext(ctx).Preload("Tickets"), criteria)
+	if err := pageBookings(db, criteria.Descending, criteria.After, criteria.Limit).Find(&bookings).Error; err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// CountSearch counts the bookings matching criteria on all pages.
+func (r *bookingRepositoryImpl) CountSearch(ctx context.Context, criteria BookingCriteria) (int64, error) {
+	var count int64
+	if err := matchCriteria(r.db.WithContext(ctx).Model(&models.Booking{}), criteria).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// matchCriteria applies the filters of criteria, but not its cursor or limit.
+func matchCriteria(db *gorm.DB, criteria BookingCriteria) *gorm.DB {
+	db = filterBookings(db, BookingQuery{
+		EventID:     criteria.EventID,
+		CreatedFrom: criteria.CreatedFrom,
+		CreatedTo:   criteria.CreatedTo,
+	})
+	if criteria.BookingID != 0 {
+		db = db.Where("id = ?", criteria.BookingID)
+	}
+	if criteria.UserID != 0 {
+		db = db.Where("user_id = ?", criteria.UserID)
+	}
+	if criteria.TicketID != 0 {
+		// Built on db's session so the subquery is scoped to the same tenant
+		tickets := db.Session(&gorm.Session{NewDB: true}).Model(&models.Ticket{}).Select("booking_id").Where("id = ?", criteria.TicketID)
+		db = db.Where("id IN (?)", tickets)
+	}
+	if len(criteria.Statuses) > 0 {
+		db = db.Where("status IN ?", criteria.Statuses)
+	}
+	if criteria.TotalCurrency != "" {
+		db = db.Where("total_currency = ?", criteria.TotalCurrency)
+	}
+	if criteria.MinTotal != nil {
+		db = db.Where("total_amount >= ?", *criteria.MinTotal)
+	}
+	if criteria.MaxTotal != nil {
+		db = db.Where("total_amount <= ?", *criteria.MaxTotal)
+	}
+	return db
+}
+
+// pageBookings orders by (created_at, id) and continues after the cursor.
+func pageBookings(db *gorm.DB, descending bool, after *pagination.Cursor, limit int) *gorm.DB {
+	order, compare := "ASC", ">"
+	if descending {
+		order, compare = "DESC", "<"
+	}
+	if after != nil {
+		db = db.Where("created_at "+compare+" ? OR (created_at = ? AND id "+compare+" ?)",
+			after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	return db.Order("created_at " + order).Order("id " + order).Limit(limit)
+}
+
+// filterBookings applies the filters of query, but not its cursor or limit.
+func filterBookings(db *gorm.DB, query BookingQuery) *gorm.DB {
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.EventID != 0 {
+		db = db.Where("event_id = ?", query.EventID)
+	}
+	if !query.CreatedFrom.IsZero() {
+		db = db.Where("created_at >= ?", query.CreatedFrom)
+	}
+	if !query.CreatedTo.IsZero() {
+		db = db.Where("created_at < ?", query.CreatedTo)
+	}
+	return db
+}
+
+func (r *bookingRepositoryImpl) GetPendingBookingsOlderThan(ctx context.Context, duration time.Duration) ([]models.Booking, error) {
 	var bookings []models.Booking
 	cutoffTime := time.Now().Add(-duration)
 
-	if err := r.db.Where("status = ? AND created_at < ?", models.BookingStatusPending, cutoffTime).Find(&bookings).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("status = ? AND created_at < ?", models.BookingStatusPending, cutoffTime).Find(&bookings).Error; err != nil {
 		return nil, err
 	}
 	return bookings, nil
 }
+
+// ScanBookings hands bookings last updated before updatedBefore to fn in
+// batches, in ID order, with their tickets and price records loaded.
+func (r *bookingRepositoryImpl) ScanBookings(ctx context.Context, updatedBefore time.Time, batchSize int, fn func(bookings []models.Booking) error) error {
+	var batch []models.Booking
+	return r.db.WithContext(ctx).Preload("Tickets").Preload("PriceRecords").
+		Where("updated_at < ?", updatedBefore).
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
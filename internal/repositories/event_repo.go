@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type EventRepository interface {
+	GetEventByID(ctx context.Context, eventID uint) (*models.Event, error)
+}
+
+type eventRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEventRepository(db *gorm.DB) EventRepository {
+	return &eventRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *eventRepositoryImpl) GetEventByID(ctx context.Context, eventID uint) (*models.Event, error) {
+	var event models.Event
+	if err := r.db.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &event, nil
+}
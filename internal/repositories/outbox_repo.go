@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, message *models.OutboxMessage) error
+	ProcessPending(ctx context.Context, limit, maxAttempts int, publish func(message models.OutboxMessage) error) (int, error)
+	List(ctx context.Context, status models.OutboxStatus, ids []uint, limit int) ([]models.OutboxMessage, error)
+	Requeue(ctx context.Context, ids []uint) (int64, error)
+}
+
+type outboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *outboxRepositoryImpl) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	message.Status = models.OutboxStatusPending
+	return r.db.WithContext(ctx).Create(message).Error
+}
+
+// ProcessPending locks up to limit pending messages, oldest first, and hands
+// each to publish. Successful messages are marked sent; failures are retried
+// on the next call until maxAttempts is reached. On Postgres the rows are
+// claimed with SKIP LOCKED so several replicas can relay concurrently.
+func (r *outboxRepositoryImpl) ProcessPending(ctx context.Context, limit, maxAttempts int, publish func(message models.OutboxMessage) error) (int, error) {
+	processed := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("status = ?", models.OutboxStatusPending).Order("id").Limit(limit)
+		if tx.Dialector.Name() == "postgres" {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+
+		var messages []models.OutboxMessage
+		if err := query.Find(&messages).Error; err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			updates := map[string]interface{}{"attempts": message.Attempts + 1}
+			if err := publish(message); err != nil {
+				updates["last_error"] = err.Error()
+				if message.Attempts+1 >= maxAttempts {
+					updates["status"] = models.OutboxStatusFailed
+				}
+			} else {
+				updates["status"] = models.OutboxStatusSent
+				updates["sent_at"] = time.Now()
+				processed++
+			}
+			if err := tx.Model(&models.OutboxMessage{}).Where("id = ?", message.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return processed, err
+}
+
+// List returns messages oldest first, filtered by status and IDs when given.
+func (r *outboxRepositoryImpl) List(ctx context.Context, status models.OutboxStatus, ids []uint, limit int) ([]models.OutboxMessage, error) {
+	query := r.db.WithContext(ctx).Order("id").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	var messages []models.OutboxMessage
+	if err := query.Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// Requeue resets messages to pending with a fresh attempt budget so the relay
+// publishes them again, and returns how many were requeued.
+func (r *outboxRepositoryImpl) Requeue(ctx context.Context, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).Model(&models.OutboxMessage{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":     models.OutboxStatusPending,
+			"attempts":   0,
+			"last_error": "",
+			"sent_at":    nil,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type PricingRepository interface {
+	CreateTier(ctx context.Context, tier *models.PricingTier) error
+	GetTierByID(ctx context.Context, tierID uint) (*models.PricingTier, error)
+	CountTierInventory(ctx context.Context, tierID uint) (total int64, held int64, err error)
+	ListPriceRecordsByBookingID(ctx context.Context, bookingID uint) ([]models.PriceRecord, error)
+}
+
+type pricingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPricingRepository(db *gorm.DB) PricingRepository {
+	return &pricingRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *pricingRepositoryImpl) CreateTier(ctx context.Context, tier *models.PricingTier) error {
+	return r.db.WithContext(ctx).Create(tier).Error
+}
+
+func (r *pricingRepositoryImpl) GetTierByID(ctx context.Context, tierID uint) (*models.PricingTier, error) {
+	var tier models.PricingTier
+	if err := r.db.WithContext(ctx).Preload("Event").First(&tier, "id = ?", tierID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *pricingRepositoryImpl) CountTierInventory(ctx context.Context, tierID uint) (int64, int64, error) {
+	var total, held int64
+	if err := r.db.WithContext(ctx).Model(&models.Ticket{}).Where("tier_id = ?", tierID).Count(&total).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Ticket{}).
+		Where("tier_id = ? AND status <> ?", tierID, models.TicketStatusAvailable).
+		Count(&held).Error; err != nil {
+		return 0, 0, err
+	}
+	return total, held, nil
+}
+
+func (r *pricingRepositoryImpl) ListPriceRecordsByBookingID(ctx context.Context, bookingID uint) ([]models.PriceRecord, error) {
+	var records []models.PriceRecord
+	if err := r.db.WithContext(ctx).Where("booking_id = ?", bookingID).Order("id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
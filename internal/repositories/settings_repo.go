@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"booking-service/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type SettingsRepository interface {
+	ListSettings(ctx context.Context) ([]models.RuntimeSetting, error)
+}
+
+type settingsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSettingsRepository(db *gorm.DB) SettingsRepository {
+	return &settingsRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *settingsRepositoryImpl) ListSettings(ctx context.Context) ([]models.RuntimeSetting, error) {
+	var settings []models.RuntimeSetting
+	if err := r.db.WithContext(ctx).Order("key").Find(&settings).Error; err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
@@ -3,118 +3,230 @@ package repositories
 import (
 	"booking-service/internal/models"
 	"booking-service/utils"
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"gorm.io/gorm"
 )
 
 type TicketRepository interface {
-	CreateTicket(ticket *models.Ticket) error
-	CreateTicketsBatch(tickets []models.Ticket) error
-	GetTicketByID(ticketID uint) (*models.Ticket, error)
-	UpdateTicketStatus(ticketID uint, status models.TicketStatus) error
-	ReserveTicket(ticketID, userID, bookingID uint) error
-	ListAvailableTickets(eventID uint) ([]models.Ticket, error)
-	DeleteTicket(ticketID uint) error
+	CreateTicket(ctx context.Context, ticket *models.Ticket) error
+	CreateTicketsBatch(ctx context.Context, tickets []models.Ticket) error
+	GetTicketByID(ctx context.Context, ticketID uint) (*models.Ticket, error)
+	UpdateTicketStatus(ctx context.Context, ticketID uint, status models.TicketStatus) error
+	ReserveTicket(ctx context.Context, ticketID, userID, bookingID uint) error
+	ListAvailableTickets(ctx context.Context, eventID uint) ([]models.Ticket, error)
+	DeleteTicket(ctx context.Context, ticketID uint) error
+	CountAvailableByEvent(ctx context.Context) ([]EventAvailability, error)
+	ListOrphanedTickets(ctx context.Context, eventID uint) ([]models.Ticket, error)
+	ReleaseOrphanedTickets(ctx context.Context, ticketIDs []uint) (int64, error)
+	ReissueCredential(ctx context.Context, ticketID uint) (*models.Ticket, error)
+}
+
+// EventAvailability is the number of tickets still on sale for an event.
+type EventAvailability struct {
+	TenantID  string
+	EventID   uint
+	Available int64
 }
 
 type ticketRepositoryImpl struct {
 	db     *gorm.DB
-	logger *utils.Logger
+	logger *slog.Logger
 }
 
 func NewTicketRepository(db *gorm.DB) TicketRepository {
 	return &ticketRepositoryImpl{
 		db:     db,
-		logger: utils.NewLogger(),
+		logger: slog.Default(),
 	}
 }
 
-func (r *ticketRepositoryImpl) CreateTicket(ticket *models.Ticket) error {
-	r.logger.Info(fmt.Sprintf("Creating ticket: %+v", ticket))
-	if err := r.db.Create(ticket).Error; err != nil {
-		appErr := utils.NewAppError(500, "Failed to create ticket", err.Error())
-		r.logger.Error(appErr.Error())
+func (r *ticketRepositoryImpl) CreateTicket(ctx context.Context, ticket *models.Ticket) error {
+	r.logger.DebugContext(ctx, "Creating ticket", "event_id", ticket.EventID)
+	if err := r.db.WithContext(ctx).Create(ticket).Error; err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to create ticket", err.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return appErr
 	}
-	r.logger.Info(fmt.Sprintf("Ticket created successfully: %+v", ticket))
+	r.logger.DebugContext(ctx, "Ticket created", "ticket_id", ticket.ID)
 	return nil
 }
 
-func (r *ticketRepositoryImpl) CreateTicketsBatch(tickets []models.Ticket) error {
-	r.logger.Info(fmt.Sprintf("Creating batch of %d tickets", len(tickets)))
-	if err := r.db.Create(&tickets).Error; err != nil {
-		appErr := utils.NewAppError(500, "Failed to create tickets batch", err.Error())
-		r.logger.Error(appErr.Error())
+func (r *ticketRepositoryImpl) CreateTicketsBatch(ctx context.Context, tickets []models.Ticket) error {
+	r.logger.DebugContext(ctx, "Creating tickets", "count", len(tickets))
+	if err := r.db.WithContext(ctx).Create(&tickets).Error; err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to create tickets batch", err.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return appErr
 	}
-	r.logger.Info(fmt.Sprintf("Batch of %d tickets created successfully", len(tickets)))
+	r.logger.DebugContext(ctx, "Tickets created", "count", len(tickets))
 	return nil
 }
 
-func (r *ticketRepositoryImpl) GetTicketByID(ticketID uint) (*models.Ticket, error) {
-	r.logger.Info(fmt.Sprintf("Fetching ticket by ID: %s", ticketID))
+func (r *ticketRepositoryImpl) GetTicketByID(ctx context.Context, ticketID uint) (*models.Ticket, error) {
+	r.logger.DebugContext(ctx, "Fetching ticket", "ticket_id", ticketID)
 	var ticket models.Ticket
-	if err := r.db.First(&ticket, "id = ?", ticketID).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&ticket, "id = ?", ticketID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			r.logger.Warn(fmt.Sprintf("Ticket not found: %s", ticketID))
+			r.logger.DebugContext(ctx, "Ticket not found", "ticket_id", ticketID)
 			return nil, nil
 		}
-		appErr := utils.NewAppError(500, "Failed to retrieve ticket", err.Error())
-		r.logger.Error(appErr.Error())
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve ticket", err.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return nil, appErr
 	}
-	r.logger.Info(fmt.Sprintf("Ticket retrieved successfully: %+v", ticket))
+	r.logger.DebugContext(ctx, "Ticket retrieved", "ticket_id", ticket.ID, "status", ticket.Status)
 	return &ticket, nil
 }
 
-func (r *ticketRepositoryImpl) UpdateTicketStatus(ticketID uint, status models.TicketStatus) error {
-	r.logger.Info(fmt.Sprintf("Updating status of ticket %s to %s", ticketID, status))
-	if err := r.db.Model(&models.Ticket{}).Where("id = ?", ticketID).Update("status", status).Error; err != nil {
-		appErr := utils.NewAppError(500, "Failed to update ticket status", err.Error())
-		r.logger.Error(appErr.Error())
+// nextCredentialVersion revokes a ticket's credential. It is applied whenever
+// a ticket stops being sold, so the credential of that sale no longer admits.
+var nextCredentialVersion = gorm.Expr("credential_version + 1")
+
+// UpdateTicketStatus sets the status of a ticket. Any status but sold
+// revokes its credential.
+func (r *ticketRepositoryImpl) UpdateTicketStatus(ctx context.Context, ticketID uint, status models.TicketStatus) error {
+	r.logger.DebugContext(ctx, "Updating ticket status", "ticket_id", ticketID, "status", status)
+	updates := map[string]interface{}{"status": status}
+	if status != models.TicketStatusSold {
+		updates["credential_version"] = nextCredentialVersion
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Ticket{}).Where("id = ?", ticketID).Updates(updates).Error; err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to update ticket status", err.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return appErr
 	}
-	r.logger.Info(fmt.Sprintf("Ticket status updated successfully: %s", ticketID))
+	r.logger.DebugContext(ctx, "Ticket status updated", "ticket_id", ticketID, "status", status)
 	return nil
 }
 
-func (r *ticketRepositoryImpl) ReserveTicket(ticketID, userID, bookingID uint) error {
-	r.logger.Info(fmt.Sprintf("Reserving ticket %s for user %s and booking %s", ticketID, userID, bookingID))
-	if err := r.db.Model(&models.Ticket{}).Where("id = ? AND status = ?", ticketID, models.TicketStatusAvailable).
+func (r *ticketRepositoryImpl) ReserveTicket(ctx context.Context, ticketID, userID, bookingID uint) error {
+	r.logger.DebugContext(ctx, "Reserving ticket", "ticket_id", ticketID, "booking_id", bookingID)
+	result := r.db.WithContext(ctx).Model(&models.Ticket{}).Where("id = ? AND status = ?", ticketID, models.TicketStatusAvailable).
 		Updates(map[string]interface{}{
-			"status":     models.TicketStatusReserved,
-			"user_id":    userID,
-			"booking_id": bookingID,
-		}).Error; err != nil {
-		appErr := utils.NewAppError(500, "Failed to reserve ticket", err.Error())
-		r.logger.Error(appErr.Error())
+			"status":             models.TicketStatusReserved,
+			"user_id":            userID,
+			"booking_id":         bookingID,
+			"credential_version": nextCredentialVersion,
+		})
+	if result.Error != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to reserve ticket", result.Error.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return appErr
 	}
-	r.logger.Info(fmt.Sprintf("Ticket reserved successfully: %s", ticketID))
+	// Someone else held the ticket between reading and reserving it
+	if result.RowsAffected == 0 {
+		appErr := utils.NewAppError(409, utils.CodeTicketNotAvailable, "Ticket not available", fmt.Sprintf("Ticket %d was reserved by another booking", ticketID))
+		r.logger.WarnContext(ctx, appErr.Message, "ticket_id", ticketID, "booking_id", bookingID)
+		return appErr
+	}
+	r.logger.DebugContext(ctx, "Ticket reserved", "ticket_id", ticketID, "booking_id", bookingID)
 	return nil
 }
 
-func (r *ticketRepositoryImpl) ListAvailableTickets(eventID uint) ([]models.Ticket, error) {
-	r.logger.Info(fmt.Sprintf("Listing available tickets for event %s", eventID))
+func (r *ticketRepositoryImpl) ListAvailableTickets(ctx context.Context, eventID uint) ([]models.Ticket, error) {
+	r.logger.DebugContext(ctx, "Listing available tickets", "event_id", eventID)
 	var tickets []models.Ticket
-	if err := r.db.Where("event_id = ? AND status = ?", eventID, models.TicketStatusAvailable).Find(&tickets).Error; err != nil {
-		appErr := utils.NewAppError(500, "Failed to list available tickets", err.Error())
-		r.logger.Error(appErr.Error())
+	if err := r.db.WithContext(ctx).Where("event_id = ? AND status = ?", eventID, models.TicketStatusAvailable).Find(&tickets).Error; err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to list available tickets", err.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return nil, appErr
 	}
-	r.logger.Info(fmt.Sprintf("Found %d available tickets for event %s", len(tickets), eventID))
+	r.logger.DebugContext(ctx, "Available tickets listed", "event_id", eventID, "count", len(tickets))
 	return tickets, nil
 }
 
-func (r *ticketRepositoryImpl) DeleteTicket(ticketID uint) error {
-	r.logger.Info(fmt.Sprintf("Deleting ticket %s", ticketID))
-	if err := r.db.Delete(&models.Ticket{}, "id = ?", ticketID).Error; err != nil {
-		appErr := utils.NewAppError(500, "Failed to delete ticket", err.Error())
-		r.logger.Error(appErr.Error())
+func (r *ticketRepositoryImpl) DeleteTicket(ctx context.Context, ticketID uint) error {
+	r.logger.DebugContext(ctx, "Deleting ticket", "ticket_id", ticketID)
+	if err := r.db.WithContext(ctx).Delete(&models.Ticket{}, "id = ?", ticketID).Error; err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to delete ticket", err.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return appErr
 	}
-	r.logger.Info(fmt.Sprintf("Ticket deleted successfully: %s", ticketID))
+	r.logger.DebugContext(ctx, "Ticket deleted", "ticket_id", ticketID)
 	return nil
 }
+
+func (r *ticketRepositoryImpl) CountAvailableByEvent(ctx context.Context) ([]EventAvailability, error) {
+	var counts []EventAvailability
+	err := r.db.WithContext(ctx).Model(&models.Ticket{}).
+		Select("tenant_id, event_id, COUNT(*) AS available").
+		Where("status = ?", models.TicketStatusAvailable).
+		Group("tenant_id, event_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// orphaned restricts a ticket query to held tickets whose booking is unset,
+// missing or canceled.
+func orphaned(db *gorm.DB) *gorm.DB {
+	liveBookings := db.Session(&gorm.Session{NewDB: true}).Model(&models.Booking{}).
+		Select("id").
+		Where("status <> ?", models.BookingStatusCanceled)
+	return db.Where("status <> ?", models.TicketStatusAvailable).
+		Where("booking_id IS NULL OR booking_id NOT IN (?)", liveBookings)
+}
+
+// ListOrphanedTickets returns held tickets that no live booking accounts for,
+// in all events when eventID is zero.
+func (r *ticketRepositoryImpl) ListOrphanedTickets(ctx context.Context, eventID uint) ([]models.Ticket, error) {
+	query := r.db.WithContext(ctx).Scopes(orphaned).Order("id")
+	if eventID != 0 {
+		query = query.Where("event_id = ?", eventID)
+	}
+
+	var tickets []models.Ticket
+	if err := query.Find(&tickets).Error; err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to list orphaned tickets", err.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return nil, appErr
+	}
+	return tickets, nil
+}
+
+// ReleaseOrphanedTickets puts the given tickets back on sale, skipping any
+// that were booked since they were listed, and returns how many were released.
+func (r *ticketRepositoryImpl) ReleaseOrphanedTickets(ctx context.Context, ticketIDs []uint) (int64, error) {
+	if len(ticketIDs) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).Model(&models.Ticket{}).Scopes(orphaned).
+		Where("id IN ?", ticketIDs).
+		Updates(map[string]interface{}{
+			"status":             models.TicketStatusAvailable,
+			"user_id":            nil,
+			"booking_id":         nil,
+			"credential_version": nextCredentialVersion,
+		})
+	if result.Error != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to release orphaned tickets", result.Error.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return 0, appErr
+	}
+	r.logger.DebugContext(ctx, "Orphaned tickets released", "count", result.RowsAffected)
+	return result.RowsAffected, nil
+}
+
+// ReissueCredential bumps the credential version of a sold ticket, revoking
+// its current credential, and returns the ticket; nil if it is not sold.
+func (r *ticketRepositoryImpl) ReissueCredential(ctx context.Context, ticketID uint) (*models.Ticket, error) {
+	result := r.db.WithContext(ctx).Model(&models.Ticket{}).
+		Where("id = ? AND status = ?", ticketID, models.TicketStatusSold).
+		Update("credential_version", nextCredentialVersion)
+	if result.Error != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to reissue ticket credential", result.Error.Error())
+		r.logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return nil, appErr
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	r.logger.DebugContext(ctx, "Ticket credential reissued", "ticket_id", ticketID)
+	return r.GetTicketByID(ctx, ticketID)
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TransferRepository interface {
+	CreateTransfer(ctx context.Context, transfer *models.TicketTransfer) error
+	GetTransferByID(ctx context.Context, id uint) (*models.TicketTransfer, error)
+	GetTransferByTokenHash(ctx context.Context, tokenHash string) (*models.TicketTransfer, error)
+	ListTransfersByTicket(ctx context.Context, ticketID uint) ([]models.TicketTransfer, error)
+	SettleTransfer(ctx context.Context, id uint, status models.TicketTransferStatus) (bool, error)
+	CompleteTransfer(ctx context.Context, transfer *models.TicketTransfer, toUserID uint) (*models.Ticket, error)
+}
+
+// errTransferStale rolls back a transfer whose ticket or status changed
+// since it was read.
+var errTransferStale = errors.New("transfer no longer applies")
+
+type transferRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) TransferRepository {
+	return &transferRepositoryImpl{
+		db: db,
+	}
+}
+
+// CreateTransfer stores a pending transfer, canceling any other pending
+// transfer of the ticket so only the latest token can be accepted.
+func (r *transferRepositoryImpl) CreateTransfer(ctx context.Context, transfer *models.TicketTransfer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.TicketTransfer{}).
+			Where("ticket_id = ? AND status = ?", transfer.TicketID, models.TicketTransferPending).
+			Update("status", models.TicketTransferCanceled).Error; err != nil {
+			return err
+		}
+		return tx.Create(transfer).Error
+	})
+}
+
+func (r *transferRepositoryImpl) GetTransferByID(ctx context.Context, id uint) (*models.TicketTransfer, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *transferRepositoryImpl) GetTransferByTokenHash(ctx context.Context, tokenHash string) (*models.TicketTransfer, error) {
+	return r.first(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *transferRepositoryImpl) first(ctx context.Context, query string, args ...interface{}) (*models.TicketTransfer, error) {
+	var transfer models.TicketTransfer
+	if err := r.db.WithContext(ctx).Where(query, args...).First(&transfer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// ListTransfersByTicket returns every transfer of a ticket, oldest first.
+func (r *transferRepositoryImpl) ListTransfersByTicket(ctx context.Context, ticketID uint) ([]models.TicketTransfer, error) {
+	var transfers []models.TicketTransfer
+	if err := r.db.WithContext(ctx).Where("ticket_id = ?", ticketID).Order("id").Find(&transfers).Error; err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// SettleTransfer moves a pending transfer to status and reports whether it
+// was still pending.
+func (r *transferRepositoryImpl) SettleTransfer(ctx context.Context, id uint, status models.TicketTransferStatus) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.TicketTransfer{}).
+		Where("id = ? AND status = ?", id, models.TicketTransferPending).
+		Update("status", status)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CompleteTransfer accepts a pending transfer for toUserID and hands them the
+// ticket, bumping its credential version, in one transaction. It returns the
+// updated ticket, or nil if the transfer is no longer pending or the sender
+// no longer holds the sold ticket.
+func (r *transferRepositoryImpl) CompleteTransfer(ctx context.Context, transfer *models.TicketTransfer, toUserID uint) (*models.Ticket, error) {
+	var ticket models.Ticket
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.TicketTransfer{}).
+			Where("id = ? AND status = ? AND expires_at > ?", transfer.ID, models.TicketTransferPending, now).
+			Updates(map[string]interface{}{
+				"status":      models.TicketTransferAccepted,
+				"to_user_id":  toUserID,
+				"accepted_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTransferStale
+		}
+
+		result = tx.Model(&models.Ticket{}).
+			Where("id = ? AND status = ? AND user_id = ?", transfer.TicketID, models.TicketStatusSold, transfer.FromUserID).
+			Updates(map[string]interface{}{
+				"user_id":            toUserID,
+				"credential_version": nextCredentialVersion,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTransferStale
+		}
+		return tx.First(&ticket, "id = ?", transfer.TicketID).Error
+	})
+	if errors.Is(err, errTransferStale) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
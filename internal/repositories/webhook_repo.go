@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uint) error
+	EnqueueDeliveries(ctx context.Context, eventType, payload string) (int, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, subscriptionID uint, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error)
+}
+
+type webhookRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *webhookRepositoryImpl) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *webhookRepositoryImpl) GetSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := r.db.WithContext(ctx).First(&subscription, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *webhookRepositoryImpl) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := r.db.WithContext(ctx).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes a subscription together with its delivery log.
+func (r *webhookRepositoryImpl) DeleteSubscription(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.WebhookSubscription{}, "id = ?", id).Error
+	})
+}
+
+// EnqueueDeliveries creates a pending delivery of payload for every active
+// subscription of the context's tenant whose filter matches eventType, and
+// returns how many were created.
+func (r *webhookRepositoryImpl) EnqueueDeliveries(ctx context.Context, eventType, payload string) (int, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Order("id").Find(&subscriptions).Error; err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var deliveries []models.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if subscription.Events.Matches(eventType) {
+			deliveries = append(deliveries, models.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				EventType:      eventType,
+				Payload:        payload,
+				Status:         models.WebhookDeliveryPending,
+				NextAttemptAt:  now,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	if err := r.db.WithContext(ctx).Create(&deliveries).Error; err != nil {
+		return 0, err
+	}
+	return len(deliveries), nil
+}
+
+// ClaimDue returns up to limit pending deliveries that are due, oldest due
+// first, with their subscriptions. Claimed deliveries are not due again until
+// lease has passed, so a delivery abandoned by a crashed worker is retried
+// and concurrent dispatchers do not send the same one. On Postgres the rows
+// are claimed with SKIP LOCKED so several replicas can dispatch concurrently.
+func (r *webhookRepositoryImpl) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		query := tx.Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at, id").Limit(limit)
+		if tx.Dialector.Name() == "postgres" {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		if err := query.Find(&deliveries).Error; err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		if err := tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Subscription").Where("id IN ?", ids).Order("next_attempt_at, id").Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RecordAttempt saves the outcome of an attempt to send a delivery.
+func (r *webhookRepositoryImpl) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"response_status": delivery.ResponseStatus,
+			"last_error":      delivery.LastError,
+			"delivered_at":    delivery.DeliveredAt,
+		}).Error
+}
+
+// ListDeliveries returns a subscription's deliveries, newest first, filtered
+// by status when given.
+func (r *webhookRepositoryImpl) ListDeliveries(ctx context.Context, subscriptionID uint, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error) {
+	query := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).Order("id DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Redeliver resets a delivery to pending with a fresh attempt budget, due
+// now, and returns it; nil if the subscription has no such delivery.
+func (r *webhookRepositoryImpl) Redeliver(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error) {
+	result := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("id = ? AND subscription_id = ?", deliveryID, subscriptionID).
+		Updates(map[string]interface{}{
+			"status":          models.WebhookDeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+			"last_error":      "",
+			"delivered_at":    nil,
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+
+	var delivery models.WebhookDelivery
+	if err := r.db.WithContext(ctx).First(&delivery, "id = ?", deliveryID).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
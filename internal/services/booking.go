@@ -1,210 +1,500 @@
 package services
 
 import (
+	"booking-service/internal/metrics"
 	"booking-service/internal/models"
 	"booking-service/internal/repositories"
+	"booking-service/internal/settings"
+	"booking-service/internal/tenancy"
 	"booking-service/pkg/kafka"
+	kafkaModels "booking-service/pkg/kafka/models"
+	"booking-service/pkg/money"
+	"booking-service/pkg/pagination"
 	"booking-service/utils"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 )
 
 type BookingService interface {
-	CreateBooking(userID, eventID uint, ticketIDs []uint) (*models.Booking, error)
-	ConfirmBooking(bookingID uint) error
-	CancelBooking(bookingID uint) error
-	UpdateBookingStatus(bookingID uint, status models.BookingStatus) error
-	ListBookingsByUserID(userID uint, page, pageSize int) ([]models.Booking, error)
-	GetBookingByID(bookingID uint) (*models.Booking, error)
+	CreateBooking(ctx context.Context, userID, eventID uint, ticketIDs []uint) (*models.Booking, error)
+	ConfirmBooking(ctx context.Context, bookingID uint) error
+	CancelBooking(ctx context.Context, bookingID uint) error
+	UpdateBookingStatus(ctx context.Context, bookingID uint, status models.BookingStatus) error
+	ListBookingsByUserID(ctx context.Context, userID uint, query repositories.BookingQuery) (*pagination.Page[models.Booking], error)
+	SearchBookings(ctx context.Context, criteria repositories.BookingCriteria) (*pagination.Page[models.Booking], error)
+	GetBookingByID(ctx context.Context, bookingID uint) (*models.Booking, error)
+	ExpirePendingBookings(ctx context.Context, holdTTL time.Duration) (int, error)
 }
 
 type bookingServiceImpl struct {
-	BookingRepo   repositories.BookingRepository
-	TicketService TicketService
-	Logger        *utils.Logger
-	KafkaProducer kafka.Producer
+	BookingRepo    repositories.BookingRepository
+	TicketService  TicketService
+	PricingService PricingService
+	Logger         *slog.Logger
+	KafkaProducer  kafka.Producer
+	Settings       *settings.Store
 }
 
 func NewBookingService(
 	bookingRepo repositories.BookingRepository,
 	ticketService TicketService,
+	pricingService PricingService,
 	kafkaProducer kafka.Producer,
+	settingsStore *settings.Store,
 ) BookingService {
 	return &bookingServiceImpl{
-		BookingRepo:   bookingRepo,
-		TicketService: ticketService,
-		Logger:        utils.NewLogger(),
-		KafkaProducer: kafkaProducer,
+		BookingRepo:    bookingRepo,
+		TicketService:  ticketService,
+		PricingService: pricingService,
+		Logger:         slog.Default(),
+		KafkaProducer:  kafkaProducer,
+		Settings:       settingsStore,
 	}
 }
 
-func (s *bookingServiceImpl) CreateBooking(userID, eventID uint, ticketIDs []uint) (*models.Booking, error) {
-	s.Logger.Info(fmt.Sprintf("Creating booking for user %d and event %d", userID, eventID))
+func (s *bookingServiceImpl) CreateBooking(ctx context.Context, userID, eventID uint, ticketIDs []uint) (*models.Booking, error) {
+	s.Logger.InfoContext(ctx, "Creating booking", "event_id", eventID, "ticket_count", len(ticketIDs))
+
+	current := s.Settings.Current()
+	if !current.Enabled(settings.FeatureBookings) {
+		return nil, utils.NewAppError(503, utils.CodeBookingsDisabled, "Bookings are temporarily disabled", "")
+	}
+	if len(ticketIDs) == 0 {
+		return nil, utils.NewAppError(400, utils.CodeNoTickets, "No tickets", "A booking needs at least one ticket")
+	}
+	if limit := current.MaxTicketsPerBooking; limit > 0 && len(ticketIDs) > limit {
+		return nil, utils.NewAppError(400, utils.CodeTooManyTickets, "Too many tickets", fmt.Sprintf("A booking can have at most %d tickets", limit))
+	}
 
 	tickets := make([]models.Ticket, 0, len(ticketIDs))
 	for _, ticketID := range ticketIDs {
-		ticket, err := s.TicketService.GetTicketByID(ticketID)
+		ticket, err := s.TicketService.GetTicketByID(ctx, ticketID)
 		if err != nil {
-			appErr := utils.NewAppError(500, "Failed to retrieve ticket", err.Error())
-			s.Logger.Error(appErr.Error())
+			appErr := utils.WrapError(err, 500, utils.CodeInternal, "Failed to retrieve ticket")
+			s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 			return nil, appErr
 		}
 		if ticket == nil || ticket.Status != models.TicketStatusAvailable {
-			err := utils.NewAppError(400, "Ticket not available", fmt.Sprintf("Ticket %d is not available", ticketID))
-			s.Logger.Warn(err.Error())
+			metrics.RecordReservationConflict(ctx)
+			err := utils.NewAppError(400, utils.CodeTicketNotAvailable, "Ticket not available", fmt.Sprintf("Ticket %d is not available", ticketID))
+			s.Logger.WarnContext(ctx, err.Message, "error", err)
+			return nil, err
+		}
+		if ticket.EventID != eventID {
+			err := utils.NewAppError(400, utils.CodeTicketNotForEvent, "Ticket not for event", fmt.Sprintf("Ticket %d is not for event %d", ticketID, eventID))
+			s.Logger.WarnContext(ctx, err.Message, "error", err)
 			return nil, err
 		}
 		tickets = append(tickets, *ticket)
 	}
 
-	var totalAmount float64
-	for _, ticket := range tickets {
-		totalAmount += ticket.Price
+	// Lock the price each ticket is worth right now so the buyer pays what they saw
+	priceRecords := make([]models.PriceRecord, 0, len(tickets))
+	prices := make([]money.Money, 0, len(tickets))
+	for i := range tickets {
+		record, err := s.PricingService.QuoteTicket(ctx, &tickets[i])
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to price ticket", "ticket_id", tickets[i].ID, "error", err)
+			return nil, err
+		}
+		priceRecords = append(priceRecords, *record)
+		prices = append(prices, record.Price)
+	}
+	subtotal, err := money.Sum("", prices...)
+	if err != nil {
+		appErr := utils.NewAppError(400, utils.CodeMixedCurrencies, "Mixed currencies", err.Error())
+		s.Logger.WarnContext(ctx, appErr.Message, "error", appErr)
+		return nil, appErr
+	}
+
+	tenant, _ := tenancy.FromContext(ctx)
+	fee, err := subtotal.MultiplyBps(tenant.FeeBps)
+	if err != nil {
+		return nil, utils.NewAppError(400, utils.CodeInvalidBookingAmount, "Invalid booking amount", err.Error())
+	}
+	totalAmount, err := subtotal.Add(fee)
+	if err != nil {
+		return nil, utils.NewAppError(400, utils.CodeInvalidBookingAmount, "Invalid booking amount", err.Error())
 	}
 
 	booking := &models.Booking{
-		UserID:      userID,
-		EventID:     eventID,
-		TotalAmount: totalAmount,
-		Status:      models.BookingStatusPending,
-		Tickets:     tickets,
+		UserID:       userID,
+		EventID:      eventID,
+		FeeAmount:    fee.Amount,
+		TotalAmount:  totalAmount,
+		Status:       models.BookingStatusPending,
+		Tickets:      tickets,
+		PriceRecords: priceRecords,
 	}
 
-	if err := s.BookingRepo.CreateBooking(booking); err != nil {
-		appErr := utils.NewAppError(500, "Failed to create booking", err.Error())
-		s.Logger.Error(appErr.Error())
+	if err := s.BookingRepo.CreateBooking(ctx, booking); err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to create booking", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return nil, appErr
 	}
 
-	for _, ticket := range tickets {
-		if err := s.TicketService.ReserveTicket(ticket.ID, userID, booking.ID); err != nil {
-			appErr := utils.NewAppError(500, "Failed to reserve ticket", err.Error())
-			s.Logger.Error(appErr.Error())
-			return nil, appErr
+	metrics.RecordTransition(ctx, models.BookingStatusPending)
+
+	for i, ticket := range tickets {
+		if err := s.TicketService.ReserveTicket(ctx, ticket.ID, userID, booking.ID); err != nil {
+			s.Logger.WarnContext(ctx, "Failed to reserve ticket", "ticket_id", ticket.ID, "error", err)
+			s.abandonBooking(ctx, booking.ID, tickets[:i])
+			return nil, err
 		}
 	}
 
-	if err := s.publishEvent("booking.created", booking); err != nil {
-		s.Logger.Error(fmt.Sprintf("Failed to publish booking.created event: %v", err))
+	if err := s.publishEvent(ctx, models.EventBookingCreated, booking); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to publish booking event", "event_type", models.EventBookingCreated, "booking_id", booking.ID, "error", err)
 	}
 
-	s.Logger.Info(fmt.Sprintf("Booking created successfully: %+v", booking))
+	s.Logger.InfoContext(ctx, "Booking created", "booking_id", booking.ID, "total", booking.TotalAmount.String())
 	return booking, nil
 }
 
-func (s *bookingServiceImpl) ConfirmBooking(bookingID uint) error {
-	s.Logger.Info(fmt.Sprintf("Confirming booking %d", bookingID))
+// abandonBooking cancels a booking that could not hold all its tickets and
+// releases the ones it did hold. Nothing was published for it yet.
+func (s *bookingServiceImpl) abandonBooking(ctx context.Context, bookingID uint, reserved []models.Ticket) {
+	for _, ticket := range reserved {
+		if err := s.TicketService.UpdateTicketStatus(ctx, ticket.ID, models.TicketStatusAvailable); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to release ticket", "ticket_id", ticket.ID, "error", err)
+		}
+	}
+	if _, err := s.BookingRepo.TransitionBookingStatus(ctx, bookingID, []models.BookingStatus{models.BookingStatusPending}, models.BookingStatusCanceled); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to cancel abandoned booking", "booking_id", bookingID, "error", err)
+		return
+	}
+	metrics.RecordTransition(ctx, models.BookingStatusCanceled)
+}
+
+func (s *bookingServiceImpl) ConfirmBooking(ctx context.Context, bookingID uint) error {
+	s.Logger.InfoContext(ctx, "Confirming booking", "booking_id", bookingID)
 
-	if err := s.BookingRepo.UpdateBookingStatus(bookingID, models.BookingStatusConfirmed); err != nil {
-		appErr := utils.NewAppError(500, "Failed to confirm booking", err.Error())
-		s.Logger.Error(appErr.Error())
-		return appErr
+	if err := s.transition(ctx, bookingID, models.BookingStatusConfirmed.Sources(), models.BookingStatusConfirmed); err != nil {
+		return err
 	}
 
-	booking, err := s.BookingRepo.GetBookingByID(bookingID)
+	booking, err := s.BookingRepo.GetBookingByID(ctx, bookingID)
 	if err != nil {
-		appErr := utils.NewAppError(500, "Failed to retrieve booking", err.Error())
-		s.Logger.Error(appErr.Error())
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve booking", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return appErr
 	}
 	if booking == nil {
-		err := utils.NewAppError(404, "Booking not found", fmt.Sprintf("Booking ID %d not found", bookingID))
-		s.Logger.Warn(err.Error())
+		err := utils.NewAppError(404, utils.CodeBookingNotFound, "Booking not found", fmt.Sprintf("Booking ID %d not found", bookingID))
+		s.Logger.WarnContext(ctx, err.Message, "error", err)
 		return err
 	}
 
 	for _, ticket := range booking.Tickets {
-		if err := s.TicketService.UpdateTicketStatus(ticket.ID, models.TicketStatusSold); err != nil {
-			appErr := utils.NewAppError(500, fmt.Sprintf("Failed to mark ticket %d as sold", ticket.ID), err.Error())
-			s.Logger.Error(appErr.Error())
+		if err := s.TicketService.UpdateTicketStatus(ctx, ticket.ID, models.TicketStatusSold); err != nil {
+			appErr := utils.NewAppError(500, utils.CodeInternal, fmt.Sprintf("Failed to mark ticket %d as sold", ticket.ID), err.Error())
+			s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		}
 	}
 
-	if err := s.publishEvent("booking.confirmed", booking); err != nil {
-		s.Logger.Error(fmt.Sprintf("Failed to publish booking.confirmed event: %v", err))
+	if err := s.publishEvent(ctx, models.EventBookingConfirmed, booking); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to publish booking event", "event_type", models.EventBookingConfirmed, "booking_id", bookingID, "error", err)
 	}
 
-	s.Logger.Info(fmt.Sprintf("Booking %d confirmed successfully", bookingID))
+	s.Logger.InfoContext(ctx, "Booking confirmed", "booking_id", bookingID)
 	return nil
 }
 
-func (s *bookingServiceImpl) CancelBooking(bookingID uint) error {
-	s.Logger.Info(fmt.Sprintf("Cancelling booking %d", bookingID))
+func (s *bookingServiceImpl) CancelBooking(ctx context.Context, bookingID uint) error {
+	return s.cancelBooking(ctx, bookingID, models.BookingStatusCanceled.Sources())
+}
 
-	if err := s.BookingRepo.UpdateBookingStatus(bookingID, models.BookingStatusCanceled); err != nil {
-		appErr := utils.NewAppError(500, "Failed to cancel booking", err.Error())
-		s.Logger.Error(appErr.Error())
-		return appErr
-	}
+// cancelBooking cancels a booking in one of the from statuses and releases
+// its tickets. Bookings with tickets transferred to other users cannot be
+// canceled, as releasing those tickets would take them from their new owners.
+func (s *bookingServiceImpl) cancelBooking(ctx context.Context, bookingID uint, from []models.BookingStatus) error {
+	s.Logger.InfoContext(ctx, "Cancelling booking", "booking_id", bookingID)
 
-	booking, err := s.BookingRepo.GetBookingByID(bookingID)
+	booking, err := s.BookingRepo.GetBookingByID(ctx, bookingID)
 	if err != nil {
-		appErr := utils.NewAppError(500, "Failed to retrieve booking", err.Error())
-		s.Logger.Error(appErr.Error())
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve booking", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return appErr
 	}
 	if booking == nil {
-		err := utils.NewAppError(404, "Booking not found", fmt.Sprintf("Booking ID %d not found", bookingID))
-		s.Logger.Warn(err.Error())
+		err := utils.NewAppError(404, utils.CodeBookingNotFound, "Booking not found", fmt.Sprintf("Booking ID %d not found", bookingID))
+		s.Logger.WarnContext(ctx, err.Message, "error", err)
+		return err
+	}
+	for _, ticket := range booking.Tickets {
+		if transferred(booking, ticket) {
+			err := utils.NewAppError(409, utils.CodeBookingHasTransferredTickets, "Booking has transferred tickets",
+				fmt.Sprintf("Ticket %d of booking %d belongs to another user", ticket.ID, bookingID))
+			s.Logger.WarnContext(ctx, err.Message, "error", err)
+			return err
+		}
+	}
+
+	if err := s.transition(ctx, bookingID, from, models.BookingStatusCanceled); err != nil {
 		return err
 	}
+	booking.Status = models.BookingStatusCanceled
 
 	for _, ticket := range booking.Tickets {
-		if err := s.TicketService.UpdateTicketStatus(ticket.ID, models.TicketStatusAvailable); err != nil {
-			appErr := utils.NewAppError(500, fmt.Sprintf("Failed to release ticket %d", ticket.ID), err.Error())
-			s.Logger.Error(appErr.Error())
+		if err := s.TicketService.UpdateTicketStatus(ctx, ticket.ID, models.TicketStatusAvailable); err != nil {
+			appErr := utils.NewAppError(500, utils.CodeInternal, fmt.Sprintf("Failed to release ticket %d", ticket.ID), err.Error())
+			s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		}
 	}
 
-	if err := s.publishEvent("booking.canceled", booking); err != nil {
-		s.Logger.Error(fmt.Sprintf("Failed to publish booking.canceled event: %v", err))
+	if err := s.publishEvent(ctx, models.EventBookingCanceled, booking); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to publish booking event", "event_type", models.EventBookingCanceled, "booking_id", bookingID, "error", err)
 	}
 
-	s.Logger.Info(fmt.Sprintf("Booking %d cancelled successfully", bookingID))
+	s.Logger.InfoContext(ctx, "Booking cancelled", "booking_id", bookingID)
 	return nil
 }
 
-func (s *bookingServiceImpl) UpdateBookingStatus(bookingID uint, status models.BookingStatus) error {
-	s.Logger.Info(fmt.Sprintf("Updating booking status for %d to %s", bookingID, status))
-	if err := s.BookingRepo.UpdateBookingStatus(bookingID, status); err != nil {
-		appErr := utils.NewAppError(500, "Failed to update booking status", err.Error())
-		s.Logger.Error(appErr.Error())
-		return appErr
+// transferred reports whether ticket was handed from the booking's user to
+// another one.
+func transferred(booking *models.Booking, ticket models.Ticket) bool {
+	return ticket.UserID != nil && *ticket.UserID != booking.UserID
+}
+
+func (s *bookingServiceImpl) UpdateBookingStatus(ctx context.Context, bookingID uint, status models.BookingStatus) error {
+	s.Logger.InfoContext(ctx, "Updating booking status", "booking_id", bookingID, "status", status)
+	if err := validateStatus(status); err != nil {
+		s.Logger.WarnContext(ctx, err.Message, "error", err)
+		return err
+	}
+	if err := s.transition(ctx, bookingID, status.Sources(), status); err != nil {
+		return err
 	}
 
-	s.Logger.Info(fmt.Sprintf("Booking status updated successfully: %d", bookingID))
+	s.Logger.InfoContext(ctx, "Booking status updated", "booking_id", bookingID, "status", status)
 	return nil
 }
 
-func (s *bookingServiceImpl) ListBookingsByUserID(userID uint, page, pageSize int) ([]models.Booking, error) {
-	s.Logger.Info(fmt.Sprintf("Listing bookings for user %d", userID))
-	bookings, err := s.BookingRepo.ListBookingsByUserID(userID, page, pageSize)
+// transition moves a booking to status to if it is in one of the from
+// statuses. A booking that has moved on in the meantime is a conflict.
+func (s *bookingServiceImpl) transition(ctx context.Context, bookingID uint, from []models.BookingStatus, to models.BookingStatus) error {
+	moved := false
+	if len(from) > 0 {
+		var err error
+		if moved, err = s.BookingRepo.TransitionBookingStatus(ctx, bookingID, from, to); err != nil {
+			appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to update booking status", err.Error())
+			s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+			return appErr
+		}
+	}
+	if !moved {
+		booking, err := s.GetBookingByID(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+		appErr := utils.NewAppError(409, utils.CodeBookingStatusConflict, "Booking status conflict", fmt.Sprintf("Booking %d is %s and cannot become %s", bookingID, booking.Status, to))
+		s.Logger.WarnContext(ctx, appErr.Message, "error", appErr)
+		return appErr
+	}
+	metrics.RecordTransition(ctx, to)
+	return nil
+}
+
+// ListBookingsByUserID returns a page of a user's bookings. A zero limit
+// means pagination.DefaultLimit.
+func (s *bookingServiceImpl) ListBookingsByUserID(ctx context.Context, userID uint, query repositories.BookingQuery) (*pagination.Page[models.Booking], error) {
+	if err := validateBookingQuery(&query); err != nil {
+		s.Logger.WarnContext(ctx, err.Message, "error", err)
+		return nil, err
+	}
+	s.Logger.DebugContext(ctx, "Listing bookings", "owner_id", userID, "limit", query.Limit)
+
+	total, err := s.BookingRepo.CountBookingsByUserID(ctx, userID, query)
+	if err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to list bookings", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return nil, appErr
+	}
+
+	// Fetch one extra booking to learn whether there is a next page
+	limit := query.Limit
+	query.Limit++
+	bookings, err := s.BookingRepo.ListBookingsByUserID(ctx, userID, query)
 	if err != nil {
-		appErr := utils.NewAppError(500, "Failed to list bookings", err.Error())
-		s.Logger.Error(appErr.Error())
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to list bookings", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return nil, appErr
 	}
-	s.Logger.Info(fmt.Sprintf("Retrieved %d bookings for user %d", len(bookings), userID))
-	return bookings, nil
+
+	page := bookingPage(bookings, limit, total)
+	s.Logger.DebugContext(ctx, "Bookings listed", "owner_id", userID, "count", len(page.Data))
+	return page, nil
 }
 
-func (s *bookingServiceImpl) GetBookingByID(bookingID uint) (*models.Booking, error) {
-	s.Logger.Info(fmt.Sprintf("Fetching booking by ID %d", bookingID))
-	booking, err := s.BookingRepo.GetBookingByID(bookingID)
+// SearchBookings returns a page of the tenant's bookings matching criteria.
+// Amount bounds without a currency are in the tenant's currency.
+func (s *bookingServiceImpl) SearchBookings(ctx context.Context, criteria repositories.BookingCriteria) (*pagination.Page[models.Booking], error) {
+	if err := validateBookingCriteria(ctx, &criteria); err != nil {
+		s.Logger.WarnContext(ctx, err.Message, "error", err)
+		return nil, err
+	}
+
+	total, err := s.BookingRepo.CountSearch(ctx, criteria)
+	if err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to search bookings", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return nil, appErr
+	}
+
+	limit := criteria.Limit
+	criteria.Limit++
+	bookings, err := s.BookingRepo.Search(ctx, criteria)
 	if err != nil {
-		appErr := utils.NewAppError(500, "Failed to retrieve booking", err.Error())
-		s.Logger.Error(appErr.Error())
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to search bookings", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return nil, appErr
+	}
+
+	page := bookingPage(bookings, limit, total)
+	s.Logger.DebugContext(ctx, "Bookings searched", "count", len(page.Data), "total", total)
+	return page, nil
+}
+
+// bookingPage trims bookings, fetched with one extra to detect a next page,
+// to limit and sets the cursor of the next page.
+func bookingPage(bookings []models.Booking, limit int, total int64) *pagination.Page[models.Booking] {
+	page := &pagination.Page[models.Booking]{Data: bookings, Total: total}
+	if len(bookings) > limit {
+		page.Data = bookings[:limit]
+		last := page.Data[limit-1]
+		page.NextCursor = pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	if page.Data == nil {
+		page.Data = []models.Booking{}
+	}
+	return page
+}
+
+// validateBookingQuery checks the filters and limit of a listing and applies
+// the default limit.
+func validateBookingQuery(query *repositories.BookingQuery) *utils.AppError {
+	if query.Status != "" {
+		if err := validateStatus(query.Status); err != nil {
+			return err
+		}
+	}
+	if err := validateDateRange(query.CreatedFrom, query.CreatedTo); err != nil {
+		return err
+	}
+	return validateLimit(&query.Limit)
+}
+
+// validateBookingCriteria checks a search and applies the default limit and
+// the tenant's currency.
+func validateBookingCriteria(ctx context.Context, criteria *repositories.BookingCriteria) *utils.AppError {
+	for _, status := range criteria.Statuses {
+		if err := validateStatus(status); err != nil {
+			return err
+		}
+	}
+	if err := validateDateRange(criteria.CreatedFrom, criteria.CreatedTo); err != nil {
+		return err
+	}
+	if criteria.MinTotal != nil && criteria.MaxTotal != nil && *criteria.MinTotal > *criteria.MaxTotal {
+		return utils.NewAppError(400, utils.CodeInvalidAmountRange, "Invalid amount range", "min_total cannot exceed max_total")
+	}
+	if (criteria.MinTotal != nil || criteria.MaxTotal != nil) && criteria.TotalCurrency == "" {
+		tenant, _ := tenancy.FromContext(ctx)
+		criteria.TotalCurrency = tenant.Currency
+	}
+	return validateLimit(&criteria.Limit)
+}
+
+func validateStatus(status models.BookingStatus) *utils.AppError {
+	switch status {
+	case models.BookingStatusPending, models.BookingStatusConfirmed, models.BookingStatusCanceled:
+		return nil
+	default:
+		return utils.NewAppError(400, utils.CodeInvalidBookingStatus, "Invalid booking status", fmt.Sprintf("Unknown status %q", status))
+	}
+}
+
+func validateDateRange(from, to time.Time) *utils.AppError {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return utils.NewAppError(400, utils.CodeInvalidDateRange, "Invalid date range", "from must be before to")
+	}
+	return nil
+}
+
+func validateLimit(limit *int) *utils.AppError {
+	if *limit == 0 {
+		*limit = pagination.DefaultLimit
+	}
+	if *limit < 0 || *limit > pagination.MaxLimit {
+		return utils.NewAppError(400, utils.CodeInvalidLimit, "Invalid limit", fmt.Sprintf("limit must be between 1 and %d", pagination.MaxLimit))
+	}
+	return nil
+}
+
+func (s *bookingServiceImpl) GetBookingByID(ctx context.Context, bookingID uint) (*models.Booking, error) {
+	s.Logger.DebugContext(ctx, "Fetching booking", "booking_id", bookingID)
+	booking, err := s.BookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve booking", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
 		return nil, appErr
 	}
 	if booking == nil {
-		err := utils.NewAppError(404, "Booking not found", fmt.Sprintf("Booking ID %d not found", bookingID))
-		s.Logger.Warn(err.Error())
+		err := utils.NewAppError(404, utils.CodeBookingNotFound, "Booking not found", fmt.Sprintf("Booking ID %d not found", bookingID))
+		s.Logger.WarnContext(ctx, err.Message, "error", err)
 		return nil, err
 	}
-	s.Logger.Info(fmt.Sprintf("Booking retrieved successfully: %+v", booking))
+	s.Logger.DebugContext(ctx, "Booking retrieved", "booking_id", bookingID, "status", booking.Status)
 	return booking, nil
 }
 
-func (s *bookingServiceImpl) publishEvent(eventType string, payload interface{}) error {
-	topic := fmt.Sprintf("booking.%s", eventType)
-	return s.KafkaProducer.Publish(topic, payload)
+// ExpirePendingBookings cancels bookings that stayed pending longer than
+// holdTTL, releasing their tickets, and returns how many were canceled.
+func (s *bookingServiceImpl) ExpirePendingBookings(ctx context.Context, holdTTL time.Duration) (int, error) {
+	bookings, err := s.BookingRepo.GetPendingBookingsOlderThan(ctx, holdTTL)
+	if err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to list pending bookings", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return 0, appErr
+	}
+
+	expired := 0
+	for _, booking := range bookings {
+		// Only holds expire; a booking confirmed since it was listed stays confirmed
+		err := s.cancelBooking(ctx, booking.ID, []models.BookingStatus{models.BookingStatusPending})
+		var appErr *utils.AppError
+		if errors.As(err, &appErr) && appErr.Code == 409 {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	if expired > 0 {
+		s.Logger.InfoContext(ctx, "Expired pending bookings", "count", expired)
+	}
+	return expired, nil
+}
+
+// publishEvent publishes the booking as the BookingEvent consumers decode to
+// the tenant's topic for eventType.
+func (s *bookingServiceImpl) publishEvent(ctx context.Context, eventType string, booking *models.Booking) error {
+	tenant, _ := tenancy.FromContext(ctx)
+	ticketIDs := make([]uint, 0, len(booking.Tickets))
+	for _, ticket := range booking.Tickets {
+		ticketIDs = append(ticketIDs, ticket.ID)
+	}
+	event := kafkaModels.BookingEvent{
+		TenantID:    tenant.ID,
+		BookingID:   booking.ID,
+		UserID:      booking.UserID,
+		EventID:     booking.EventID,
+		TicketIDs:   ticketIDs,
+		TotalAmount: booking.TotalAmount,
+	}
+	return s.KafkaProducer.Publish(ctx, tenant.Topic(eventType), event)
 }
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"booking-service/internal/credentials"
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/utils"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type CredentialService interface {
+	IssueCredential(ctx context.Context, ticketID uint) (string, error)
+	ReissueCredential(ctx context.Context, ticketID uint) (string, error)
+	VerifyCredential(ctx context.Context, credential string) (*models.Ticket, error)
+}
+
+type credentialServiceImpl struct {
+	TicketRepo repositories.TicketRepository
+	Issuer     *credentials.Issuer
+	Logger     *slog.Logger
+}
+
+func NewCredentialService(ticketRepo repositories.TicketRepository, issuer *credentials.Issuer) CredentialService {
+	return &credentialServiceImpl{
+		TicketRepo: ticketRepo,
+		Issuer:     issuer,
+		Logger:     slog.Default(),
+	}
+}
+
+// IssueCredential returns the signed credential of a sold ticket's current
+// version.
+func (s *credentialServiceImpl) IssueCredential(ctx context.Context, ticketID uint) (string, error) {
+	ticket, err := s.TicketRepo.GetTicketByID(ctx, ticketID)
+	if err != nil {
+		return "", utils.WrapError(err, 500, utils.CodeInternal, "Failed to retrieve ticket")
+	}
+	if ticket == nil {
+		return "", utils.NewAppError(404, utils.CodeTicketNotFound, "Ticket not found", fmt.Sprintf("Ticket %d not found", ticketID))
+	}
+	if ticket.Status != models.TicketStatusSold {
+		return "", utils.NewAppError(409, utils.CodeTicketHasNoCredential, "Ticket has no credential", fmt.Sprintf("Ticket %d is not sold", ticketID))
+	}
+	return s.sign(ctx, ticket)
+}
+
+// ReissueCredential revokes a sold ticket's credential, e.g. after it leaked,
+// and returns the one that replaces it.
+func (s *credentialServiceImpl) ReissueCredential(ctx context.Context, ticketID uint) (string, error) {
+	ticket, err := s.TicketRepo.ReissueCredential(ctx, ticketID)
+	if err != nil {
+		return "", utils.WrapError(err, 500, utils.CodeInternal, "Failed to reissue ticket credential")
+	}
+	if ticket == nil {
+		return "", utils.NewAppError(409, utils.CodeTicketHasNoCredential, "Ticket has no credential", fmt.Sprintf("Ticket %d is not sold", ticketID))
+	}
+
+	s.Logger.InfoContext(ctx, "Ticket credential reissued", "ticket_id", ticketID, "credential_version", ticket.CredentialVersion)
+	return s.sign(ctx, ticket)
+}
+
+// VerifyCredential checks a credential presented at the door and returns the
+// ticket it admits. Forged credentials are a 400; revoked ones, and those of
+// tickets no longer sold or outside the tenant, are a 409.
+func (s *credentialServiceImpl) VerifyCredential(ctx context.Context, credential string) (*models.Ticket, error) {
+	claims, err := s.Issuer.Verify(credential)
+	if err != nil {
+		return nil, utils.NewAppError(400, utils.CodeInvalidCredential, "Invalid credential", err.Error())
+	}
+	ticket, err := s.TicketRepo.GetTicketByID(ctx, claims.TicketID)
+	if err != nil {
+		return nil, utils.WrapError(err, 500, utils.CodeInternal, "Failed to retrieve ticket")
+	}
+	if ticket == nil || !claims.Admits(ticket) {
+		return nil, utils.NewAppError(409, utils.CodeCredentialNotAdmitted, "Credential not admitted",
+			fmt.Sprintf("Credential version %d of ticket %d is no longer current", claims.Version, claims.TicketID))
+	}
+	return ticket, nil
+}
+
+func (s *credentialServiceImpl) sign(ctx context.Context, ticket *models.Ticket) (string, error) {
+	credential, err := s.Issuer.Issue(ticket)
+	if err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to sign ticket credential", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return "", appErr
+	}
+	return credential, nil
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/utils"
+	"context"
+	"fmt"
+)
+
+type EventService interface {
+	GetEventByID(ctx context.Context, eventID uint) (*models.Event, error)
+}
+
+type eventServiceImpl struct {
+	EventRepo repositories.EventRepository
+}
+
+func NewEventService(eventRepo repositories.EventRepository) EventService {
+	return &eventServiceImpl{
+		EventRepo: eventRepo,
+	}
+}
+
+func (s *eventServiceImpl) GetEventByID(ctx context.Context, eventID uint) (*models.Event, error) {
+	event, err := s.EventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve event", err.Error())
+	}
+	if event == nil {
+		return nil, utils.NewAppError(404, utils.CodeEventNotFound, "Event not found", fmt.Sprintf("Event %d not found", eventID))
+	}
+	return event, nil
+}
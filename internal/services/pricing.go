@@ -0,0 +1,148 @@
+package services
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/pricing"
+	"booking-service/internal/repositories"
+	"booking-service/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type PricingService interface {
+	CreateTier(ctx context.Context, tier *models.PricingTier) error
+	GetTierByID(ctx context.Context, tierID uint) (*models.PricingTier, error)
+	CreateTicketsForTier(ctx context.Context, tierID uint, numTickets int) ([]models.Ticket, error)
+	QuoteTicket(ctx context.Context, ticket *models.Ticket) (*models.PriceRecord, error)
+}
+
+type pricingServiceImpl struct {
+	PricingRepo repositories.PricingRepository
+	TicketRepo  repositories.TicketRepository
+	Logger      *slog.Logger
+}
+
+func NewPricingService(pricingRepo repositories.PricingRepository, ticketRepo repositories.TicketRepository) PricingService {
+	return &pricingServiceImpl{
+		PricingRepo: pricingRepo,
+		TicketRepo:  ticketRepo,
+		Logger:      slog.Default(),
+	}
+}
+
+func (s *pricingServiceImpl) CreateTier(ctx context.Context, tier *models.PricingTier) error {
+	basePrice, err := applyTenantCurrency(ctx, tier.BasePrice)
+	if err != nil {
+		return err
+	}
+	tier.BasePrice = basePrice
+	if !tier.BasePrice.IsPositive() {
+		return utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", "Base price must be positive")
+	}
+	if err := tier.BasePrice.Validate(); err != nil {
+		return utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", err.Error())
+	}
+	if tier.Strategy == "" {
+		tier.Strategy = pricing.StrategyFixed
+	}
+	if _, err := pricing.Build(tier.Strategy, []byte(tier.StrategyConfig)); err != nil {
+		return utils.NewAppError(400, utils.CodeInvalidPricingStrategy, "Invalid pricing strategy", err.Error())
+	}
+
+	if err := s.PricingRepo.CreateTier(ctx, tier); err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to create pricing tier", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return appErr
+	}
+
+	s.Logger.InfoContext(ctx, "Pricing tier created", "tier_id", tier.ID, "event_id", tier.EventID, "strategy", tier.Strategy)
+	return nil
+}
+
+func (s *pricingServiceImpl) CreateTicketsForTier(ctx context.Context, tierID uint, numTickets int) ([]models.Ticket, error) {
+	if numTickets <= 0 {
+		return nil, utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", "Number of tickets cannot be zero or negative")
+	}
+
+	tier, err := s.GetTierByID(ctx, tierID)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := make([]models.Ticket, numTickets)
+	for i := 0; i < numTickets; i++ {
+		tickets[i] = models.Ticket{
+			EventID: tier.EventID,
+			TierID:  &tier.ID,
+			Price:   tier.BasePrice,
+			Status:  models.TicketStatusAvailable,
+		}
+	}
+
+	if err := s.TicketRepo.CreateTicketsBatch(ctx, tickets); err != nil {
+		return nil, utils.WrapError(err, 500, utils.CodeInternal, "Failed to create tickets")
+	}
+	return tickets, nil
+}
+
+// QuoteTicket evaluates the ticket's current price. Tickets without a tier
+// are charged their stored price.
+func (s *pricingServiceImpl) QuoteTicket(ctx context.Context, ticket *models.Ticket) (*models.PriceRecord, error) {
+	now := time.Now()
+	record := &models.PriceRecord{
+		TicketID:    ticket.ID,
+		TierID:      ticket.TierID,
+		Strategy:    pricing.StrategyFixed,
+		BasePrice:   ticket.Price,
+		Price:       ticket.Price,
+		EvaluatedAt: now,
+	}
+	if ticket.TierID == nil {
+		return record, nil
+	}
+
+	tier, err := s.GetTierByID(ctx, *ticket.TierID)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := pricing.Build(tier.Strategy, []byte(tier.StrategyConfig))
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Invalid pricing strategy", err.Error())
+	}
+
+	total, held, err := s.PricingRepo.CountTierInventory(ctx, tier.ID)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to count tier inventory", err.Error())
+	}
+
+	price, err := strategy.Price(pricing.Input{
+		Base:      tier.BasePrice,
+		Total:     total,
+		Held:      held,
+		Now:       now,
+		EventDate: tier.Event.Date,
+	})
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to evaluate price", err.Error())
+	}
+
+	record.Strategy = strategy.Name()
+	record.BasePrice = tier.BasePrice
+	record.Price = price
+	record.TierTotal = total
+	record.TierHeld = held
+	return record, nil
+}
+
+func (s *pricingServiceImpl) GetTierByID(ctx context.Context, tierID uint) (*models.PricingTier, error) {
+	tier, err := s.PricingRepo.GetTierByID(ctx, tierID)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve pricing tier", err.Error())
+	}
+	if tier == nil {
+		return nil, utils.NewAppError(404, utils.CodePricingTierNotFound, "Pricing tier not found", fmt.Sprintf("Pricing tier %d not found", tierID))
+	}
+	return tier, nil
+}
@@ -1,39 +1,52 @@
 package services
 
 import (
+	"booking-service/internal/metrics"
 	"booking-service/internal/models"
 	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
 	kafkaModels "booking-service/pkg/kafka/models"
+	"booking-service/pkg/money"
 	"booking-service/utils"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 )
 
 type TicketService interface {
-	CreateTicketsForEvent(eventID uint, numTickets int, price float64) ([]models.Ticket, error)
-	GetTicketByID(ticketID uint) (*models.Ticket, error)
-	ReserveTicket(ticketID, userID, bookingID uint) error
-	ListAvailableTickets(eventID uint) ([]models.Ticket, error)
-	UpdateTicketStatus(ticketID uint, status models.TicketStatus) error
-	ReleaseTicket(ticketID uint) error
-	MarkTicketAsSold(ticketID uint) error
-	HandleBookingEvent(eventType string, payload kafkaModels.BookingEvent) error
+	CreateTicketsForEvent(ctx context.Context, eventID uint, numTickets int, price money.Money) ([]models.Ticket, error)
+	GetTicketByID(ctx context.Context, ticketID uint) (*models.Ticket, error)
+	ReserveTicket(ctx context.Context, ticketID, userID, bookingID uint) error
+	ListAvailableTickets(ctx context.Context, eventID uint) ([]models.Ticket, error)
+	UpdateTicketStatus(ctx context.Context, ticketID uint, status models.TicketStatus) error
+	ReleaseTicket(ctx context.Context, ticketID uint) error
+	MarkTicketAsSold(ctx context.Context, ticketID uint) error
+	HandleBookingEvent(ctx context.Context, eventType string, payload kafkaModels.BookingEvent) error
 }
 
 type ticketServiceImpl struct {
 	TicketRepo repositories.TicketRepository
-	Logger     *utils.Logger
+	Logger     *slog.Logger
 }
 
 func NewTicketService(ticketRepo repositories.TicketRepository) TicketService {
 	return &ticketServiceImpl{
 		TicketRepo: ticketRepo,
-		Logger:     utils.NewLogger(),
+		Logger:     slog.Default(),
 	}
 }
 
-func (s *ticketServiceImpl) CreateTicketsForEvent(eventID uint, numTickets int, price float64) ([]models.Ticket, error) {
-	if numTickets <= 0 || price <= 0 {
-		return nil, utils.NewAppError(400, "Invalid input", "Number of tickets or price cannot be zero or negative")
+func (s *ticketServiceImpl) CreateTicketsForEvent(ctx context.Context, eventID uint, numTickets int, price money.Money) ([]models.Ticket, error) {
+	price, err := applyTenantCurrency(ctx, price)
+	if err != nil {
+		return nil, err
+	}
+	if numTickets <= 0 || !price.IsPositive() {
+		return nil, utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", "Number of tickets or price cannot be zero or negative")
+	}
+	if err := price.Validate(); err != nil {
+		return nil, utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", err.Error())
 	}
 
 	tickets := make([]models.Ticket, numTickets)
@@ -45,100 +58,144 @@ func (s *ticketServiceImpl) CreateTicketsForEvent(eventID uint, numTickets int,
 		}
 	}
 
-	if err := s.TicketRepo.CreateTicketsBatch(tickets); err != nil {
-		return nil, utils.NewAppError(500, "Failed to create tickets", err.Error())
+	if err := s.TicketRepo.CreateTicketsBatch(ctx, tickets); err != nil {
+		return nil, utils.WrapError(err, 500, utils.CodeInternal, "Failed to create tickets")
 	}
 
 	return tickets, nil
 }
 
-func (s *ticketServiceImpl) GetTicketByID(ticketID uint) (*models.Ticket, error) {
-	ticket, err := s.TicketRepo.GetTicketByID(ticketID)
+func (s *ticketServiceImpl) GetTicketByID(ctx context.Context, ticketID uint) (*models.Ticket, error) {
+	ticket, err := s.TicketRepo.GetTicketByID(ctx, ticketID)
 	if err != nil {
-		return nil, utils.NewAppError(500, "Failed to retrieve ticket", err.Error())
+		return nil, utils.WrapError(err, 500, utils.CodeInternal, "Failed to retrieve ticket")
 	}
 	if ticket == nil {
-		return nil, utils.NewAppError(404, "Ticket not found", fmt.Sprintf("Ticket %d not found", ticketID))
+		return nil, utils.NewAppError(404, utils.CodeTicketNotFound, "Ticket not found", fmt.Sprintf("Ticket %d not found", ticketID))
 	}
 	return ticket, nil
 }
 
-func (s *ticketServiceImpl) ReserveTicket(ticketID, userID, bookingID uint) error {
-	ticket, err := s.GetTicketByID(ticketID)
+func (s *ticketServiceImpl) ReserveTicket(ctx context.Context, ticketID, userID, bookingID uint) error {
+	ticket, err := s.GetTicketByID(ctx, ticketID)
 	if err != nil {
 		return err
 	}
 	if ticket.Status != models.TicketStatusAvailable {
-		return utils.NewAppError(400, "Ticket not available", fmt.Sprintf("Ticket %d is not available", ticketID))
+		metrics.RecordReservationConflict(ctx)
+		return utils.NewAppError(400, utils.CodeTicketNotAvailable, "Ticket not available", fmt.Sprintf("Ticket %d is not available", ticketID))
 	}
 
-	return s.TicketRepo.ReserveTicket(ticketID, userID, bookingID)
+	err = s.TicketRepo.ReserveTicket(ctx, ticketID, userID, bookingID)
+	var appErr *utils.AppError
+	if errors.As(err, &appErr) && appErr.Code == 409 {
+		metrics.RecordReservationConflict(ctx)
+	}
+	return err
 }
 
-func (s *ticketServiceImpl) ListAvailableTickets(eventID uint) ([]models.Ticket, error) {
-	tickets, err := s.TicketRepo.ListAvailableTickets(eventID)
+func (s *ticketServiceImpl) ListAvailableTickets(ctx context.Context, eventID uint) ([]models.Ticket, error) {
+	tickets, err := s.TicketRepo.ListAvailableTickets(ctx, eventID)
 	if err != nil {
-		return nil, utils.NewAppError(500, "Failed to list available tickets", err.Error())
+		return nil, utils.WrapError(err, 500, utils.CodeInternal, "Failed to list available tickets")
 	}
 	return tickets, nil
 }
 
-func (s *ticketServiceImpl) UpdateTicketStatus(ticketID uint, status models.TicketStatus) error {
-	ticket, err := s.GetTicketByID(ticketID)
+func (s *ticketServiceImpl) UpdateTicketStatus(ctx context.Context, ticketID uint, status models.TicketStatus) error {
+	ticket, err := s.GetTicketByID(ctx, ticketID)
 	if err != nil {
 		return err
 	}
 	if ticket == nil {
-		return utils.NewAppError(404, "Ticket not found", fmt.Sprintf("Ticket %d not found", ticketID))
+		return utils.NewAppError(404, utils.CodeTicketNotFound, "Ticket not found", fmt.Sprintf("Ticket %d not found", ticketID))
 	}
 
-	return s.TicketRepo.UpdateTicketStatus(ticketID, status)
+	return s.TicketRepo.UpdateTicketStatus(ctx, ticketID, status)
 }
 
-func (s *ticketServiceImpl) ReleaseTicket(ticketID uint) error {
-	ticket, err := s.GetTicketByID(ticketID)
+func (s *ticketServiceImpl) ReleaseTicket(ctx context.Context, ticketID uint) error {
+	ticket, err := s.GetTicketByID(ctx, ticketID)
 	if err != nil {
 		return err
 	}
 	if ticket.Status != models.TicketStatusReserved {
-		return utils.NewAppError(400, "Ticket not reserved", fmt.Sprintf("Ticket %d is not reserved", ticketID))
+		return utils.NewAppError(400, utils.CodeTicketNotReserved, "Ticket not reserved", fmt.Sprintf("Ticket %d is not reserved", ticketID))
 	}
 
-	return s.UpdateTicketStatus(ticketID, models.TicketStatusAvailable)
+	return s.UpdateTicketStatus(ctx, ticketID, models.TicketStatusAvailable)
 }
 
-func (s *ticketServiceImpl) MarkTicketAsSold(ticketID uint) error {
-	ticket, err := s.GetTicketByID(ticketID)
+func (s *ticketServiceImpl) MarkTicketAsSold(ctx context.Context, ticketID uint) error {
+	ticket, err := s.GetTicketByID(ctx, ticketID)
 	if err != nil {
 		return err
 	}
 	if ticket.Status != models.TicketStatusReserved {
-		return utils.NewAppError(400, "Ticket not reserved", fmt.Sprintf("Ticket %d is not reserved", ticketID))
+		return utils.NewAppError(400, utils.CodeTicketNotReserved, "Ticket not reserved", fmt.Sprintf("Ticket %d is not reserved", ticketID))
 	}
 
-	return s.UpdateTicketStatus(ticketID, models.TicketStatusSold)
+	return s.UpdateTicketStatus(ctx, ticketID, models.TicketStatusSold)
 }
 
-func (s *ticketServiceImpl) HandleBookingEvent(eventType string, payload kafkaModels.BookingEvent) error {
-	s.Logger.Info(fmt.Sprintf("Handling booking event: %s", eventType))
+func (s *ticketServiceImpl) HandleBookingEvent(ctx context.Context, eventType string, payload kafkaModels.BookingEvent) error {
+	s.Logger.InfoContext(ctx, "Handling booking event", "event_type", eventType, "booking_id", payload.BookingID)
+
+	// Consumers run outside a request, so scope the handling to the event's tenant
+	if _, ok := tenancy.FromContext(ctx); !ok {
+		ctx = tenancy.WithTenant(ctx, tenancy.Tenant{ID: payload.TenantID})
+	}
 
 	switch eventType {
-	case "booking.canceled":
-		for _, ticketID := range payload.TicketIDs {
-			if err := s.ReleaseTicket(ticketID); err != nil {
-				s.Logger.Error(fmt.Sprintf("Failed to release ticket %d: %v", ticketID, err))
-				return err
-			}
+	case models.EventBookingCanceled:
+		return s.applyBookingEvent(ctx, payload, models.TicketStatusAvailable, s.ReleaseTicket)
+	case models.EventBookingConfirmed:
+		return s.applyBookingEvent(ctx, payload, models.TicketStatusSold, s.MarkTicketAsSold)
+	default:
+		s.Logger.WarnContext(ctx, "Unhandled event type", "event_type", eventType)
+	}
+	return nil
+}
+
+// applyBookingEvent applies a booking event to each of its tickets. Events are
+// delivered at least once and this service consumes the events it publishes,
+// so tickets already in the target status, or since held by another booking,
+// are skipped.
+func (s *ticketServiceImpl) applyBookingEvent(ctx context.Context, payload kafkaModels.BookingEvent, target models.TicketStatus, apply func(ctx context.Context, ticketID uint) error) error {
+	for _, ticketID := range payload.TicketIDs {
+		ticket, err := s.GetTicketByID(ctx, ticketID)
+		if err != nil {
+			return err
 		}
-	case "booking.confirmed":
-		for _, ticketID := range payload.TicketIDs {
-			if err := s.MarkTicketAsSold(ticketID); err != nil {
-				s.Logger.Error(fmt.Sprintf("Failed to mark ticket %d as sold: %v", ticketID, err))
-				return err
-			}
+		if ticket.Status == target {
+			s.Logger.DebugContext(ctx, "Ticket already up to date", "ticket_id", ticketID, "status", target)
+			continue
+		}
+		if ticket.BookingID != nil && *ticket.BookingID != payload.BookingID {
+			s.Logger.WarnContext(ctx, "Ticket held by another booking", "ticket_id", ticketID, "booking_id", payload.BookingID)
+			continue
+		}
+		if err := apply(ctx, ticketID); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to update ticket for booking event", "ticket_id", ticketID, "status", target, "error", err)
+			return err
 		}
-	default:
-		s.Logger.Warn(fmt.Sprintf("Unhandled event type: %s", eventType))
 	}
 	return nil
 }
+
+// applyTenantCurrency defaults an unset currency to the tenant's and rejects
+// prices in any other currency.
+func applyTenantCurrency(ctx context.Context, price money.Money) (money.Money, error) {
+	tenant, ok := tenancy.FromContext(ctx)
+	if !ok || tenant.Currency == "" {
+		return price, nil
+	}
+	if price.Currency == "" {
+		price.Currency = tenant.Currency
+	}
+	if price.Currency != tenant.Currency {
+		return price, utils.NewAppError(400, utils.CodeInvalidCurrency, "Invalid currency",
+			fmt.Sprintf("Tenant %s sells in %s, not %s", tenant.ID, tenant.Currency, price.Currency))
+	}
+	return price, nil
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/settings"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/kafka"
+	kafkaModels "booking-service/pkg/kafka/models"
+	"booking-service/utils"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type TransferService interface {
+	InitiateTransfer(ctx context.Context, ticketID uint, toUserID *uint) (*models.TicketTransfer, string, error)
+	AcceptTransfer(ctx context.Context, token string, userID uint) (*models.Ticket, error)
+	CancelTransfer(ctx context.Context, transferID uint) error
+	GetTransfer(ctx context.Context, transferID uint) (*models.TicketTransfer, error)
+	ListTransfers(ctx context.Context, ticketID uint) ([]models.TicketTransfer, error)
+}
+
+type transferServiceImpl struct {
+	TransferRepo  repositories.TransferRepository
+	TicketService TicketService
+	EventService  EventService
+	KafkaProducer kafka.Producer
+	Settings      *settings.Store
+	Logger        *slog.Logger
+}
+
+func NewTransferService(
+	transferRepo repositories.TransferRepository,
+	ticketService TicketService,
+	eventService EventService,
+	kafkaProducer kafka.Producer,
+	settingsStore *settings.Store,
+) TransferService {
+	return &transferServiceImpl{
+		TransferRepo:  transferRepo,
+		TicketService: ticketService,
+		EventService:  eventService,
+		KafkaProducer: kafkaProducer,
+		Settings:      settingsStore,
+		Logger:        slog.Default(),
+	}
+}
+
+// InitiateTransfer offers a sold ticket to another user on behalf of its
+// owner and returns the transfer with the token that accepts it. The token
+// is not stored and cannot be shown again. When toUserID is set only that
+// user can accept.
+func (s *transferServiceImpl) InitiateTransfer(ctx context.Context, ticketID uint, toUserID *uint) (*models.TicketTransfer, string, error) {
+	ticket, err := s.TicketService.GetTicketByID(ctx, ticketID)
+	if err != nil {
+		return nil, "", err
+	}
+	if ticket.Status != models.TicketStatusSold || ticket.UserID == nil {
+		return nil, "", utils.NewAppError(409, utils.CodeTicketNotTransferable, "Ticket not transferable", fmt.Sprintf("Ticket %d is not sold", ticketID))
+	}
+	if toUserID != nil && *toUserID == *ticket.UserID {
+		return nil, "", utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", "A ticket cannot be transferred to its owner")
+	}
+	closes, err := s.transfersClose(ctx, ticket)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := newTransferToken()
+	if err != nil {
+		return nil, "", utils.NewAppError(500, utils.CodeInternal, "Failed to generate transfer token", err.Error())
+	}
+	// A transfer cannot outlive the window in which it could be accepted
+	expiresAt := time.Now().Add(s.Settings.Current().TransferTTL)
+	if closes.Before(expiresAt) {
+		expiresAt = closes
+	}
+	transfer := &models.TicketTransfer{
+		TicketID:   ticket.ID,
+		FromUserID: *ticket.UserID,
+		ToUserID:   toUserID,
+		TokenHash:  hashTransferToken(token),
+		Status:     models.TicketTransferPending,
+		ExpiresAt:  expiresAt,
+	}
+	if err := s.TransferRepo.CreateTransfer(ctx, transfer); err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to create transfer", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return nil, "", appErr
+	}
+
+	s.Logger.InfoContext(ctx, "Ticket transfer initiated", "transfer_id", transfer.ID, "ticket_id", ticket.ID, "expires_at", expiresAt)
+	return transfer, token, nil
+}
+
+// AcceptTransfer hands the ticket of the transfer identified by token to
+// userID. The ticket's credential version is bumped so credentials issued to
+// the sender stop admitting, and ticket.transferred is published.
+func (s *transferServiceImpl) AcceptTransfer(ctx context.Context, token string, userID uint) (*models.Ticket, error) {
+	transfer, err := s.TransferRepo.GetTransferByTokenHash(ctx, hashTransferToken(token))
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve transfer", err.Error())
+	}
+	if transfer == nil {
+		return nil, utils.NewAppError(404, utils.CodeTransferNotFound, "Transfer not found", "")
+	}
+	if transfer.Status != models.TicketTransferPending {
+		return nil, utils.NewAppError(409, utils.CodeTransferNoLongerPending, "Transfer no longer pending", fmt.Sprintf("Transfer %d is %s", transfer.ID, transfer.Status))
+	}
+	if !time.Now().Before(transfer.ExpiresAt) {
+		if _, err := s.TransferRepo.SettleTransfer(ctx, transfer.ID, models.TicketTransferExpired); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to expire transfer", "transfer_id", transfer.ID, "error", err)
+		}
+		return nil, utils.NewAppError(410, utils.CodeTransferExpired, "Transfer expired", fmt.Sprintf("Transfer %d expired at %s", transfer.ID, transfer.ExpiresAt.Format(time.RFC3339)))
+	}
+	if transfer.ToUserID != nil && *transfer.ToUserID != userID {
+		return nil, utils.NewAppError(403, utils.CodeForbidden, "Forbidden", "The transfer is addressed to another user")
+	}
+	if transfer.FromUserID == userID {
+		return nil, utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", "A ticket cannot be transferred to its owner")
+	}
+
+	// The event may have moved or the cutoff grown since the transfer started
+	ticket, err := s.TicketService.GetTicketByID(ctx, transfer.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.transfersClose(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	ticket, err = s.TransferRepo.CompleteTransfer(ctx, transfer, userID)
+	if err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to complete transfer", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return nil, appErr
+	}
+	if ticket == nil {
+		// The sender lost the ticket meanwhile, e.g. to another transfer
+		if _, err := s.TransferRepo.SettleTransfer(ctx, transfer.ID, models.TicketTransferCanceled); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to cancel transfer", "transfer_id", transfer.ID, "error", err)
+		}
+		return nil, utils.NewAppError(409, utils.CodeTransferNoLongerPending, "Transfer no longer pending", fmt.Sprintf("Ticket %d changed since transfer %d was created", transfer.TicketID, transfer.ID))
+	}
+
+	tenant, _ := tenancy.FromContext(ctx)
+	event := kafkaModels.TicketTransferredEvent{
+		TenantID:          tenant.ID,
+		TicketID:          ticket.ID,
+		EventID:           ticket.EventID,
+		TransferID:        transfer.ID,
+		FromUserID:        transfer.FromUserID,
+		ToUserID:          userID,
+		CredentialVersion: ticket.CredentialVersion,
+	}
+	if err := s.KafkaProducer.Publish(ctx, tenant.Topic(models.EventTicketTransferred), event); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to publish ticket event", "event_type", models.EventTicketTransferred, "ticket_id", ticket.ID, "error", err)
+	}
+
+	s.Logger.InfoContext(ctx, "Ticket transferred", "transfer_id", transfer.ID, "ticket_id", ticket.ID, "credential_version", ticket.CredentialVersion)
+	return ticket, nil
+}
+
+// CancelTransfer withdraws a pending transfer; its token stops working.
+func (s *transferServiceImpl) CancelTransfer(ctx context.Context, transferID uint) error {
+	canceled, err := s.TransferRepo.SettleTransfer(ctx, transferID, models.TicketTransferCanceled)
+	if err != nil {
+		return utils.NewAppError(500, utils.CodeInternal, "Failed to cancel transfer", err.Error())
+	}
+	if !canceled {
+		return utils.NewAppError(409, utils.CodeTransferNoLongerPending, "Transfer no longer pending", fmt.Sprintf("Transfer %d is not pending", transferID))
+	}
+	s.Logger.InfoContext(ctx, "Ticket transfer canceled", "transfer_id", transferID)
+	return nil
+}
+
+func (s *transferServiceImpl) GetTransfer(ctx context.Context, transferID uint) (*models.TicketTransfer, error) {
+	transfer, err := s.TransferRepo.GetTransferByID(ctx, transferID)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve transfer", err.Error())
+	}
+	if transfer == nil {
+		return nil, utils.NewAppError(404, utils.CodeTransferNotFound, "Transfer not found", fmt.Sprintf("Transfer %d not found", transferID))
+	}
+	return transfer, nil
+}
+
+// ListTransfers returns the transfer history of a ticket, oldest first.
+func (s *transferServiceImpl) ListTransfers(ctx context.Context, ticketID uint) ([]models.TicketTransfer, error) {
+	transfers, err := s.TransferRepo.ListTransfersByTicket(ctx, ticketID)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to list transfers", err.Error())
+	}
+	return transfers, nil
+}
+
+// transfersClose returns when transfers of the ticket stop being accepted,
+// the configured cutoff before its event, or an error if that has passed.
+func (s *transferServiceImpl) transfersClose(ctx context.Context, ticket *models.Ticket) (time.Time, error) {
+	event, err := s.EventService.GetEventByID(ctx, ticket.EventID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cutoff := s.Settings.Current().TransferCutoff
+	closes := event.Date.Add(-cutoff)
+	if !time.Now().Before(closes) {
+		return time.Time{}, utils.NewAppError(409, utils.CodeTransfersClosed, "Transfers closed",
+			fmt.Sprintf("Tickets cannot be transferred less than %s before event %d", cutoff, event.ID))
+	}
+	return closes, nil
+}
+
+// newTransferToken returns a random URL-safe token; only its hash is stored.
+func newTransferToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func hashTransferToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
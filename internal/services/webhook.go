@@ -0,0 +1,146 @@
+package services
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/webhooks"
+	"booking-service/utils"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+)
+
+// minSecretLength keeps caller-chosen signing secrets out of brute-force range.
+const minSecretLength = 16
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uint) error
+	ListDeliveries(ctx context.Context, subscriptionID uint, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error)
+}
+
+type webhookServiceImpl struct {
+	WebhookRepo repositories.WebhookRepository
+	Targets     webhooks.TargetPolicy
+	Logger      *slog.Logger
+}
+
+// NewWebhookService returns the service managing webhook subscriptions.
+// Subscription URLs must resolve to addresses that targets allows.
+func NewWebhookService(webhookRepo repositories.WebhookRepository, targets webhooks.TargetPolicy) WebhookService {
+	return &webhookServiceImpl{
+		WebhookRepo: webhookRepo,
+		Targets:     targets,
+		Logger:      slog.Default(),
+	}
+}
+
+// CreateSubscription validates and stores a subscription. Without a secret,
+// a random one is generated; either way it is left in the subscription for
+// the caller to hand out once.
+func (s *webhookServiceImpl) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	target, err := url.Parse(subscription.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", "URL must be an absolute http or https URL")
+	}
+	// The dispatcher checks each connection again, as DNS answers can change
+	if err := s.Targets.CheckURL(ctx, subscription.URL); err != nil {
+		if errors.Is(err, webhooks.ErrPrivateTarget) {
+			return utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", "URL must resolve to public addresses")
+		}
+		return utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", "URL host cannot be resolved")
+	}
+	for _, eventType := range subscription.Events {
+		if !slices.Contains(models.WebhookEventTypes, eventType) {
+			return utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", fmt.Sprintf("Unknown event type %q", eventType))
+		}
+	}
+	switch {
+	case subscription.Secret == "":
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return utils.NewAppError(500, utils.CodeInternal, "Failed to generate webhook secret", err.Error())
+		}
+		subscription.Secret = hex.EncodeToString(secret)
+	case len(subscription.Secret) < minSecretLength:
+		return utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", fmt.Sprintf("Secret must be at least %d characters", minSecretLength))
+	}
+	subscription.Active = true
+
+	if err := s.WebhookRepo.CreateSubscription(ctx, subscription); err != nil {
+		appErr := utils.NewAppError(500, utils.CodeInternal, "Failed to create webhook subscription", err.Error())
+		s.Logger.ErrorContext(ctx, appErr.Message, "error", appErr)
+		return appErr
+	}
+
+	s.Logger.InfoContext(ctx, "Webhook subscription created", "subscription_id", subscription.ID, "events", subscription.Events)
+	return nil
+}
+
+func (s *webhookServiceImpl) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	subscriptions, err := s.WebhookRepo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to list webhook subscriptions", err.Error())
+	}
+	return subscriptions, nil
+}
+
+func (s *webhookServiceImpl) DeleteSubscription(ctx context.Context, id uint) error {
+	if _, err := s.getSubscription(ctx, id); err != nil {
+		return err
+	}
+	if err := s.WebhookRepo.DeleteSubscription(ctx, id); err != nil {
+		return utils.NewAppError(500, utils.CodeInternal, "Failed to delete webhook subscription", err.Error())
+	}
+	s.Logger.InfoContext(ctx, "Webhook subscription deleted", "subscription_id", id)
+	return nil
+}
+
+func (s *webhookServiceImpl) ListDeliveries(ctx context.Context, subscriptionID uint, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error) {
+	switch status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliveryDelivered, models.WebhookDeliveryFailed:
+	default:
+		return nil, utils.NewAppError(400, utils.CodeInvalidInput, "Invalid input", fmt.Sprintf("Unknown delivery status %q", status))
+	}
+	if _, err := s.getSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.WebhookRepo.ListDeliveries(ctx, subscriptionID, status, limit)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to list webhook deliveries", err.Error())
+	}
+	return deliveries, nil
+}
+
+// Redeliver queues a delivery to be sent again now, whatever its outcome so
+// far, with a fresh attempt budget.
+func (s *webhookServiceImpl) Redeliver(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error) {
+	delivery, err := s.WebhookRepo.Redeliver(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to redeliver webhook", err.Error())
+	}
+	if delivery == nil {
+		return nil, utils.NewAppError(404, utils.CodeWebhookDeliveryNotFound, "Webhook delivery not found", "")
+	}
+	s.Logger.InfoContext(ctx, "Webhook redelivery queued", "subscription_id", subscriptionID, "delivery_id", deliveryID)
+	return delivery, nil
+}
+
+func (s *webhookServiceImpl) getSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	subscription, err := s.WebhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, utils.NewAppError(500, utils.CodeInternal, "Failed to retrieve webhook subscription", err.Error())
+	}
+	if subscription == nil {
+		return nil, utils.NewAppError(404, utils.CodeWebhookSubscriptionNotFound, "Webhook subscription not found", "")
+	}
+	return subscription, nil
+}
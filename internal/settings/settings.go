@@ -0,0 +1,157 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Feature toggles read by the service. Features missing from the settings
+// are enabled.
+const (
+	FeatureBookings = "bookings" // accept new bookings
+)
+
+// Settings are the values that can change while the service runs. They are
+// read from the runtime.settings section of config.yaml and, optionally,
+// overridden by rows of the runtime_setting table.
+type Settings struct {
+	HoldTTL              time.Duration   `mapstructure:"hold_ttl" env:"RUNTIME_HOLD_TTL"`                               // pending bookings older than this are canceled
+	MaxTicketsPerBooking int             `mapstructure:"max_tickets_per_booking" env:"RUNTIME_MAX_TICKETS_PER_BOOKING"` // 0 means no limit
+	TransferTTL          time.Duration   `mapstructure:"transfer_ttl" env:"RUNTIME_TRANSFER_TTL"`                       // how long a ticket transfer can be accepted
+	TransferCutoff       time.Duration   `mapstructure:"transfer_cutoff" env:"RUNTIME_TRANSFER_CUTOFF"`                 // transfers are closed this long before the event
+	RateLimit            RateLimit       `mapstructure:"rate_limit"`
+	Features             map[string]bool `mapstructure:"features"`
+}
+
+// RateLimit is a token bucket applied per client to the API.
+type RateLimit struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second" env:"RUNTIME_RATE_LIMIT_REQUESTS_PER_SECOND"` // 0 disables rate limiting
+	Burst             int     `mapstructure:"burst" env:"RUNTIME_RATE_LIMIT_BURST"`
+}
+
+// Enabled reports whether a feature is on.
+func (s *Settings) Enabled(feature string) bool {
+	enabled, ok := s.Features[feature]
+	return !ok || enabled
+}
+
+// Problems lists why the settings cannot be applied, by key.
+func (s *Settings) Problems() []string {
+	var problems []string
+	if s.HoldTTL <= 0 {
+		problems = append(problems, fmt.Sprintf("hold_ttl: must be positive, got %s", s.HoldTTL))
+	}
+	if s.MaxTicketsPerBooking < 0 {
+		problems = append(problems, fmt.Sprintf("max_tickets_per_booking: cannot be negative, got %d", s.MaxTicketsPerBooking))
+	}
+	if s.TransferTTL <= 0 {
+		problems = append(problems, fmt.Sprintf("transfer_ttl: must be positive, got %s", s.TransferTTL))
+	}
+	if s.TransferCutoff < 0 {
+		problems = append(problems, fmt.Sprintf("transfer_cutoff: cannot be negative, got %s", s.TransferCutoff))
+	}
+	if s.RateLimit.RequestsPerSecond < 0 {
+		problems = append(problems, fmt.Sprintf("rate_limit.requests_per_second: cannot be negative, got %g", s.RateLimit.RequestsPerSecond))
+	}
+	if s.RateLimit.RequestsPerSecond > 0 && s.RateLimit.Burst < 1 {
+		problems = append(problems, fmt.Sprintf("rate_limit.burst: must be at least 1 when rate limiting, got %d", s.RateLimit.Burst))
+	}
+	return problems
+}
+
+// Validate returns an error listing every problem.
+func (s *Settings) Validate() error {
+	if problems := s.Problems(); len(problems) > 0 {
+		return errors.New("invalid runtime settings: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Clone returns a copy that shares nothing with s.
+func (s *Settings) Clone() *Settings {
+	clone := *s
+	clone.Features = make(map[string]bool, len(s.Features))
+	for name, enabled := range s.Features {
+		clone.Features[name] = enabled
+	}
+	return &clone
+}
+
+// Values returns every setting as a string, keyed like Set.
+func (s *Settings) Values() map[string]string {
+	values := map[string]string{
+		"hold_ttl":                       s.HoldTTL.String(),
+		"max_tickets_per_booking":        strconv.Itoa(s.MaxTicketsPerBooking),
+		"transfer_ttl":                   s.TransferTTL.String(),
+		"transfer_cutoff":                s.TransferCutoff.String(),
+		"rate_limit.requests_per_second": strconv.FormatFloat(s.RateLimit.RequestsPerSecond, 'g', -1, 64),
+		"rate_limit.burst":               strconv.Itoa(s.RateLimit.Burst),
+	}
+	for name, enabled := range s.Features {
+		values["features."+name] = strconv.FormatBool(enabled)
+	}
+	return values
+}
+
+// Set parses value into the setting named key, e.g. "hold_ttl" or
+// "features.bookings".
+func (s *Settings) Set(key, value string) error {
+	var err error
+	switch {
+	case key == "hold_ttl":
+		s.HoldTTL, err = time.ParseDuration(value)
+	case key == "max_tickets_per_booking":
+		s.MaxTicketsPerBooking, err = strconv.Atoi(value)
+	case key == "transfer_ttl":
+		s.TransferTTL, err = time.ParseDuration(value)
+	case key == "transfer_cutoff":
+		s.TransferCutoff, err = time.ParseDuration(value)
+	case key == "rate_limit.requests_per_second":
+		s.RateLimit.RequestsPerSecond, err = strconv.ParseFloat(value, 64)
+	case key == "rate_limit.burst":
+		s.RateLimit.Burst, err = strconv.Atoi(value)
+	case strings.HasPrefix(key, "features.") && len(key) > len("features."):
+		var enabled bool
+		enabled, err = strconv.ParseBool(value)
+		if s.Features == nil {
+			s.Features = make(map[string]bool)
+		}
+		s.Features[strings.TrimPrefix(key, "features.")] = enabled
+	default:
+		return fmt.Errorf("unknown runtime setting %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+	return nil
+}
+
+// Change is a setting whose value differs between two snapshots. From or To
+// is empty when the setting was added or removed.
+type Change struct {
+	Key  string
+	From string
+	To   string
+}
+
+// Diff lists the settings that differ from old to new, sorted by key.
+func Diff(old, new *Settings) []Change {
+	before, after := old.Values(), new.Values()
+	var changes []Change
+	for key, from := range before {
+		if to := after[key]; to != from {
+			changes = append(changes, Change{Key: key, From: from, To: to})
+		}
+	}
+	for key, to := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, Change{Key: key, To: to})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
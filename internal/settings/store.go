@@ -0,0 +1,92 @@
+package settings
+
+import (
+	"booking-service/internal/repositories"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// Source produces settings, given those of the sources before it. The first
+// source starts from the settings the store was created with.
+type Source func(ctx context.Context, base *Settings) (*Settings, error)
+
+// Store holds the current settings snapshot. Readers get an immutable
+// snapshot without locking; Reload builds a new one from the sources and
+// swaps it in only if it is valid.
+type Store struct {
+	current atomic.Pointer[Settings]
+	initial *Settings
+	sources []Source
+	reload  sync.Mutex
+	Logger  *slog.Logger
+}
+
+// NewStore returns a store serving initial until the first reload.
+func NewStore(initial *Settings, sources ...Source) *Store {
+	s := &Store{
+		initial: initial.Clone(),
+		sources: sources,
+		Logger:  slog.Default(),
+	}
+	s.current.Store(initial.Clone())
+	return s
+}
+
+// Current returns the current snapshot. Callers must not modify it.
+func (s *Store) Current() *Settings {
+	return s.current.Load()
+}
+
+// Reload rebuilds the settings from the sources. Invalid settings are
+// rejected and the current snapshot is kept; valid changes are applied
+// atomically and logged.
+func (s *Store) Reload(ctx context.Context) error {
+	s.reload.Lock()
+	defer s.reload.Unlock()
+
+	next := s.initial.Clone()
+	for _, source := range s.sources {
+		loaded, err := source(ctx, next)
+		if err != nil {
+			return fmt.Errorf("failed to load runtime settings: %w", err)
+		}
+		next = loaded
+	}
+	return s.apply(ctx, next)
+}
+
+func (s *Store) apply(ctx context.Context, next *Settings) error {
+	if err := next.Validate(); err != nil {
+		return fmt.Errorf("rejected runtime settings: %w", err)
+	}
+	changes := Diff(s.Current(), next)
+	if len(changes) == 0 {
+		return nil
+	}
+	s.current.Store(next)
+	for _, change := range changes {
+		s.Logger.InfoContext(ctx, "Runtime setting changed", "key", change.Key, "from", change.From, "to", change.To)
+	}
+	return nil
+}
+
+// DatabaseSource overrides settings with the rows of the runtime_setting
+// table, whose keys are named like Settings.Set.
+func DatabaseSource(repo repositories.SettingsRepository) Source {
+	return func(ctx context.Context, base *Settings) (*Settings, error) {
+		rows, err := repo.ListSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		settings := base.Clone()
+		for _, row := range rows {
+			if err := settings.Set(row.Key, row.Value); err != nil {
+				return nil, err
+			}
+		}
+		return settings, nil
+	}
+}
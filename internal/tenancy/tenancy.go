@@ -0,0 +1,155 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const DefaultTenantID = "default"
+
+var (
+	ErrMissingTenant  = errors.New("no tenant in context")
+	ErrUnknownTenant  = errors.New("unknown tenant")
+	ErrTenantMismatch = errors.New("token was issued for another tenant")
+	ErrTenantRequired = errors.New("token has no tenant claim")
+)
+
+// Tenant is a ticketing brand served by this deployment and its settings.
+type Tenant struct {
+	ID          string   `mapstructure:"id"`
+	Hosts       []string `mapstructure:"hosts"`
+	Currency    string   `mapstructure:"currency"`
+	FeeBps      int64    `mapstructure:"fee_bps"` // booking fee in basis points of the subtotal
+	TopicPrefix string   `mapstructure:"topic_prefix"`
+	// ResolveByHost lets tokens without a tenant claim pick this tenant by
+	// requesting one of its hosts, for brands whose tokens predate the claim.
+	ResolveByHost bool `mapstructure:"resolve_by_host"`
+}
+
+// Topic prefixes a Kafka topic with the tenant's namespace.
+func (t Tenant) Topic(topic string) string {
+	return t.TopicPrefix + topic
+}
+
+// Registry resolves tenants by ID or request host.
+type Registry struct {
+	byID   map[string]Tenant
+	byHost map[string]string
+}
+
+func NewRegistry(tenants []Tenant) (*Registry, error) {
+	r := &Registry{
+		byID:   make(map[string]Tenant, len(tenants)),
+		byHost: make(map[string]string),
+	}
+	for _, tenant := range tenants {
+		if tenant.ID == "" {
+			return nil, errors.New("tenant ID cannot be empty")
+		}
+		if _, exists := r.byID[tenant.ID]; exists {
+			return nil, fmt.Errorf("duplicate tenant %q", tenant.ID)
+		}
+		r.byID[tenant.ID] = tenant
+		for _, host := range tenant.Hosts {
+			host = strings.ToLower(host)
+			if other, exists := r.byHost[host]; exists {
+				return nil, fmt.Errorf("host %q is mapped to tenants %q and %q", host, other, tenant.ID)
+			}
+			r.byHost[host] = tenant.ID
+		}
+	}
+	return r, nil
+}
+
+// Get returns the tenant with the given ID.
+func (r *Registry) Get(id string) (Tenant, error) {
+	tenant, ok := r.byID[id]
+	if !ok {
+		return Tenant{}, fmt.Errorf("%w: %q", ErrUnknownTenant, id)
+	}
+	return tenant, nil
+}
+
+// All returns every configured tenant.
+func (r *Registry) All() []Tenant {
+	tenants := make([]Tenant, 0, len(r.byID))
+	for _, tenant := range r.byID {
+		tenants = append(tenants, tenant)
+	}
+	return tenants
+}
+
+// ForHost returns the tenant mapped to a Host header, ignoring any port.
+func (r *Registry) ForHost(host string) (Tenant, bool) {
+	if h, _, found := strings.Cut(host, ":"); found {
+		host = h
+	}
+	id, ok := r.byHost[strings.ToLower(host)]
+	if !ok {
+		return Tenant{}, false
+	}
+	return r.byID[id], true
+}
+
+// Resolve returns the tenant of a request: the one named by the token's
+// tenant claim, or for a token without one the tenant of the host if it opted
+// in with ResolveByHost, else the default tenant. Otherwise the host only
+// confirms the tenant, since the client controls it: a host mapped to another
+// tenant is ErrTenantMismatch. Without a default tenant, tokens must carry
+// the claim.
+func (r *Registry) Resolve(claimTenantID, host string) (Tenant, error) {
+	hostTenant, hostMapped := r.ForHost(host)
+	var tenant Tenant
+	switch {
+	case claimTenantID != "":
+		var err error
+		if tenant, err = r.Get(claimTenantID); err != nil {
+			return Tenant{}, err
+		}
+	case hostMapped && hostTenant.ResolveByHost:
+		return hostTenant, nil
+	default:
+		var ok bool
+		if tenant, ok = r.byID[DefaultTenantID]; !ok {
+			return Tenant{}, ErrTenantRequired
+		}
+	}
+	if hostMapped && hostTenant.ID != tenant.ID {
+		return Tenant{}, ErrTenantMismatch
+	}
+	return tenant, nil
+}
+
+type tenantKey struct{}
+type systemKey struct{}
+
+// WithTenant returns a context scoped to the tenant.
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// FromContext returns the tenant stored by WithTenant.
+func FromContext(ctx context.Context) (Tenant, bool) {
+	if ctx == nil {
+		return Tenant{}, false
+	}
+	tenant, ok := ctx.Value(tenantKey{}).(Tenant)
+	return tenant, ok
+}
+
+// WithSystem marks a context as running on behalf of the system rather than a
+// tenant, so queries are not scoped. Use only for cross-tenant maintenance.
+func WithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemKey{}, true)
+}
+
+// IsSystem reports whether the context was marked by WithSystem.
+func IsSystem(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	system, _ := ctx.Value(systemKey{}).(bool)
+	return system
+}
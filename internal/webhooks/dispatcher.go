@@ -0,0 +1,232 @@
+package webhooks
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// maxErrorBody bounds how much of a failed response is kept in the log. Only
+// responses from public addresses are kept, so a subscription cannot be used
+// to read internal services.
+const maxErrorBody = 512
+
+// DispatcherConfig tunes how deliveries are sent.
+type DispatcherConfig struct {
+	Workers     int           // deliveries sent concurrently
+	Interval    time.Duration // how often to poll for due deliveries
+	BatchSize   int           // deliveries claimed per poll
+	MaxAttempts int           // attempts before a delivery is marked failed
+	Timeout     time.Duration // per request
+
+	// AllowPrivateTargets also sends to loopback, private and link-local
+	// addresses, e.g. to receivers in a development network.
+	AllowPrivateTargets bool
+
+	// Failed attempts are retried after MinBackoff, doubling per attempt up to
+	// MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Dispatcher sends due webhook deliveries on a pool of workers.
+type Dispatcher struct {
+	repo     repositories.WebhookRepository
+	config   DispatcherConfig
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	Client   *http.Client
+	Logger   *slog.Logger
+}
+
+func NewDispatcher(repo repositories.WebhookRepository, config DispatcherConfig) *Dispatcher {
+	return &Dispatcher{
+		repo:   repo,
+		config: config,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		Client: TargetPolicy{AllowPrivate: config.AllowPrivateTargets}.Client(config.Timeout),
+		Logger: slog.Default(),
+	}
+}
+
+func (d *Dispatcher) Name() string { return "webhook dispatcher" }
+
+// Run polls for due deliveries until Shutdown is called.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	defer close(d.done)
+	ctx = context.WithoutCancel(ctx)
+
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stop:
+			return nil
+		case <-ticker.C:
+			if _, err := d.Dispatch(ctx); err != nil {
+				d.Logger.ErrorContext(ctx, "Failed to dispatch webhooks", "error", err)
+			}
+		}
+	}
+}
+
+// Shutdown stops polling once the deliveries in flight are sent. Pending
+// deliveries stay queued for the next start.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dispatch sends due deliveries in batches until none are left, and returns
+// how many succeeded. Deliveries of every tenant are sent.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	ctx = tenancy.WithSystem(ctx)
+	total := 0
+	for {
+		deliveries, err := d.repo.ClaimDue(ctx, d.config.BatchSize, d.lease())
+		if err != nil {
+			return total, err
+		}
+		total += d.sendAll(ctx, deliveries)
+		if len(deliveries) < d.config.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+// lease covers sending a whole batch on the workers, with a spare round.
+func (d *Dispatcher) lease() time.Duration {
+	rounds := (d.config.BatchSize+d.config.Workers-1)/d.config.Workers + 1
+	return time.Duration(rounds) * d.config.Timeout
+}
+
+func (d *Dispatcher) sendAll(ctx context.Context, deliveries []models.WebhookDelivery) int {
+	queue := make(chan *models.WebhookDelivery)
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		delivered int
+	)
+	for i := 0; i < d.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range queue {
+				if d.attempt(ctx, delivery) {
+					mu.Lock()
+					delivered++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range deliveries {
+		queue <- &deliveries[i]
+	}
+	close(queue)
+	wg.Wait()
+	return delivered
+}
+
+// attempt sends a delivery once and records the outcome, scheduling a retry
+// or giving up after the maximum attempts.
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) bool {
+	status, err := d.send(ctx, delivery)
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	if err == nil {
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= d.config.MaxAttempts {
+			delivery.Status = models.WebhookDeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts, d.config.MinBackoff, d.config.MaxBackoff))
+		}
+	}
+
+	logger := d.Logger.With("delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID,
+		"event_type", delivery.EventType, "attempts", delivery.Attempts)
+	switch delivery.Status {
+	case models.WebhookDeliveryDelivered:
+		logger.InfoContext(ctx, "Webhook delivered", "status", status)
+	case models.WebhookDeliveryFailed:
+		logger.ErrorContext(ctx, "Webhook delivery failed, giving up", "error", err)
+	default:
+		logger.WarnContext(ctx, "Webhook delivery failed, will retry", "error", err, "next_attempt_at", delivery.NextAttemptAt)
+	}
+
+	if err := d.repo.RecordAttempt(ctx, delivery); err != nil {
+		logger.ErrorContext(ctx, "Failed to record webhook attempt", "error", err)
+	}
+	return err == nil
+}
+
+// send posts the payload and returns the response status; non-2xx responses
+// are errors, quoting the response body when the endpoint is public.
+func (d *Dispatcher) send(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	var peer net.IP
+	trace := &httptrace.ClientTrace{GotConn: func(info httptrace.GotConnInfo) {
+		if addr, ok := info.Conn.RemoteAddr().(*net.TCPAddr); ok {
+			peer = addr.IP
+		}
+	}}
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodPost, delivery.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "booking-service-webhooks")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, fmt.Sprint(delivery.ID))
+	req.Header.Set(HeaderSignature, Sign(delivery.Subscription.Secret, time.Now(), body))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if !IsPublic(peer) {
+			return resp.StatusCode, fmt.Errorf("endpoint answered %s", resp.Status)
+		}
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, fmt.Errorf("endpoint answered %s: %s", resp.Status, bytes.TrimSpace(detail))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+	return resp.StatusCode, nil
+}
+
+// Backoff returns the wait before retrying after attempt failed attempts:
+// min doubled per attempt after the first, capped at max.
+func Backoff(attempt int, min, max time.Duration) time.Duration {
+	wait := min
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
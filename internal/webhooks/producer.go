@@ -0,0 +1,61 @@
+package webhooks
+
+import (
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/kafka"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Event is the body of a webhook request. Data is the Kafka message of the
+// event, e.g. the BookingEvent.
+type Event struct {
+	Type      string          `json:"type"`
+	TenantID  string          `json:"tenant_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// producer queues a webhook delivery for every event published through it,
+// so webhooks receive exactly the domain events Kafka consumers do.
+type producer struct {
+	next kafka.Producer
+	repo repositories.WebhookRepository
+}
+
+// NewProducer returns a kafka.Producer that publishes to next and then queues
+// the event for the tenant's matching webhook subscriptions.
+func NewProducer(next kafka.Producer, repo repositories.WebhookRepository) kafka.Producer {
+	return &producer{next: next, repo: repo}
+}
+
+func (p *producer) Publish(ctx context.Context, topic string, message interface{}) error {
+	if err := p.next.Publish(ctx, topic, message); err != nil {
+		return err
+	}
+
+	// Topics are the event type behind the tenant's prefix
+	tenant, _ := tenancy.FromContext(ctx)
+	eventType := strings.TrimPrefix(topic, tenant.TopicPrefix)
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook event: %w", err)
+	}
+	payload, err := json.Marshal(Event{Type: eventType, TenantID: tenant.ID, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook event: %w", err)
+	}
+	if _, err := p.repo.EnqueueDeliveries(ctx, eventType, string(payload)); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (p *producer) Close() error {
+	return p.next.Close()
+}
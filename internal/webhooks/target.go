@@ -0,0 +1,128 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateTarget is returned for webhook targets that are not public
+// addresses.
+var ErrPrivateTarget = errors.New("webhook target is not a public address")
+
+// blockedNets are non-public ranges that net.IP's predicates do not cover.
+var blockedNets = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),     // "this network", dialed as the local host
+	mustParseCIDR("100.64.0.0/10"), // carrier-grade NAT, used inside clouds
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// Resolver looks up the addresses of a host. *net.Resolver implements it.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// TargetPolicy decides where webhooks may be sent. Subscriptions are created
+// by tenants, so unless AllowPrivate is set only public unicast addresses are
+// allowed, keeping loopback, private and link-local services such as cloud
+// metadata out of reach.
+type TargetPolicy struct {
+	AllowPrivate bool
+	Resolver     Resolver // nil uses net.DefaultResolver
+}
+
+// Allows reports whether webhooks may be sent to ip.
+func (p TargetPolicy) Allows(ip net.IP) bool {
+	return p.AllowPrivate || IsPublic(ip)
+}
+
+// IsPublic reports whether ip is a public unicast address.
+func IsPublic(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, network := range blockedNets {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL resolves the host of rawURL and returns ErrPrivateTarget unless
+// every address it resolves to is allowed.
+func (p TargetPolicy) CheckURL(ctx context.Context, rawURL string) error {
+	if p.AllowPrivate {
+		return nil
+	}
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := target.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !p.Allows(ip) {
+			return ErrPrivateTarget
+		}
+		return nil
+	}
+
+	resolver := p.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !p.Allows(addr.IP) {
+			return ErrPrivateTarget
+		}
+	}
+	return nil
+}
+
+// Client returns an HTTP client for sending webhooks. Connections are checked
+// against the policy once the address is resolved, so a host that re-resolves
+// to a private address after CheckURL is still refused. Redirects are not
+// followed and proxies from the environment are not used.
+func (p TargetPolicy) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !p.Allows(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateTarget, host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
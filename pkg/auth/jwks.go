@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval bounds how often an unknown key ID triggers a JWKS refetch.
+const minRefreshInterval = time.Minute
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// KeySet holds RSA public keys loaded from a local JWKS file or a JWKS URL.
+// Keys fetched from a URL are refreshed when a token references an unknown kid.
+type KeySet struct {
+	file   string
+	url    string
+	client *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	lastRefresh time.Time
+}
+
+func NewKeySet(file, url string) (*KeySet, error) {
+	if file == "" && url == "" {
+		return nil, fmt.Errorf("%w: RS256 requires a JWKS file or URL", ErrMissingKey)
+	}
+
+	ks := &KeySet{
+		file:   file,
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+	if err := ks.refresh(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Key returns the public key for kid. An empty kid is accepted when the set
+// holds exactly one key.
+func (ks *KeySet) Key(kid string) (*rsa.PublicKey, error) {
+	if key := ks.lookup(kid); key != nil {
+		return key, nil
+	}
+
+	ks.mu.RLock()
+	stale := ks.url != "" && time.Since(ks.lastRefresh) > minRefreshInterval
+	ks.mu.RUnlock()
+	if stale {
+		if err := ks.refresh(); err != nil {
+			return nil, err
+		}
+		if key := ks.lookup(kid); key != nil {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown key ID %q", ErrInvalidToken, kid)
+}
+
+func (ks *KeySet) lookup(kid string) *rsa.PublicKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key
+		}
+	}
+	return ks.keys[kid]
+}
+
+func (ks *KeySet) refresh() error {
+	data, err := ks.read()
+	if err != nil {
+		return err
+	}
+
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := parseRSAKey(jwk)
+		if err != nil {
+			return fmt.Errorf("failed to parse JWKS key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: JWKS contains no RSA signing keys", ErrMissingKey)
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.lastRefresh = time.Now()
+	ks.mu.Unlock()
+	return nil
+}
+
+func (ks *KeySet) read() ([]byte, error) {
+	if ks.file != "" {
+		data, err := os.ReadFile(ks.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		return data, nil
+	}
+
+	resp, err := ks.client.Get(ks.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("exponent too large")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrMissingKey   = errors.New("no verification key configured")
+)
+
+// Claims are the JWT claims the service relies on.
+type Claims struct {
+	Role     string `json:"role"`
+	TenantID string `json:"tenant_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// UserID parses the numeric user ID carried in the subject claim.
+func (c *Claims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: subject %q is not a user ID", ErrInvalidToken, c.Subject)
+	}
+	return uint(id), nil
+}
+
+// Config describes how tokens are verified.
+type Config struct {
+	Algorithm  string
+	HMACSecret string
+	JWKSFile   string
+	JWKSURL    string
+	Issuer     string
+	Audience   string
+}
+
+// Verifier validates signed tokens and returns their claims.
+type Verifier struct {
+	algorithm string
+	secret    []byte
+	keys      *KeySet
+	parser    *jwt.Parser
+}
+
+// NewVerifier builds a Verifier for HS256 (shared secret) or RS256 (JWKS).
+func NewVerifier(cfg Config) (*Verifier, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{cfg.Algorithm}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	v := &Verifier{
+		algorithm: cfg.Algorithm,
+		parser:    jwt.NewParser(options...),
+	}
+
+	switch cfg.Algorithm {
+	case AlgorithmHS256:
+		if cfg.HMACSecret == "" {
+			return nil, fmt.Errorf("%w: HS256 requires a secret", ErrMissingKey)
+		}
+		v.secret = []byte(cfg.HMACSecret)
+	case AlgorithmRS256:
+		keys, err := NewKeySet(cfg.JWKSFile, cfg.JWKSURL)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", cfg.Algorithm)
+	}
+	return v, nil
+}
+
+// Verify parses the token, checks its signature and registered claims, and
+// returns the claims.
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, v.keyFunc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if _, err := claims.UserID(); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	if v.algorithm == AlgorithmHS256 {
+		return v.secret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	return v.keys.Key(kid)
+}
@@ -6,12 +6,16 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-var ctx = context.Background()
-
-func NewRedisClient(host, port string) (*redis.Client, error) {
-	client := redis.NewClient(&redis.Options{
+// NewClient creates a Redis client without contacting the server; connections
+// are made lazily on first use.
+func NewClient(host, port string) *redis.Client {
+	return redis.NewClient(&redis.Options{
 		Addr: fmt.Sprintf("%s:%s", host, port),
 	})
+}
+
+func NewRedisClient(ctx context.Context, host, port string) (*redis.Client, error) {
+	client := NewClient(host, port)
 	if err := client.Ping(ctx).Err(); err != nil {
 		return nil, err
 	}
@@ -6,7 +6,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
-	"log"
+	"log/slog"
 	"time"
 )
 
@@ -17,7 +17,13 @@ func Connect(host, port, user, password, dbName string) (*gorm.DB, error) {
 	)
 
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		// Only slow and failed queries are logged, without their parameters
+		Logger: logger.New(slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn), logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  logger.Warn,
+			IgnoreRecordNotFoundError: true,
+			ParameterizedQueries:      true,
+		}),
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true,
 		},
@@ -25,13 +31,13 @@ func Connect(host, port, user, password, dbName string) (*gorm.DB, error) {
 
 	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
 	if err != nil {
-		log.Printf("[ERROR] Failed to connect to database: %v", err)
+		slog.Error("Failed to connect to database", "error", err)
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		log.Printf("[ERROR] Failed to get database instance: %v", err)
+		slog.Error("Failed to get database instance", "error", err)
 		return nil, fmt.Errorf("failed to get database instance: %w", err)
 	}
 
@@ -40,10 +46,10 @@ func Connect(host, port, user, password, dbName string) (*gorm.DB, error) {
 	sqlDB.SetConnMaxLifetime(5 * time.Minute)
 
 	if err := sqlDB.Ping(); err != nil {
-		log.Printf("[ERROR] Database connection test failed: %v", err)
+		slog.Error("Database connection test failed", "error", err)
 		return nil, fmt.Errorf("database connection test failed: %w", err)
 	}
 
-	log.Println("[INFO] Database connected successfully")
+	slog.Info("Database connected", "host", host, "database", dbName)
 	return db, nil
 }
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"booking-service/pkg/migrate"
+	"embed"
+	"io/fs"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MigrationsTable = "schema_migrations"
+	SeedsTable      = "seed_migrations"
+)
+
+var (
+	//go:embed migrations/*.sql
+	migrationFiles embed.FS
+
+	//go:embed seeds/*.sql
+	seedFiles embed.FS
+)
+
+// NewMigrator returns the migrator for the service schema. defaultCurrency
+// tags prices converted from the legacy float columns.
+func NewMigrator(db *gorm.DB, defaultCurrency string) (*migrate.Migrator, error) {
+	migrations, err := loadEmbedded(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	legacyMoney, err := legacyMoneyMigration(defaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(db, MigrationsTable, append(migrations, legacyMoney))
+}
+
+// NewSeeder returns the migrator for demo and reference data. Seeds are
+// versioned separately from the schema so production can skip them.
+func NewSeeder(db *gorm.DB) (*migrate.Migrator, error) {
+	seeds, err := loadEmbedded(seedFiles, "seeds")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(db, SeedsTable, seeds)
+}
+
+func loadEmbedded(files embed.FS, dir string) ([]migrate.Migration, error) {
+	sub, err := fs.Sub(files, dir)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.Load(sub)
+}
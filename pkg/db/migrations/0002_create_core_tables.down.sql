@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS price_record;
+DROP TABLE IF EXISTS ticket;
+DROP TABLE IF EXISTS pricing_tier;
+DROP TABLE IF EXISTS booking;
+DROP TABLE IF EXISTS event;
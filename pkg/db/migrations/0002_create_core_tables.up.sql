@@ -0,0 +1,96 @@
+-- Baseline schema. Tables and indexes use the names GORM's AutoMigrate
+-- produced so databases created before versioned migrations are adopted as is.
+-- Those databases predate some columns, which the ALTER TABLE steps add before
+-- the indexes that cover them are created.
+
+CREATE TABLE IF NOT EXISTS event (
+    id           BIGSERIAL PRIMARY KEY,
+    tenant_id    TEXT        NOT NULL DEFAULT 'default',
+    name         TEXT        NOT NULL,
+    date         TIMESTAMPTZ NOT NULL,
+    location     TEXT        NOT NULL,
+    capacity     BIGINT      NOT NULL,
+    organizer_id BIGINT,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ
+);
+ALTER TABLE event
+    ADD COLUMN IF NOT EXISTS tenant_id    TEXT NOT NULL DEFAULT 'default',
+    ADD COLUMN IF NOT EXISTS organizer_id BIGINT;
+CREATE INDEX IF NOT EXISTS idx_event_tenant_id ON event (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_event_organizer_id ON event (organizer_id);
+
+CREATE TABLE IF NOT EXISTS booking (
+    id             BIGSERIAL PRIMARY KEY,
+    tenant_id      TEXT    NOT NULL DEFAULT 'default',
+    user_id        BIGINT  NOT NULL,
+    event_id       BIGINT  NOT NULL,
+    fee_amount     BIGINT  NOT NULL DEFAULT 0,
+    total_amount   BIGINT  NOT NULL,
+    total_currency CHAR(3) NOT NULL,
+    status         TEXT    NOT NULL,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    CONSTRAINT fk_booking_event FOREIGN KEY (event_id) REFERENCES event (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+ALTER TABLE booking
+    ADD COLUMN IF NOT EXISTS tenant_id  TEXT   NOT NULL DEFAULT 'default',
+    ADD COLUMN IF NOT EXISTS fee_amount BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_booking_tenant_id ON booking (tenant_id);
+
+CREATE TABLE IF NOT EXISTS pricing_tier (
+    id                  BIGSERIAL PRIMARY KEY,
+    tenant_id           TEXT    NOT NULL DEFAULT 'default',
+    event_id            BIGINT  NOT NULL,
+    name                TEXT    NOT NULL,
+    base_price_amount   BIGINT  NOT NULL,
+    base_price_currency CHAR(3) NOT NULL,
+    strategy            TEXT    NOT NULL DEFAULT 'fixed',
+    strategy_config     TEXT,
+    created_at          TIMESTAMPTZ,
+    updated_at          TIMESTAMPTZ,
+    CONSTRAINT fk_pricing_tier_event FOREIGN KEY (event_id) REFERENCES event (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_pricing_tier_tenant_id ON pricing_tier (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_pricing_tier_event_id ON pricing_tier (event_id);
+
+CREATE TABLE IF NOT EXISTS ticket (
+    id             BIGSERIAL PRIMARY KEY,
+    tenant_id      TEXT    NOT NULL DEFAULT 'default',
+    event_id       BIGINT  NOT NULL,
+    tier_id        BIGINT,
+    price_amount   BIGINT  NOT NULL,
+    price_currency CHAR(3) NOT NULL,
+    status         TEXT    NOT NULL,
+    user_id        BIGINT,
+    booking_id     BIGINT,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    CONSTRAINT fk_ticket_event FOREIGN KEY (event_id) REFERENCES event (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_booking_tickets FOREIGN KEY (booking_id) REFERENCES booking (id)
+);
+ALTER TABLE ticket
+    ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default',
+    ADD COLUMN IF NOT EXISTS tier_id   BIGINT;
+CREATE INDEX IF NOT EXISTS idx_ticket_tenant_id ON ticket (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_ticket_tier_id ON ticket (tier_id);
+
+CREATE TABLE IF NOT EXISTS price_record (
+    id                  BIGSERIAL PRIMARY KEY,
+    tenant_id           TEXT        NOT NULL DEFAULT 'default',
+    booking_id          BIGINT      NOT NULL,
+    ticket_id           BIGINT      NOT NULL,
+    tier_id             BIGINT,
+    strategy            TEXT        NOT NULL,
+    base_price_amount   BIGINT      NOT NULL,
+    base_price_currency CHAR(3)     NOT NULL,
+    price_amount        BIGINT      NOT NULL,
+    price_currency      CHAR(3)     NOT NULL,
+    tier_total          BIGINT,
+    tier_held           BIGINT,
+    evaluated_at        TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_booking_price_records FOREIGN KEY (booking_id) REFERENCES booking (id)
+);
+CREATE INDEX IF NOT EXISTS idx_price_record_tenant_id ON price_record (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_price_record_booking_id ON price_record (booking_id);
+CREATE INDEX IF NOT EXISTS idx_price_record_ticket_id ON price_record (ticket_id);
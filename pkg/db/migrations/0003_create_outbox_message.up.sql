@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS outbox_message (
+    id         BIGSERIAL PRIMARY KEY,
+    topic      TEXT   NOT NULL,
+    payload    TEXT   NOT NULL,
+    headers    TEXT,
+    status     TEXT   NOT NULL,
+    attempts   BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ,
+    sent_at    TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_message_status ON outbox_message (status);
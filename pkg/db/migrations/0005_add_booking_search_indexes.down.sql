@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_ticket_booking_id;
+DROP INDEX IF EXISTS idx_booking_tenant_total;
+DROP INDEX IF EXISTS idx_booking_tenant_status_created;
+DROP INDEX IF EXISTS idx_booking_tenant_event_created;
+DROP INDEX IF EXISTS idx_booking_tenant_user_created;
+DROP INDEX IF EXISTS idx_booking_tenant_created;
@@ -0,0 +1,8 @@
+-- Every query is scoped to a tenant, so indexes lead with tenant_id. The
+-- (created_at, id) suffix serves the keyset order of booking listings.
+CREATE INDEX IF NOT EXISTS idx_booking_tenant_created ON booking (tenant_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_booking_tenant_user_created ON booking (tenant_id, user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_booking_tenant_event_created ON booking (tenant_id, event_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_booking_tenant_status_created ON booking (tenant_id, status, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_booking_tenant_total ON booking (tenant_id, total_currency, total_amount);
+CREATE INDEX IF NOT EXISTS idx_ticket_booking_id ON ticket (booking_id);
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS webhook_subscription (
+    id         BIGSERIAL PRIMARY KEY,
+    tenant_id  TEXT    NOT NULL DEFAULT 'default',
+    url        TEXT    NOT NULL,
+    secret     TEXT    NOT NULL,
+    events     TEXT,
+    active     BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscription_tenant_id ON webhook_subscription (tenant_id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id              BIGSERIAL PRIMARY KEY,
+    tenant_id       TEXT        NOT NULL DEFAULT 'default',
+    subscription_id BIGINT      NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        BIGINT      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    response_status BIGINT,
+    last_error      TEXT,
+    created_at      TIMESTAMPTZ,
+    delivered_at    TIMESTAMPTZ,
+    CONSTRAINT fk_webhook_delivery_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscription (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_tenant_id ON webhook_delivery (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_subscription_id ON webhook_delivery (subscription_id, id);
+-- The dispatcher polls for pending deliveries that are due
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_due ON webhook_delivery (next_attempt_at) WHERE status = 'PENDING';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ticket_transfer;
+ALTER TABLE ticket DROP COLUMN IF EXISTS credential_version;
@@ -0,0 +1,20 @@
+-- Bumped when a ticket changes owner so earlier credentials stop admitting
+ALTER TABLE ticket ADD COLUMN IF NOT EXISTS credential_version BIGINT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS ticket_transfer (
+    id           BIGSERIAL PRIMARY KEY,
+    tenant_id    TEXT        NOT NULL DEFAULT 'default',
+    ticket_id    BIGINT      NOT NULL,
+    from_user_id BIGINT      NOT NULL,
+    to_user_id   BIGINT,
+    token_hash   TEXT        NOT NULL,
+    status       TEXT        NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    accepted_at  TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    CONSTRAINT fk_ticket_transfer_ticket FOREIGN KEY (ticket_id) REFERENCES ticket (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_ticket_transfer_tenant_id ON ticket_transfer (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_ticket_transfer_ticket_id ON ticket_transfer (ticket_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_transfer_token_hash ON ticket_transfer (token_hash);
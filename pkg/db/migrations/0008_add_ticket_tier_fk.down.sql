@@ -0,0 +1 @@
+ALTER TABLE ticket DROP CONSTRAINT IF EXISTS fk_ticket_tier;
@@ -0,0 +1,7 @@
+-- Tickets keep their price when their tier goes away, so the tier reference is
+-- cleared rather than cascaded. References left dangling before the constraint
+-- existed are cleared first.
+UPDATE ticket SET tier_id = NULL
+WHERE tier_id IS NOT NULL AND tier_id NOT IN (SELECT id FROM pricing_tier);
+ALTER TABLE ticket DROP CONSTRAINT IF EXISTS fk_ticket_tier;
+ALTER TABLE ticket ADD CONSTRAINT fk_ticket_tier FOREIGN KEY (tier_id) REFERENCES pricing_tier (id) ON DELETE SET NULL;
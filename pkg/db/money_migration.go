@@ -0,0 +1,56 @@
+package db
+
+import (
+	"booking-service/pkg/migrate"
+	"booking-service/pkg/money"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// legacyMoneyMigration converts float64 price columns from before the Money
+// type into integer minor units tagged with defaultCurrency. It runs before the
+// core tables are created so the NOT NULL money columns are already populated,
+// and it is a no-op on databases that never had the legacy columns.
+func legacyMoneyMigration(defaultCurrency string) (migrate.Migration, error) {
+	exp, err := money.Exponent(defaultCurrency)
+	if err != nil {
+		return migrate.Migration{}, fmt.Errorf("invalid default currency: %w", err)
+	}
+	factor := math.Pow10(exp)
+
+	up := func(tx *gorm.DB) error {
+		migrator := tx.Migrator()
+
+		if migrator.HasTable("ticket") && migrator.HasColumn("ticket", "price") {
+			slog.Info("Migrating ticket.price to minor units", "currency", defaultCurrency)
+			if err := tx.Exec("ALTER TABLE ticket ADD COLUMN IF NOT EXISTS price_amount BIGINT, ADD COLUMN IF NOT EXISTS price_currency CHAR(3)").Error; err != nil {
+				return fmt.Errorf("failed to add ticket price columns: %w", err)
+			}
+			if err := tx.Exec("UPDATE ticket SET price_amount = ROUND(price * ?), price_currency = ? WHERE price_amount IS NULL", factor, defaultCurrency).Error; err != nil {
+				return fmt.Errorf("failed to convert ticket prices: %w", err)
+			}
+			if err := tx.Exec("ALTER TABLE ticket DROP COLUMN price").Error; err != nil {
+				return fmt.Errorf("failed to drop legacy ticket price column: %w", err)
+			}
+		}
+
+		if migrator.HasTable("booking") && !migrator.HasColumn("booking", "total_currency") {
+			slog.Info("Migrating booking.total_amount to minor units", "currency", defaultCurrency)
+			if err := tx.Exec("ALTER TABLE booking ADD COLUMN total_currency CHAR(3)").Error; err != nil {
+				return fmt.Errorf("failed to add booking currency column: %w", err)
+			}
+			if err := tx.Exec("ALTER TABLE booking ALTER COLUMN total_amount TYPE BIGINT USING ROUND(total_amount * ?)", factor).Error; err != nil {
+				return fmt.Errorf("failed to convert booking totals: %w", err)
+			}
+			if err := tx.Exec("UPDATE booking SET total_currency = ?", defaultCurrency).Error; err != nil {
+				return fmt.Errorf("failed to set booking currency: %w", err)
+			}
+		}
+
+		return nil
+	}
+	return migrate.Migration{Version: 1, Name: "convert_legacy_money", Up: up}, nil
+}
@@ -0,0 +1 @@
+DELETE FROM event WHERE id = 1 AND name = 'Event 1';
@@ -0,0 +1,6 @@
+INSERT INTO event (id, tenant_id, name, date, location, capacity, created_at, updated_at)
+VALUES (1, 'default', 'Event 1', '2024-11-16 23:43:06.667', 'Ho Chi Minh', 1000, NOW(), NOW())
+ON CONFLICT (id) DO NOTHING;
+
+-- Keep generated IDs clear of the explicit one above
+SELECT setval(pg_get_serial_sequence('event', 'id'), GREATEST((SELECT MAX(id) FROM event), 1));
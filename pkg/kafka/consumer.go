@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/segmentio/kafka-go"
 )
@@ -21,17 +22,32 @@ func NewConsumer(broker, topic, groupID string) Consumer {
 	}
 }
 
-func (c *consumerImpl) Consume(handler func(message []byte) error) error {
+// Consume reads messages until ctx is canceled, passing ctx on to the handler.
+// A message the handler fails on is logged and skipped, so one bad message
+// does not stop the consumer.
+func (c *consumerImpl) Consume(ctx context.Context, handler func(ctx context.Context, message []byte) error) error {
 	defer c.reader.Close()
 
 	for {
-		msg, err := c.reader.ReadMessage(context.Background())
+		msg, err := c.reader.ReadMessage(ctx)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
 			return fmt.Errorf("failed to read message: %w", err)
 		}
 
-		if err := handler(msg.Value); err != nil {
-			return fmt.Errorf("failed to handle message: %w", err)
+		msgCtx, span := startConsumerSpan(ctx, msg)
+		err = handler(msgCtx, msg.Value)
+		recordError(span, err)
+		span.End()
+		if err != nil {
+			slog.ErrorContext(msgCtx, "Failed to handle Kafka message, skipping it",
+				"topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset, "error", err)
 		}
 	}
 }
+
+func (c *consumerImpl) Lag() int64 {
+	return c.reader.Lag()
+}
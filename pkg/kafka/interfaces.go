@@ -1,9 +1,15 @@
 package kafka
 
+import "context"
+
 type Producer interface {
-	Publish(topic string, message interface{}) error
+	Publish(ctx context.Context, topic string, message interface{}) error
+	// Close flushes pending writes and releases the connection.
+	Close() error
 }
 
 type Consumer interface {
-	Consume(handler func(message []byte) error) error
+	Consume(ctx context.Context, handler func(ctx context.Context, message []byte) error) error
+	// Lag returns how many messages the consumer is behind the end of the partition.
+	Lag() int64
 }
@@ -1,8 +1,12 @@
 package models
 
+import "booking-service/pkg/money"
+
 type BookingEvent struct {
-	BookingID uint   `json:"booking_id"`
-	UserID    uint   `json:"user_id"`
-	EventID   uint   `json:"event_id"`
-	TicketIDs []uint `json:"ticket_ids"`
+	TenantID    string      `json:"tenant_id"`
+	BookingID   uint        `json:"booking_id"`
+	UserID      uint        `json:"user_id"`
+	EventID     uint        `json:"event_id"`
+	TicketIDs   []uint      `json:"ticket_ids"`
+	TotalAmount money.Money `json:"total_amount"`
 }
@@ -0,0 +1,13 @@
+package models
+
+// TicketTransferredEvent reports a ticket that changed owner. Credentials
+// issued before CredentialVersion no longer admit.
+type TicketTransferredEvent struct {
+	TenantID          string `json:"tenant_id"`
+	TicketID          uint   `json:"ticket_id"`
+	EventID           uint   `json:"event_id"`
+	TransferID        uint   `json:"transfer_id"`
+	FromUserID        uint   `json:"from_user_id"`
+	ToUserID          uint   `json:"to_user_id"`
+	CredentialVersion uint   `json:"credential_version"`
+}
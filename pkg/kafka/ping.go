@@ -0,0 +1,27 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Ping connects to the broker and requests its cluster metadata.
+func Ping(ctx context.Context, broker string) error {
+	conn, err := kafka.DialContext(ctx, "tcp", broker)
+	if err != nil {
+		return fmt.Errorf("failed to connect to broker %s: %w", broker, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	if _, err := conn.Brokers(); err != nil {
+		return fmt.Errorf("failed to read metadata from broker %s: %w", broker, err)
+	}
+	return nil
+}
@@ -21,22 +21,32 @@ func NewProducer(broker string) Producer {
 	}
 }
 
-func (p *producerImpl) Publish(topic string, message interface{}) error {
+func (p *producerImpl) Publish(ctx context.Context, topic string, message interface{}) error {
 	payload, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("failed to serialize message: %w", err)
 	}
 
+	var headers []kafka.Header
+	ctx, span := startProducerSpan(ctx, topic, &headers)
+	defer span.End()
+
 	err = p.writer.WriteMessages(
-		context.Background(),
+		ctx,
 		kafka.Message{
-			Topic: topic,
-			Value: payload,
+			Topic:   topic,
+			Value:   payload,
+			Headers: headers,
 		},
 	)
+	recordError(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to publish message to topic %s: %w", topic, err)
 	}
 
 	return nil
 }
+
+func (p *producerImpl) Close() error {
+	return p.writer.Close()
+}
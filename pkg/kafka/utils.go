@@ -3,13 +3,13 @@ package kafka
 import (
 	"booking-service/pkg/kafka/models"
 	"encoding/json"
-	"fmt"
+	"log/slog"
 )
 
 func ParseBookingEvent(message []byte) models.BookingEvent {
 	var event models.BookingEvent
 	if err := json.Unmarshal(message, &event); err != nil {
-		fmt.Printf("Failed to parse Kafka message: %v\n", err)
+		slog.Warn("Failed to parse Kafka message", "error", err)
 	}
 	return event
 }
@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+
+	redacted = "[REDACTED]"
+)
+
+// DefaultRedactKeys are attribute keys whose values are never written.
+var DefaultRedactKeys = []string{
+	"password",
+	"secret",
+	"hmac_secret",
+	"token",
+	"access_token",
+	"refresh_token",
+	"authorization",
+	"cookie",
+}
+
+// Config controls the log output.
+type Config struct {
+	Level      string   // debug, info, warn or error
+	Format     string   // json or text
+	RedactKeys []string // added to DefaultRedactKeys, matched case-insensitively
+}
+
+// New builds a logger writing to w. Every record is enriched with the
+// request ID, user ID and trace ID found in the context passed to the
+// *Context logging methods.
+func New(cfg Config, w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if cfg.Level != "" {
+		if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+		}
+	}
+
+	redact := make(map[string]bool)
+	for _, key := range append(DefaultRedactKeys, cfg.RedactKeys...) {
+		redact[strings.ToLower(key)] = true
+	}
+	options := &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if redact[strings.ToLower(attr.Key)] {
+				return slog.String(attr.Key, redacted)
+			}
+			return attr
+		},
+	}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case "", FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", cfg.Format)
+	}
+	return slog.New(contextHandler{Handler: handler}), nil
+}
+
+// Setup builds a logger with New and installs it as the slog and log default.
+func Setup(cfg Config, w io.Writer) (*slog.Logger, error) {
+	logger, err := New(cfg, w)
+	if err != nil {
+		return nil, err
+	}
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+type requestIDKey struct{}
+type userIDKey struct{}
+
+// WithRequestID stores the request ID that log records should carry.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID stored by WithRequestID.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithUserID stores the authenticated user that log records should carry.
+func WithUserID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// contextHandler adds correlation fields from the context to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if requestID := RequestID(ctx); requestID != "" {
+			record.AddAttrs(slog.String("request_id", requestID))
+		}
+		if userID, ok := ctx.Value(userIDKey{}).(uint); ok {
+			record.AddAttrs(slog.Uint64("user_id", uint64(userID)))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			record.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,300 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIrreversible   = errors.New("migration cannot be reverted")
+	ErrUnknownVersion = errors.New("applied migration is unknown to this build")
+)
+
+// fileName matches migration files such as 0002_create_core_tables.up.sql.
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change. Down is nil for migrations that
+// cannot be reverted.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SQL builds a migration from up and down SQL scripts. An empty down script
+// makes the migration irreversible.
+func SQL(version int64, name, up, down string) Migration {
+	migration := Migration{
+		Version: version,
+		Name:    name,
+		Up:      execScript(up),
+	}
+	if down != "" {
+		migration.Down = execScript(down)
+	}
+	return migration
+}
+
+func execScript(script string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(script).Error
+	}
+}
+
+// Load reads the <version>_<name>.up.sql and .down.sql files at the root of
+// fsys. Every version needs an up script; the down script is optional.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	type scripts struct {
+		name     string
+		up, down string
+		hasUp    bool
+	}
+	byVersion := map[int64]*scripts{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		s, ok := byVersion[version]
+		if !ok {
+			s = &scripts{name: match[2]}
+			byVersion[version] = s
+		}
+		if s.name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, s.name, match[2])
+		}
+		if match[3] == "up" {
+			s.up, s.hasUp = string(content), true
+		} else {
+			s.down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, s := range byVersion {
+		if !s.hasUp {
+			return nil, fmt.Errorf("migration %d_%s has no up script", version, s.name)
+		}
+		migrations = append(migrations, SQL(version, s.name, s.up, s.down))
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Status reports whether a known migration has been applied.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time // nil while pending
+}
+
+// appliedMigration is a row of the version table.
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Migrator applies migrations and records them in a version table. On
+// PostgreSQL an advisory lock keyed on the table name ensures only one
+// replica migrates at a time; the others wait and then find nothing to do.
+type Migrator struct {
+	db         *gorm.DB
+	table      string
+	migrations []Migration
+	Logger     *slog.Logger
+}
+
+// New creates a Migrator recording applied versions in table.
+func New(db *gorm.DB, table string, migrations []Migration) (*Migrator, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i, migration := range sorted {
+		if migration.Version <= 0 || migration.Up == nil {
+			return nil, fmt.Errorf("migration %d_%s needs a positive version and an up step", migration.Version, migration.Name)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+	}
+
+	return &Migrator{
+		db:         db,
+		table:      table,
+		migrations: sorted,
+		Logger:     slog.Default(),
+	}, nil
+}
+
+// Up applies every pending migration in version order and returns how many
+// were applied. Each migration runs in its own transaction.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.locked(ctx, func(conn *gorm.DB, applied map[int64]appliedMigration) error {
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			m.Logger.InfoContext(ctx, "Applying migration", "table", m.table, "version", migration.Version, "name", migration.Name)
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := migration.Up(tx); err != nil {
+					return err
+				}
+				return tx.Table(m.table).Create(&appliedMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now().UTC(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// how many were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	count := 0
+	err := m.locked(ctx, func(conn *gorm.DB, applied map[int64]appliedMigration) error {
+		versions := make([]int64, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for _, version := range versions {
+			if count == steps {
+				break
+			}
+			migration, ok := byVersion[version]
+			if !ok {
+				return fmt.Errorf("%w: %d_%s", ErrUnknownVersion, version, applied[version].Name)
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("%w: %d_%s", ErrIrreversible, version, migration.Name)
+			}
+			m.Logger.InfoContext(ctx, "Reverting migration", "table", m.table, "version", version, "name", migration.Name)
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := migration.Down(tx); err != nil {
+					return err
+				}
+				return tx.Table(m.table).Where("version = ?", version).Delete(&appliedMigration{}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("reverting migration %d_%s failed: %w", version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status lists every known migration, and any applied version this build
+// does not know about, in version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, func(_ *gorm.DB, applied map[int64]appliedMigration) error {
+		known := make(map[int64]bool, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.Version] = true
+		}
+		for _, row := range applied {
+			if !known[row.Version] {
+				appliedAt := row.AppliedAt
+				statuses = append(statuses, Status{Version: row.Version, Name: row.Name, AppliedAt: &appliedAt})
+			}
+		}
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if row, ok := applied[migration.Version]; ok {
+				appliedAt := row.AppliedAt
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
+
+// locked runs fn on a single connection holding the migration lock, with the
+// version table created and its rows loaded.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *gorm.DB, applied map[int64]appliedMigration) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if conn.Dialector.Name() == "postgres" {
+			key := m.lockKey()
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", key).Error; err != nil {
+				return fmt.Errorf("failed to acquire migration lock: %w", err)
+			}
+			defer func() {
+				// Unlock even if ctx was canceled so the session does not keep the lock
+				if err := conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(?)", key).Error; err != nil {
+					m.Logger.ErrorContext(ctx, "Failed to release migration lock", "error", err)
+				}
+			}()
+		}
+
+		create := fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS %s (version BIGINT PRIMARY KEY, name TEXT NOT NULL, applied_at TIMESTAMP NOT NULL)",
+			conn.Statement.Quote(m.table),
+		)
+		if err := conn.Exec(create).Error; err != nil {
+			return fmt.Errorf("failed to create %s: %w", m.table, err)
+		}
+
+		var rows []appliedMigration
+		if err := conn.Table(m.table).Order("version").Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to read %s: %w", m.table, err)
+		}
+		applied := make(map[int64]appliedMigration, len(rows))
+		for _, row := range rows {
+			applied[row.Version] = row
+		}
+		return fn(conn, applied)
+	})
+}
+
+// lockKey derives the advisory lock ID from the table name so schema and
+// seed migrations lock independently.
+func (m *Migrator) lockKey() int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("migrate:" + m.table))
+	return int64(h.Sum64())
+}
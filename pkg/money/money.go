@@ -0,0 +1,180 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+	ErrInvalidAmount       = errors.New("invalid amount")
+)
+
+// minorUnits maps supported ISO 4217 currency codes to the number of digits
+// after the decimal separator.
+var minorUnits = map[string]int{
+	"AUD": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"IDR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"MYR": 2,
+	"PHP": 2,
+	"SGD": 2,
+	"THB": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+// Money is an exact monetary amount stored as an integer number of minor
+// units (e.g. cents) together with its ISO 4217 currency code.
+type Money struct {
+	Amount   int64  `gorm:"not null" json:"amount"`
+	Currency string `gorm:"type:char(3);not null" json:"currency"`
+}
+
+// New creates a Money value after validating the currency code.
+func New(amount int64, currency string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	if !IsSupported(currency) {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Zero returns a zero amount in the given currency.
+func Zero(currency string) Money {
+	return Money{Currency: strings.ToUpper(currency)}
+}
+
+// IsSupported reports whether the currency code is known.
+func IsSupported(currency string) bool {
+	_, ok := minorUnits[currency]
+	return ok
+}
+
+// Exponent returns the number of minor unit digits for a currency.
+func Exponent(currency string) (int, error) {
+	exp, ok := minorUnits[strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+	return exp, nil
+}
+
+// Parse converts a decimal string such as "12.50" into minor units of the
+// given currency. More fractional digits than the currency allows is an error.
+func Parse(value, currency string) (Money, error) {
+	exp, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	whole, frac, _ := strings.Cut(value, ".")
+	if whole == "" || len(frac) > exp {
+		return Money{}, fmt.Errorf("%w: %q for %s", ErrInvalidAmount, value, currency)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q for %s", ErrInvalidAmount, value, currency)
+	}
+	if negative {
+		amount = -amount
+	}
+	return New(amount, currency)
+}
+
+// Validate checks that the currency is supported.
+func (m Money) Validate() error {
+	if !IsSupported(m.Currency) {
+		return fmt.Errorf("%w: %q", ErrUnsupportedCurrency, m.Currency)
+	}
+	return nil
+}
+
+// IsPositive reports whether the amount is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Add returns the sum of two amounts in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, fmt.Errorf("%w: overflow adding %s and %s", ErrInvalidAmount, m, other)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Multiply returns the amount multiplied by an integer quantity.
+func (m Money) Multiply(quantity int64) (Money, error) {
+	if quantity != 0 && (m.Amount*quantity)/quantity != m.Amount {
+		return Money{}, fmt.Errorf("%w: overflow multiplying %s by %d", ErrInvalidAmount, m, quantity)
+	}
+	return Money{Amount: m.Amount * quantity, Currency: m.Currency}, nil
+}
+
+// MultiplyBps scales the amount by bps/10000 (basis points), rounding half
+// away from zero to the nearest minor unit.
+func (m Money) MultiplyBps(bps int64) (Money, error) {
+	scaled, err := m.Multiply(bps)
+	if err != nil {
+		return Money{}, err
+	}
+	half := int64(5000)
+	if scaled.Amount < 0 {
+		half = -half
+	}
+	return Money{Amount: (scaled.Amount + half) / 10000, Currency: m.Currency}, nil
+}
+
+// Sum adds all amounts, failing if they are not all in the same currency.
+// An empty list sums to zero in the given currency.
+func Sum(currency string, amounts ...Money) (Money, error) {
+	total := Zero(currency)
+	if len(amounts) > 0 && currency == "" {
+		total = Zero(amounts[0].Currency)
+	}
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// String formats the amount in major units, e.g. "12.50 USD".
+func (m Money) String() string {
+	exp, ok := minorUnits[m.Currency]
+	if !ok || exp == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	divisor := int64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/divisor, exp, amount%divisor, m.Currency)
+}
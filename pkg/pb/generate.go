@@ -0,0 +1,6 @@
+// Package pb holds the Go code generated from the protobuf definitions in
+// /proto. Regenerate it after editing them with go generate, which needs
+// protoc, protoc-gen-go and protoc-gen-go-grpc on the PATH.
+package pb
+
+//go:generate protoc -I ../../proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative booking/v1/booking.proto
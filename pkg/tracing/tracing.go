@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Config selects where spans are exported.
+type Config struct {
+	ServiceName string
+	Exporter    string  // none, stdout or otlp
+	Endpoint    string  // OTLP gRPC collector address, e.g. localhost:4317
+	Insecure    bool    // disable TLS to the collector
+	SampleRatio float64 // fraction of new traces recorded; parents' decisions are honoured
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator, and returns a function that flushes and stops the exporter.
+// With the none exporter spans are still propagated but not recorded.
+func Setup(ctx context.Context, cfg Config) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// ChildOnly wraps a provider so its tracers only record spans that continue
+// an existing trace. Instrumentation such as database queries then shows up
+// inside request and message traces without background polling starting a
+// new trace every few seconds.
+func ChildOnly(provider trace.TracerProvider) trace.TracerProvider {
+	return childOnlyProvider{TracerProvider: provider}
+}
+
+type childOnlyProvider struct {
+	trace.TracerProvider
+}
+
+func (p childOnlyProvider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	return childOnlyTracer{Tracer: p.TracerProvider.Tracer(name, options...)}
+}
+
+type childOnlyTracer struct {
+	trace.Tracer
+}
+
+func (t childOnlyTracer) Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return noop.NewTracerProvider().Tracer("").Start(ctx, name, options...)
+	}
+	return t.Tracer.Start(ctx, name, options...)
+}
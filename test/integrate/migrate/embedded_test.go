@@ -0,0 +1,138 @@
+package migrate_test
+
+import (
+	"booking-service/pkg/db"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// baselineSchema is what AutoMigrate created before versioned migrations:
+// float prices and none of the tenant, fee, organizer or tier columns.
+const baselineSchema = `
+CREATE TABLE event (
+    id         BIGSERIAL PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    date       TIMESTAMPTZ NOT NULL,
+    location   TEXT        NOT NULL,
+    capacity   BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE TABLE booking (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT           NOT NULL,
+    event_id     BIGINT           NOT NULL,
+    total_amount DOUBLE PRECISION NOT NULL,
+    status       TEXT             NOT NULL,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    CONSTRAINT fk_booking_event FOREIGN KEY (event_id) REFERENCES event (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE ticket (
+    id         BIGSERIAL PRIMARY KEY,
+    event_id   BIGINT           NOT NULL,
+    price      DOUBLE PRECISION NOT NULL,
+    status     TEXT             NOT NULL,
+    user_id    BIGINT,
+    booking_id BIGINT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_ticket_event FOREIGN KEY (event_id) REFERENCES event (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_booking_tickets FOREIGN KEY (booking_id) REFERENCES booking (id)
+);
+INSERT INTO event (id, name, date, location, capacity) VALUES (1, 'Event 1', NOW(), 'Ho Chi Minh', 1000);
+INSERT INTO booking (id, user_id, event_id, total_amount, status) VALUES (1, 1, 1, 12.5, 'CONFIRMED');
+INSERT INTO ticket (id, event_id, price, status, user_id, booking_id) VALUES (1, 1, 12.5, 'SOLD', 1, 1);
+`
+
+// setupPostgres connects to TEST_POSTGRES_DSN, a key=value DSN, inside a fresh
+// schema that is dropped when the test ends. The embedded migrations are
+// PostgreSQL-only, so tests using it are skipped when the variable is unset.
+func setupPostgres(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	name := fmt.Sprintf("migrate_test_%d", time.Now().UnixNano())
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+name).Error)
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + name + " CASCADE")
+	})
+
+	database, err := gorm.Open(postgres.Open(dsn+" search_path="+name), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+	})
+	require.NoError(t, err)
+	return database
+}
+
+func TestEmbeddedMigrations_UpgradeBaselineSchema(t *testing.T) {
+	database := setupPostgres(t)
+	require.NoError(t, database.Exec(baselineSchema).Error)
+
+	migrator, err := db.NewMigrator(database, "USD")
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	schemaMigrator := database.Migrator()
+	for table, columns := range map[string][]string{
+		"event":   {"tenant_id", "organizer_id"},
+		"booking": {"tenant_id", "fee_amount", "total_currency"},
+		"ticket":  {"tenant_id", "tier_id", "price_amount", "credential_version"},
+	} {
+		for _, column := range columns {
+			assert.True(t, schemaMigrator.HasColumn(table, column), "%s.%s", table, column)
+		}
+	}
+	for table, indexes := range map[string][]string{
+		"event":   {"idx_event_tenant_id", "idx_event_organizer_id"},
+		"booking": {"idx_booking_tenant_id", "idx_booking_tenant_created"},
+		"ticket":  {"idx_ticket_tenant_id", "idx_ticket_tier_id"},
+	} {
+		for _, index := range indexes {
+			assert.True(t, schemaMigrator.HasIndex(table, index), "%s %s", table, index)
+		}
+	}
+	assert.True(t, schemaMigrator.HasConstraint("ticket", "fk_ticket_tier"))
+
+	// Existing rows land in the default tenant with their prices converted
+	var ticket struct {
+		TenantID    string
+		PriceAmount int64
+	}
+	require.NoError(t, database.Table("ticket").Where("id = ?", 1).Take(&ticket).Error)
+	assert.Equal(t, "default", ticket.TenantID)
+	assert.Equal(t, int64(1250), ticket.PriceAmount)
+
+	var feeAmount int64
+	require.NoError(t, database.Table("booking").Where("id = ?", 1).Select("fee_amount").Scan(&feeAmount).Error)
+	assert.Equal(t, int64(0), feeAmount)
+}
+
+func TestEmbeddedMigrations_RevertAndReapply(t *testing.T) {
+	database := setupPostgres(t)
+	migrator, err := db.NewMigrator(database, "USD")
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	// The legacy money conversion cannot be reverted
+	reverted, err := migrator.Down(ctx, applied-1)
+	require.NoError(t, err)
+	assert.Equal(t, applied-1, reverted)
+
+	reapplied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, applied-1, reapplied)
+}
@@ -0,0 +1,165 @@
+package migrate_test
+
+import (
+	"booking-service/pkg/db"
+	"booking-service/pkg/migrate"
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to in-memory database: %v", err)
+	}
+	// Keep a single connection so every query sees the same in-memory database
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("Failed to get database instance: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	return database
+}
+
+func testFiles() fstest.MapFS {
+	return fstest.MapFS{
+		"0001_create_venue.up.sql":     {Data: []byte("CREATE TABLE venue (id INTEGER PRIMARY KEY, name TEXT NOT NULL);")},
+		"0001_create_venue.down.sql":   {Data: []byte("DROP TABLE venue;")},
+		"0002_add_venue_city.up.sql":   {Data: []byte("ALTER TABLE venue ADD COLUMN city TEXT;")},
+		"0002_add_venue_city.down.sql": {Data: []byte("ALTER TABLE venue DROP COLUMN city;")},
+		"0003_seed_venue.up.sql":       {Data: []byte("INSERT INTO venue (id, name, city) VALUES (1, 'Hall', 'Hanoi');\nINSERT INTO venue (id, name, city) VALUES (2, 'Arena', 'Hue');")},
+		"README.md":                    {Data: []byte("not a migration")},
+	}
+}
+
+func newMigrator(t *testing.T, database *gorm.DB) *migrate.Migrator {
+	migrations, err := migrate.Load(testFiles())
+	assert.NoError(t, err)
+	migrator, err := migrate.New(database, "schema_migrations", migrations)
+	assert.NoError(t, err)
+	return migrator
+}
+
+func TestLoad_ParsesVersionedFiles(t *testing.T) {
+	migrations, err := migrate.Load(testFiles())
+
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 3)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_venue", migrations[0].Name)
+	assert.NotNil(t, migrations[1].Down)
+	assert.Nil(t, migrations[2].Down)
+}
+
+func TestLoad_RequiresUpScript(t *testing.T) {
+	_, err := migrate.Load(fstest.MapFS{
+		"0001_create_venue.down.sql": {Data: []byte("DROP TABLE venue;")},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestNew_RejectsDuplicateVersions(t *testing.T) {
+	_, err := migrate.New(nil, "schema_migrations", []migrate.Migration{
+		migrate.SQL(1, "a", "SELECT 1", ""),
+		migrate.SQL(1, "b", "SELECT 1", ""),
+	})
+
+	assert.Error(t, err)
+}
+
+func TestUp_AppliesPendingMigrationsOnce(t *testing.T) {
+	database := setupTestDB(t)
+	migrator := newMigrator(t, database)
+
+	applied, err := migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, applied)
+
+	var count int64
+	assert.NoError(t, database.Table("venue").Where("city IS NOT NULL").Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	applied, err = migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+}
+
+func TestUp_FailedMigrationIsNotRecorded(t *testing.T) {
+	database := setupTestDB(t)
+	migrator, err := migrate.New(database, "schema_migrations", []migrate.Migration{
+		migrate.SQL(1, "create_venue", "CREATE TABLE venue (id INTEGER PRIMARY KEY);", "DROP TABLE venue;"),
+		migrate.SQL(2, "broken", "ALTER TABLE missing ADD COLUMN name TEXT;", ""),
+	})
+	assert.NoError(t, err)
+
+	applied, err := migrator.Up(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 1, applied)
+
+	statuses, err := migrator.Status(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.Nil(t, statuses[1].AppliedAt)
+}
+
+func TestDown_RevertsNewestFirst(t *testing.T) {
+	database := setupTestDB(t)
+	migrator := newMigrator(t, database)
+	_, err := migrator.Up(ctx)
+	assert.NoError(t, err)
+
+	// The seed has no down script
+	_, err = migrator.Down(ctx, 1)
+	assert.ErrorIs(t, err, migrate.ErrIrreversible)
+
+	assert.NoError(t, database.Exec("DELETE FROM schema_migrations WHERE version = 3").Error)
+	reverted, err := migrator.Down(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reverted)
+	assert.False(t, database.Migrator().HasTable("venue"))
+
+	statuses, err := migrator.Status(ctx)
+	assert.NoError(t, err)
+	for _, status := range statuses {
+		assert.Nil(t, status.AppliedAt, "version %d", status.Version)
+	}
+}
+
+func TestStatus_ReportsUnknownAppliedVersions(t *testing.T) {
+	database := setupTestDB(t)
+	migrator := newMigrator(t, database)
+	_, err := migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, database.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (9, 'from_newer_build', CURRENT_TIMESTAMP)").Error)
+
+	statuses, err := migrator.Status(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 4)
+	assert.Equal(t, int64(9), statuses[3].Version)
+	assert.Equal(t, "from_newer_build", statuses[3].Name)
+
+	_, err = migrator.Down(ctx, 1)
+	assert.ErrorIs(t, err, migrate.ErrUnknownVersion)
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrator, err := db.NewMigrator(nil, "VND")
+	assert.NoError(t, err)
+	assert.NotNil(t, migrator)
+
+	seeder, err := db.NewSeeder(nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, seeder)
+
+	_, err = db.NewMigrator(nil, "XYZ")
+	assert.Error(t, err)
+}
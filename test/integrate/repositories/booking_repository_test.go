@@ -3,6 +3,10 @@ package repositories_test
 import (
 	"booking-service/internal/models"
 	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/money"
+	"booking-service/pkg/pagination"
+	"context"
 	"testing"
 	"time"
 
@@ -11,13 +15,18 @@ import (
 	"gorm.io/gorm"
 )
 
+var ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "tenant-a"})
+
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("Failed to connect to in-memory database: %v", err)
 	}
+	if err := db.Use(tenancy.Plugin{}); err != nil {
+		t.Fatalf("Failed to register tenancy plugin: %v", err)
+	}
 	// Migrate schema
-	err = db.AutoMigrate(&models.Booking{}, &models.Ticket{}, &models.Event{})
+	err = db.AutoMigrate(&models.Booking{}, &models.Ticket{}, &models.Event{}, &models.PricingTier{}, &models.PriceRecord{})
 	if err != nil {
 		t.Fatalf("Failed to migrate schema: %v", err)
 	}
@@ -38,18 +47,18 @@ func TestCreateBooking(t *testing.T) {
 	booking := &models.Booking{
 		UserID:      1,
 		EventID:     1,
-		TotalAmount: 100.0,
+		TotalAmount: money.Money{Amount: 10000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	err := repo.CreateBooking(booking)
+	err := repo.CreateBooking(ctx, booking)
 	assert.NoError(t, err)
 	assert.NotZero(t, booking.ID) // Ensure the ID is auto-generated
 
 	var storedBooking models.Booking
-	err = db.First(&storedBooking, "id = ?", booking.ID).Error
+	err = db.WithContext(ctx).First(&storedBooking, "id = ?", booking.ID).Error
 	assert.NoError(t, err)
 	assert.Equal(t, uint(1), storedBooking.UserID)
 	assert.Equal(t, uint(1), storedBooking.EventID)
@@ -62,43 +71,52 @@ func TestGetBookingByID(t *testing.T) {
 	booking := &models.Booking{
 		UserID:      1,
 		EventID:     1,
-		TotalAmount: 100.0,
+		TotalAmount: money.Money{Amount: 10000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	err := db.Create(booking).Error
+	err := db.WithContext(ctx).Create(booking).Error
 	assert.NoError(t, err)
 
-	result, err := repo.GetBookingByID(booking.ID)
+	result, err := repo.GetBookingByID(ctx, booking.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, booking.ID, result.ID)
 	t.Cleanup(func() { tearDownTestDB(db, t) })
 }
 
-func TestUpdateBookingStatus(t *testing.T) {
+func TestTransitionBookingStatus(t *testing.T) {
 	db := setupTestDB(t)
 	repo := repositories.NewBookingRepository(db)
 
 	booking := &models.Booking{
 		UserID:      1,
 		EventID:     1,
-		TotalAmount: 100.0,
+		TotalAmount: money.Money{Amount: 10000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	err := db.Create(booking).Error
+	err := db.WithContext(ctx).Create(booking).Error
 	assert.NoError(t, err)
 
-	err = repo.UpdateBookingStatus(booking.ID, models.BookingStatusConfirmed)
+	moved, err := repo.TransitionBookingStatus(ctx, booking.ID, models.BookingStatusConfirmed.Sources(), models.BookingStatusConfirmed)
 	assert.NoError(t, err)
+	assert.True(t, moved)
 
 	var updatedBooking models.Booking
-	err = db.First(&updatedBooking, "id = ?", booking.ID).Error
+	err = db.WithContext(ctx).First(&updatedBooking, "id = ?", booking.ID).Error
+	assert.NoError(t, err)
+	assert.Equal(t, models.BookingStatusConfirmed, updatedBooking.Status)
+
+	// A hold expiring after the booking was confirmed must not cancel it
+	moved, err = repo.TransitionBookingStatus(ctx, booking.ID, []models.BookingStatus{models.BookingStatusPending}, models.BookingStatusCanceled)
+	assert.NoError(t, err)
+	assert.False(t, moved)
+	err = db.WithContext(ctx).First(&updatedBooking, "id = ?", booking.ID).Error
 	assert.NoError(t, err)
 	assert.Equal(t, models.BookingStatusConfirmed, updatedBooking.Status)
 	t.Cleanup(func() { tearDownTestDB(db, t) })
@@ -111,20 +129,20 @@ func TestDeleteBooking(t *testing.T) {
 	booking := &models.Booking{
 		UserID:      1,
 		EventID:     1,
-		TotalAmount: 100.0,
+		TotalAmount: money.Money{Amount: 10000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	err := db.Create(booking).Error
+	err := db.WithContext(ctx).Create(booking).Error
 	assert.NoError(t, err)
 
-	err = repo.DeleteBooking(booking.ID)
+	err = repo.DeleteBooking(ctx, booking.ID)
 	assert.NoError(t, err)
 
 	var deletedBooking models.Booking
-	err = db.First(&deletedBooking, "id = ?", booking.ID).Error
+	err = db.WithContext(ctx).First(&deletedBooking, "id = ?", booking.ID).Error
 	assert.Error(t, err)
 	assert.Equal(t, gorm.ErrRecordNotFound, err)
 	t.Cleanup(func() { tearDownTestDB(db, t) })
@@ -137,7 +155,7 @@ func TestGetPendingBookingsOlderThan(t *testing.T) {
 	oldBooking := &models.Booking{
 		UserID:      1,
 		EventID:     1,
-		TotalAmount: 100.0,
+		TotalAmount: money.Money{Amount: 10000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		CreatedAt:   time.Now().Add(-2 * time.Hour),
 		UpdatedAt:   time.Now().Add(-2 * time.Hour),
@@ -146,24 +164,59 @@ func TestGetPendingBookingsOlderThan(t *testing.T) {
 	newBooking := &models.Booking{
 		UserID:      2,
 		EventID:     2,
-		TotalAmount: 200.0,
+		TotalAmount: money.Money{Amount: 20000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	err := db.Create(oldBooking).Error
+	err := db.WithContext(ctx).Create(oldBooking).Error
 	assert.NoError(t, err)
 
-	err = db.Create(newBooking).Error
+	err = db.WithContext(ctx).Create(newBooking).Error
 	assert.NoError(t, err)
 
-	result, err := repo.GetPendingBookingsOlderThan(1 * time.Hour)
+	result, err := repo.GetPendingBookingsOlderThan(ctx, 1*time.Hour)
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, oldBooking.ID, result[0].ID)
 }
 
+func TestScanBookings(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewBookingRepository(db)
+
+	for i := 0; i < 3; i++ {
+		booking := &models.Booking{
+			UserID:      1,
+			EventID:     1,
+			TotalAmount: money.Money{Amount: 1000, Currency: "USD"},
+			Status:      models.BookingStatusConfirmed,
+			CreatedAt:   time.Now().Add(-2 * time.Hour),
+			UpdatedAt:   time.Now().Add(-2 * time.Hour),
+		}
+		assert.NoError(t, db.WithContext(ctx).Create(booking).Error)
+		bookingID := booking.ID
+		ticket := &models.Ticket{EventID: 1, BookingID: &bookingID, Status: models.TicketStatusSold, Price: money.Money{Amount: 1000, Currency: "USD"}}
+		assert.NoError(t, db.WithContext(ctx).Create(ticket).Error)
+	}
+	recent := &models.Booking{UserID: 2, EventID: 1, Status: models.BookingStatusPending, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	assert.NoError(t, db.WithContext(ctx).Create(recent).Error)
+
+	var batches [][]models.Booking
+	err := repo.ScanBookings(ctx, time.Now().Add(-time.Hour), 2, func(bookings []models.Booking) error {
+		batches = append(batches, append([]models.Booking(nil), bookings...))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Len(t, batches[0], 2)
+	assert.Len(t, batches[1], 1)
+	assert.Len(t, batches[0][0].Tickets, 1)
+	assert.NotEqual(t, recent.ID, batches[1][0].ID)
+}
+
 func TestListBookingsByUserID(t *testing.T) {
 	db := setupTestDB(t)
 	repo := repositories.NewBookingRepository(db)
@@ -171,7 +224,7 @@ func TestListBookingsByUserID(t *testing.T) {
 	booking1 := &models.Booking{
 		UserID:      1,
 		EventID:     1,
-		TotalAmount: 100.0,
+		TotalAmount: money.Money{Amount: 10000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -180,22 +233,192 @@ func TestListBookingsByUserID(t *testing.T) {
 	booking2 := &models.Booking{
 		UserID:      1,
 		EventID:     2,
-		TotalAmount: 200.0,
+		TotalAmount: money.Money{Amount: 20000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	err := db.Create(booking1).Error
+	err := db.WithContext(ctx).Create(booking1).Error
 	assert.NoError(t, err)
 
-	err = db.Create(booking2).Error
+	err = db.WithContext(ctx).Create(booking2).Error
 	assert.NoError(t, err)
 
-	result, err := repo.ListBookingsByUserID(1, 1, 10)
+	result, err := repo.ListBookingsByUserID(ctx, 1, repositories.BookingQuery{Limit: 10})
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 	assert.Equal(t, uint(1), result[0].EventID)
 	assert.Equal(t, uint(2), result[1].EventID)
 	t.Cleanup(func() { tearDownTestDB(db, t) })
 }
+
+// createBookingsForPaging creates five bookings of user 1, two of them at the
+// same instant, and one of user 2.
+func createBookingsForPaging(t *testing.T, db *gorm.DB) []models.Booking {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{0, time.Hour, time.Hour, 2 * time.Hour, 3 * time.Hour}
+	bookings := make([]models.Booking, 0, len(offsets))
+	for i, offset := range offsets {
+		booking := models.Booking{
+			UserID:      1,
+			EventID:     uint(i%2 + 1),
+			TotalAmount: money.Money{Amount: 1000, Currency: "USD"},
+			Status:      models.BookingStatusPending,
+			CreatedAt:   base.Add(offset),
+			UpdatedAt:   base.Add(offset),
+		}
+		if i == 4 {
+			booking.Status = models.BookingStatusConfirmed
+		}
+		assert.NoError(t, db.WithContext(ctx).Create(&booking).Error)
+		bookings = append(bookings, booking)
+	}
+	other := models.Booking{UserID: 2, EventID: 1, Status: models.BookingStatusPending, CreatedAt: base, UpdatedAt: base}
+	assert.NoError(t, db.WithContext(ctx).Create(&other).Error)
+	return bookings
+}
+
+func collectIDs(bookings []models.Booking) []uint {
+	ids := make([]uint, 0, len(bookings))
+	for _, booking := range bookings {
+		ids = append(ids, booking.ID)
+	}
+	return ids
+}
+
+func TestListBookingsByUserID_KeysetPagesInOrder(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewBookingRepository(db)
+	created := createBookingsForPaging(t, db)
+
+	for _, descending := range []bool{false, true} {
+		var seen []uint
+		query := repositories.BookingQuery{Descending: descending, Limit: 2}
+		for {
+			page, err := repo.ListBookingsByUserID(ctx, 1, query)
+			assert.NoError(t, err)
+			if len(page) == 0 {
+				break
+			}
+			seen = append(seen, collectIDs(page)...)
+			last := page[len(page)-1]
+			query.After = &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}
+
+		expected := collectIDs(created)
+		if descending {
+			for i, j := 0, len(expected)-1; i < j; i, j = i+1, j-1 {
+				expected[i], expected[j] = expected[j], expected[i]
+			}
+		}
+		assert.Equal(t, expected, seen, "descending=%t", descending)
+	}
+}
+
+func TestListBookingsByUserID_Filters(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewBookingRepository(db)
+	created := createBookingsForPaging(t, db)
+
+	query := repositories.BookingQuery{
+		EventID:     1,
+		CreatedFrom: created[1].CreatedAt,
+		CreatedTo:   created[4].CreatedAt,
+		Limit:       10,
+	}
+	result, err := repo.ListBookingsByUserID(ctx, 1, query)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{created[2].ID}, collectIDs(result))
+
+	count, err := repo.CountBookingsByUserID(ctx, 1, query)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = repo.CountBookingsByUserID(ctx, 1, repositories.BookingQuery{Status: models.BookingStatusConfirmed})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestListBookingsByUserID_CanceledContext(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewBookingRepository(db)
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := repo.ListBookingsByUserID(canceledCtx, 1, repositories.BookingQuery{Limit: 10})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSearch(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewBookingRepository(db)
+	created := createBookingsForPaging(t, db)
+
+	bookingID := created[3].ID
+	ticket := models.Ticket{EventID: 2, Status: models.TicketStatusSold, Price: money.Money{Amount: 1000, Currency: "USD"}, BookingID: &bookingID}
+	assert.NoError(t, db.WithContext(ctx).Create(&ticket).Error)
+	assert.NoError(t, db.WithContext(ctx).Model(&created[4]).Update("total_amount", 5000).Error)
+
+	minTotal, maxTotal := int64(1000), int64(2000)
+	for name, tc := range map[string]struct {
+		criteria repositories.BookingCriteria
+		expected []uint
+	}{
+		"all users": {
+			criteria: repositories.BookingCriteria{EventID: 1, Limit: 10},
+			expected: []uint{created[0].ID, created[2].ID, created[4].ID, created[4].ID + 1},
+		},
+		"booking ID": {
+			criteria: repositories.BookingCriteria{BookingID: created[1].ID, Limit: 10},
+			expected: []uint{created[1].ID},
+		},
+		"ticket ID": {
+			criteria: repositories.BookingCriteria{TicketID: ticket.ID, Limit: 10},
+			expected: []uint{created[3].ID},
+		},
+		"statuses": {
+			criteria: repositories.BookingCriteria{UserID: 1, Statuses: []models.BookingStatus{models.BookingStatusConfirmed, models.BookingStatusCanceled}, Limit: 10},
+			expected: []uint{created[4].ID},
+		},
+		"amount range": {
+			criteria: repositories.BookingCriteria{TotalCurrency: "USD", MinTotal: &minTotal, MaxTotal: &maxTotal, CreatedFrom: created[2].CreatedAt, Limit: 10},
+			expected: []uint{created[1].ID, created[2].ID, created[3].ID},
+		},
+	} {
+		result, err := repo.Search(ctx, tc.criteria)
+		assert.NoError(t, err, name)
+		assert.ElementsMatch(t, tc.expected, collectIDs(result), name)
+
+		count, err := repo.CountSearch(ctx, tc.criteria)
+		assert.NoError(t, err, name)
+		assert.Equal(t, int64(len(tc.expected)), count, name)
+	}
+
+	result, err := repo.Search(ctx, repositories.BookingCriteria{TicketID: ticket.ID, Limit: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, []uint{ticket.ID}, []uint{result[0].Tickets[0].ID})
+	}
+}
+
+func TestSearch_KeysetPagesInOrder(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewBookingRepository(db)
+	created := createBookingsForPaging(t, db)
+
+	var seen []uint
+	criteria := repositories.BookingCriteria{UserID: 1, Limit: 2}
+	for {
+		page, err := repo.Search(ctx, criteria)
+		assert.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		seen = append(seen, collectIDs(page)...)
+		last := page[len(page)-1]
+		criteria.After = &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	assert.Equal(t, collectIDs(created), seen)
+}
@@ -0,0 +1,83 @@
+package repositories_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxProcessPending(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&models.OutboxMessage{}))
+	repo := repositories.NewOutboxRepository(db)
+
+	assert.NoError(t, repo.Enqueue(ctx, &models.OutboxMessage{Topic: "booking.created", Payload: `{"id":1}`}))
+	assert.NoError(t, repo.Enqueue(ctx, &models.OutboxMessage{Topic: "booking.canceled", Payload: `{"id":2}`}))
+
+	var published []string
+	sent, err := repo.ProcessPending(ctx, 10, 2, func(message models.OutboxMessage) error {
+		if message.Topic == "booking.canceled" {
+			return errors.New("broker unavailable")
+		}
+		published = append(published, message.Topic)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"booking.created"}, published)
+
+	var failing models.OutboxMessage
+	assert.NoError(t, db.First(&failing, "topic = ?", "booking.canceled").Error)
+	assert.Equal(t, models.OutboxStatusPending, failing.Status)
+	assert.Equal(t, 1, failing.Attempts)
+	assert.Equal(t, "broker unavailable", failing.LastError)
+
+	// The second failure reaches max attempts and parks the message
+	sent, err = repo.ProcessPending(ctx, 10, 2, func(message models.OutboxMessage) error {
+		return errors.New("broker unavailable")
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.NoError(t, db.First(&failing, failing.ID).Error)
+	assert.Equal(t, models.OutboxStatusFailed, failing.Status)
+
+	var delivered models.OutboxMessage
+	assert.NoError(t, db.First(&delivered, "topic = ?", "booking.created").Error)
+	assert.Equal(t, models.OutboxStatusSent, delivered.Status)
+	assert.NotNil(t, delivered.SentAt)
+}
+
+func TestOutboxListAndRequeue(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&models.OutboxMessage{}))
+	repo := repositories.NewOutboxRepository(db)
+
+	for _, topic := range []string{"booking.created", "booking.confirmed", "booking.canceled"} {
+		assert.NoError(t, repo.Enqueue(ctx, &models.OutboxMessage{Topic: topic, Payload: "{}"}))
+	}
+	_, err := repo.ProcessPending(ctx, 10, 1, func(message models.OutboxMessage) error {
+		if message.Topic == "booking.created" {
+			return nil
+		}
+		return errors.New("broker unavailable")
+	})
+	assert.NoError(t, err)
+
+	failed, err := repo.List(ctx, models.OutboxStatusFailed, nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, failed, 2)
+
+	requeued, err := repo.Requeue(ctx, []uint{failed[0].ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), requeued)
+
+	messages, err := repo.List(ctx, "", []uint{failed[0].ID}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, models.OutboxStatusPending, messages[0].Status)
+	assert.Zero(t, messages[0].Attempts)
+	assert.Empty(t, messages[0].LastError)
+}
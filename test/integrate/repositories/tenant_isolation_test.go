@@ -0,0 +1,80 @@
+package repositories_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/money"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var otherTenantCtx = tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "tenant-b"})
+
+func TestTenantIsolation_Bookings(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewBookingRepository(db)
+
+	booking := &models.Booking{
+		TenantID:    "tenant-b", // overwritten with the context's tenant
+		UserID:      1,
+		EventID:     1,
+		TotalAmount: money.Money{Amount: 10000, Currency: "USD"},
+		Status:      models.BookingStatusPending,
+	}
+	assert.NoError(t, repo.CreateBooking(ctx, booking))
+	assert.Equal(t, "tenant-a", booking.TenantID)
+
+	found, err := repo.GetBookingByID(otherTenantCtx, booking.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	bookings, err := repo.ListBookingsByUserID(otherTenantCtx, 1, repositories.BookingQuery{Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, bookings)
+
+	moved, err := repo.TransitionBookingStatus(otherTenantCtx, booking.ID, models.BookingStatusCanceled.Sources(), models.BookingStatusCanceled)
+	assert.NoError(t, err)
+	assert.False(t, moved)
+	assert.NoError(t, repo.DeleteBooking(otherTenantCtx, booking.ID))
+
+	found, err = repo.GetBookingByID(ctx, booking.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, models.BookingStatusPending, found.Status)
+}
+
+func TestTenantIsolation_Tickets(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewTicketRepository(db)
+
+	tickets := []models.Ticket{
+		{EventID: 1, Price: money.Money{Amount: 5000, Currency: "USD"}, Status: models.TicketStatusAvailable},
+		{EventID: 1, Price: money.Money{Amount: 5000, Currency: "USD"}, Status: models.TicketStatusAvailable},
+	}
+	assert.NoError(t, repo.CreateTicketsBatch(ctx, tickets))
+
+	available, err := repo.ListAvailableTickets(otherTenantCtx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, available)
+
+	assert.Error(t, repo.ReserveTicket(otherTenantCtx, tickets[0].ID, 2, 1))
+
+	available, err = repo.ListAvailableTickets(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, available, 2)
+}
+
+func TestTenantIsolation_MissingTenantFails(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewBookingRepository(db)
+
+	_, err := repo.ListBookingsByUserID(context.Background(), 1, repositories.BookingQuery{Limit: 10})
+	assert.True(t, errors.Is(err, tenancy.ErrMissingTenant))
+
+	_, err = repo.ListBookingsByUserID(tenancy.WithSystem(context.Background()), 1, repositories.BookingQuery{Limit: 10})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,152 @@
+package repositories_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/money"
+	"booking-service/utils"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountAvailableByEvent(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewTicketRepository(db)
+	price := money.Money{Amount: 1000, Currency: "USD"}
+
+	tenantB := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "tenant-b"})
+	assert.NoError(t, repo.CreateTicketsBatch(ctx, []models.Ticket{
+		{EventID: 1, Price: price, Status: models.TicketStatusAvailable},
+		{EventID: 1, Price: price, Status: models.TicketStatusAvailable},
+		{EventID: 1, Price: price, Status: models.TicketStatusSold},
+	}))
+	assert.NoError(t, repo.CreateTicketsBatch(tenantB, []models.Ticket{
+		{EventID: 2, Price: price, Status: models.TicketStatusAvailable},
+	}))
+
+	counts, err := repo.CountAvailableByEvent(tenancy.WithSystem(context.Background()))
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []repositories.EventAvailability{
+		{TenantID: "tenant-a", EventID: 1, Available: 2},
+		{TenantID: "tenant-b", EventID: 2, Available: 1},
+	}, counts)
+}
+
+func TestReleaseOrphanedTickets(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewTicketRepository(db)
+	price := money.Money{Amount: 1000, Currency: "USD"}
+	userID := uint(7)
+
+	live := &models.Booking{UserID: userID, EventID: 1, TotalAmount: price, Status: models.BookingStatusPending}
+	canceled := &models.Booking{UserID: userID, EventID: 1, TotalAmount: price, Status: models.BookingStatusCanceled}
+	assert.NoError(t, db.WithContext(ctx).Create(live).Error)
+	assert.NoError(t, db.WithContext(ctx).Create(canceled).Error)
+	missingID := uint(999)
+
+	tickets := []models.Ticket{
+		{EventID: 1, Price: price, Status: models.TicketStatusReserved, UserID: &userID, BookingID: &live.ID},
+		{EventID: 1, Price: price, Status: models.TicketStatusSold, UserID: &userID, BookingID: &canceled.ID},
+		{EventID: 1, Price: price, Status: models.TicketStatusReserved, UserID: &userID, BookingID: &missingID},
+		{EventID: 2, Price: price, Status: models.TicketStatusReserved, UserID: &userID},
+		{EventID: 1, Price: price, Status: models.TicketStatusAvailable},
+	}
+	assert.NoError(t, repo.CreateTicketsBatch(ctx, tickets))
+
+	orphans, err := repo.ListOrphanedTickets(ctx, 0)
+	assert.NoError(t, err)
+	assert.Len(t, orphans, 3)
+
+	orphans, err = repo.ListOrphanedTickets(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, orphans, 2)
+
+	// The live booking's ticket is skipped even when asked for explicitly
+	released, err := repo.ReleaseOrphanedTickets(ctx, []uint{tickets[0].ID, tickets[1].ID, tickets[2].ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), released)
+
+	var ticket models.Ticket
+	assert.NoError(t, db.WithContext(ctx).First(&ticket, tickets[1].ID).Error)
+	assert.Equal(t, models.TicketStatusAvailable, ticket.Status)
+	assert.Nil(t, ticket.BookingID)
+	assert.Nil(t, ticket.UserID)
+	var kept models.Ticket
+	assert.NoError(t, db.WithContext(ctx).First(&kept, tickets[0].ID).Error)
+	assert.Equal(t, models.TicketStatusReserved, kept.Status)
+
+	// Another tenant's orphans are out of reach
+	tenantB := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "tenant-b"})
+	orphans, err = repo.ListOrphanedTickets(tenantB, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, orphans)
+}
+
+func TestReissueCredential(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewTicketRepository(db)
+	price := money.Money{Amount: 1000, Currency: "USD"}
+
+	tickets := []models.Ticket{
+		{EventID: 1, Price: price, Status: models.TicketStatusSold},
+		{EventID: 1, Price: price, Status: models.TicketStatusAvailable},
+	}
+	assert.NoError(t, repo.CreateTicketsBatch(ctx, tickets))
+
+	reissued, err := repo.ReissueCredential(ctx, tickets[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), reissued.CredentialVersion)
+
+	unsold, err := repo.ReissueCredential(ctx, tickets[1].ID)
+	assert.NoError(t, err)
+	assert.Nil(t, unsold)
+
+	other := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "tenant-b"})
+	foreign, err := repo.ReissueCredential(other, tickets[0].ID)
+	assert.NoError(t, err)
+	assert.Nil(t, foreign)
+}
+
+func TestReserveTicket_LosesRace(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewTicketRepository(db)
+
+	tickets := []models.Ticket{{EventID: 1, Price: money.Money{Amount: 1000, Currency: "USD"}, Status: models.TicketStatusAvailable}}
+	assert.NoError(t, repo.CreateTicketsBatch(ctx, tickets))
+
+	assert.NoError(t, repo.ReserveTicket(ctx, tickets[0].ID, 1, 10))
+	err := repo.ReserveTicket(ctx, tickets[0].ID, 2, 11)
+
+	var appErr *utils.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 409, appErr.Code)
+	ticket, err := repo.GetTicketByID(ctx, tickets[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(10), *ticket.BookingID)
+}
+
+func TestCredentialVersion_BumpedWhenTicketLeavesSale(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repositories.NewTicketRepository(db)
+
+	tickets := []models.Ticket{{EventID: 1, Price: money.Money{Amount: 1000, Currency: "USD"}, Status: models.TicketStatusSold}}
+	assert.NoError(t, repo.CreateTicketsBatch(ctx, tickets))
+	version := func() uint {
+		ticket, err := repo.GetTicketByID(ctx, tickets[0].ID)
+		assert.NoError(t, err)
+		return ticket.CredentialVersion
+	}
+	assert.Equal(t, uint(1), version())
+
+	// Released, then sold to someone else: the first sale's credential stays revoked
+	assert.NoError(t, repo.UpdateTicketStatus(ctx, tickets[0].ID, models.TicketStatusAvailable))
+	assert.Equal(t, uint(2), version())
+	assert.NoError(t, repo.ReserveTicket(ctx, tickets[0].ID, 2, 11))
+	assert.Equal(t, uint(3), version())
+	assert.NoError(t, repo.UpdateTicketStatus(ctx, tickets[0].ID, models.TicketStatusSold))
+	assert.Equal(t, uint(3), version())
+}
@@ -0,0 +1,115 @@
+package repositories_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupTransferRepo(t *testing.T) (*gorm.DB, repositories.TransferRepository, *models.Ticket) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.TicketTransfer{}))
+
+	event := &models.Event{Name: "Concert", Date: time.Now().Add(72 * time.Hour), Location: "Hall", Capacity: 10}
+	require.NoError(t, db.WithContext(ctx).Create(event).Error)
+	owner := uint(1)
+	ticket := &models.Ticket{
+		EventID: event.ID,
+		Price:   money.Money{Amount: 1000, Currency: "USD"},
+		Status:  models.TicketStatusSold,
+		UserID:  &owner,
+	}
+	require.NoError(t, db.WithContext(ctx).Create(ticket).Error)
+	return db, repositories.NewTransferRepository(db), ticket
+}
+
+func newTransfer(ticket *models.Ticket, tokenHash string) *models.TicketTransfer {
+	return &models.TicketTransfer{
+		TicketID:   ticket.ID,
+		FromUserID: *ticket.UserID,
+		TokenHash:  tokenHash,
+		Status:     models.TicketTransferPending,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func TestCreateTransfer_SupersedesPending(t *testing.T) {
+	_, repo, ticket := setupTransferRepo(t)
+
+	first := newTransfer(ticket, "first")
+	require.NoError(t, repo.CreateTransfer(ctx, first))
+	second := newTransfer(ticket, "second")
+	require.NoError(t, repo.CreateTransfer(ctx, second))
+
+	transfers, err := repo.ListTransfersByTicket(ctx, ticket.ID)
+	assert.NoError(t, err)
+	require.Len(t, transfers, 2)
+	assert.Equal(t, models.TicketTransferCanceled, transfers[0].Status)
+	assert.Equal(t, models.TicketTransferPending, transfers[1].Status)
+
+	found, err := repo.GetTransferByTokenHash(ctx, "second")
+	assert.NoError(t, err)
+	assert.Equal(t, second.ID, found.ID)
+	missing, err := repo.GetTransferByTokenHash(otherTenantCtx, "second")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestCompleteTransfer_MovesOwnership(t *testing.T) {
+	_, repo, ticket := setupTransferRepo(t)
+	transfer := newTransfer(ticket, "token")
+	require.NoError(t, repo.CreateTransfer(ctx, transfer))
+
+	updated, err := repo.CompleteTransfer(ctx, transfer, 2)
+
+	assert.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, uint(2), *updated.UserID)
+	assert.Equal(t, uint(2), updated.CredentialVersion)
+
+	accepted, err := repo.GetTransferByID(ctx, transfer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TicketTransferAccepted, accepted.Status)
+	assert.Equal(t, uint(2), *accepted.ToUserID)
+	assert.NotNil(t, accepted.AcceptedAt)
+
+	// The token is spent
+	again, err := repo.CompleteTransfer(ctx, transfer, 3)
+	assert.NoError(t, err)
+	assert.Nil(t, again)
+}
+
+func TestCompleteTransfer_RollsBackWhenSenderLostTicket(t *testing.T) {
+	db, repo, ticket := setupTransferRepo(t)
+	transfer := newTransfer(ticket, "token")
+	require.NoError(t, repo.CreateTransfer(ctx, transfer))
+	require.NoError(t, db.WithContext(ctx).Model(ticket).Update("user_id", 4).Error)
+
+	updated, err := repo.CompleteTransfer(ctx, transfer, 2)
+
+	assert.NoError(t, err)
+	assert.Nil(t, updated)
+	pending, err := repo.GetTransferByID(ctx, transfer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TicketTransferPending, pending.Status)
+}
+
+func TestSettleTransfer_OnlyPending(t *testing.T) {
+	_, repo, ticket := setupTransferRepo(t)
+	transfer := newTransfer(ticket, "token")
+	require.NoError(t, repo.CreateTransfer(ctx, transfer))
+
+	settled, err := repo.SettleTransfer(ctx, transfer.ID, models.TicketTransferCanceled)
+	assert.NoError(t, err)
+	assert.True(t, settled)
+
+	settled, err = repo.SettleTransfer(ctx, transfer.ID, models.TicketTransferExpired)
+	assert.NoError(t, err)
+	assert.False(t, settled)
+}
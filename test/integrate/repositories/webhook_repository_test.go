@@ -0,0 +1,130 @@
+package repositories_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupWebhookRepo(t *testing.T) (*gorm.DB, repositories.WebhookRepository) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.WebhookSubscription{}, &models.WebhookDelivery{}))
+	return db, repositories.NewWebhookRepository(db)
+}
+
+func TestWebhookEnqueueDeliveries_MatchesFilters(t *testing.T) {
+	db, repo := setupWebhookRepo(t)
+
+	all := &models.WebhookSubscription{URL: "https://a.example/hook", Secret: "s"}
+	canceledOnly := &models.WebhookSubscription{URL: "https://b.example/hook", Secret: "s", Events: models.EventFilter{models.EventBookingCanceled}}
+	inactive := &models.WebhookSubscription{URL: "https://c.example/hook", Secret: "s"}
+	otherTenant := &models.WebhookSubscription{URL: "https://d.example/hook", Secret: "s"}
+	for _, subscription := range []*models.WebhookSubscription{all, canceledOnly, inactive} {
+		subscription.Active = true
+		require.NoError(t, repo.CreateSubscription(ctx, subscription))
+	}
+	require.NoError(t, db.WithContext(ctx).Model(inactive).Update("active", false).Error)
+	otherTenant.Active = true
+	require.NoError(t, repo.CreateSubscription(otherTenantCtx, otherTenant))
+
+	created, err := repo.EnqueueDeliveries(ctx, models.EventBookingCreated, `{"type":"booking.created"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created)
+
+	canceled, err := repo.EnqueueDeliveries(ctx, models.EventBookingCanceled, `{"type":"booking.canceled"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, canceled)
+
+	deliveries, err := repo.ListDeliveries(ctx, canceledOnly.ID, "", 10)
+	assert.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, models.EventBookingCanceled, deliveries[0].EventType)
+	assert.Equal(t, "tenant-a", deliveries[0].TenantID)
+
+	found, err := repo.GetSubscription(ctx, canceledOnly.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.EventFilter{models.EventBookingCanceled}, found.Events)
+}
+
+func TestWebhookClaimDue_LeasesDeliveries(t *testing.T) {
+	_, repo := setupWebhookRepo(t)
+	for tenantCtx, url := range map[context.Context]string{ctx: "https://a.example/hook", otherTenantCtx: "https://b.example/hook"} {
+		require.NoError(t, repo.CreateSubscription(tenantCtx, &models.WebhookSubscription{URL: url, Secret: "s", Active: true}))
+		_, err := repo.EnqueueDeliveries(tenantCtx, models.EventBookingCreated, "{}")
+		require.NoError(t, err)
+	}
+
+	// The dispatcher claims deliveries of every tenant
+	system := tenancy.WithSystem(context.Background())
+	claimed, err := repo.ClaimDue(system, 1, time.Minute)
+	assert.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "https://a.example/hook", claimed[0].Subscription.URL)
+	assert.True(t, claimed[0].NextAttemptAt.After(time.Now()), "claimed deliveries are leased")
+
+	second, err := repo.ClaimDue(system, 10, time.Minute)
+	assert.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, "https://b.example/hook", second[0].Subscription.URL)
+
+	again, err := repo.ClaimDue(system, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, again, "leased deliveries are not claimed twice")
+
+	// A failed attempt is due again once its backoff has passed
+	delivery := claimed[0]
+	delivery.Attempts = 1
+	delivery.ResponseStatus = 500
+	delivery.LastError = "endpoint answered 500"
+	delivery.NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, repo.RecordAttempt(system, &delivery))
+
+	retried, err := repo.ClaimDue(system, 10, time.Minute)
+	assert.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, 1, retried[0].Attempts)
+	assert.Equal(t, "endpoint answered 500", retried[0].LastError)
+}
+
+func TestWebhookRedeliver(t *testing.T) {
+	_, repo := setupWebhookRepo(t)
+	subscription := &models.WebhookSubscription{URL: "https://a.example/hook", Secret: "s", Active: true}
+	require.NoError(t, repo.CreateSubscription(ctx, subscription))
+	_, err := repo.EnqueueDeliveries(ctx, models.EventBookingCreated, "{}")
+	require.NoError(t, err)
+
+	deliveries, err := repo.ListDeliveries(ctx, subscription.ID, models.WebhookDeliveryPending, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	failed := deliveries[0]
+	failed.Status = models.WebhookDeliveryFailed
+	failed.Attempts = 8
+	failed.LastError = "connection refused"
+	require.NoError(t, repo.RecordAttempt(ctx, &failed))
+
+	missing, err := repo.Redeliver(ctx, subscription.ID+1, failed.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, missing, "deliveries are only found under their subscription")
+	missing, err = repo.Redeliver(otherTenantCtx, subscription.ID, failed.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	redelivered, err := repo.Redeliver(ctx, subscription.ID, failed.ID)
+	assert.NoError(t, err)
+	require.NotNil(t, redelivered)
+	assert.Equal(t, models.WebhookDeliveryPending, redelivered.Status)
+	assert.Zero(t, redelivered.Attempts)
+	assert.Empty(t, redelivered.LastError)
+
+	assert.NoError(t, repo.DeleteSubscription(ctx, subscription.ID))
+	deliveries, err = repo.ListDeliveries(ctx, subscription.ID, "", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
@@ -2,6 +2,8 @@ package mocks
 
 import (
 	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"context"
 	"github.com/stretchr/testify/mock"
 	"time"
 )
@@ -10,37 +12,66 @@ type BookingRepositoryMock struct {
 	mock.Mock
 }
 
-func (m *BookingRepositoryMock) GetPendingBookingsOlderThan(duration time.Duration) ([]models.Booking, error) {
-	panic("implement me")
+func (m *BookingRepositoryMock) GetPendingBookingsOlderThan(ctx context.Context, duration time.Duration) ([]models.Booking, error) {
+	args := m.Called(ctx, duration)
+	return args.Get(0).([]models.Booking), args.Error(1)
 }
 
-func (m *BookingRepositoryMock) CreateBooking(booking *models.Booking) error {
-	args := m.Called(booking)
+func (m *BookingRepositoryMock) CreateBooking(ctx context.Context, booking *models.Booking) error {
+	args := m.Called(ctx, booking)
 	return args.Error(0)
 }
 
-func (m *BookingRepositoryMock) GetBookingByID(bookingID uint) (*models.Booking, error) {
-	args := m.Called(bookingID)
+func (m *BookingRepositoryMock) GetBookingByID(ctx context.Context, bookingID uint) (*models.Booking, error) {
+	args := m.Called(ctx, bookingID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Booking), args.Error(1)
 }
 
-func (m *BookingRepositoryMock) UpdateBookingStatus(bookingID uint, status models.BookingStatus) error {
-	args := m.Called(bookingID, status)
-	return args.Error(0)
+func (m *BookingRepositoryMock) TransitionBookingStatus(ctx context.Context, bookingID uint, from []models.BookingStatus, to models.BookingStatus) (bool, error) {
+	args := m.Called(ctx, bookingID, from, to)
+	return args.Bool(0), args.Error(1)
 }
 
-func (m *BookingRepositoryMock) DeleteBooking(bookingID uint) error {
-	args := m.Called(bookingID)
+func (m *BookingRepositoryMock) DeleteBooking(ctx context.Context, bookingID uint) error {
+	args := m.Called(ctx, bookingID)
 	return args.Error(0)
 }
 
-func (m *BookingRepositoryMock) ListBookingsByUserID(userID uint, page, pageSize int) ([]models.Booking, error) {
-	args := m.Called(userID, page, pageSize)
+func (m *BookingRepositoryMock) ListBookingsByUserID(ctx context.Context, userID uint, query repositories.BookingQuery) ([]models.Booking, error) {
+	args := m.Called(ctx, userID, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.Booking), args.Error(1)
 }
+
+func (m *BookingRepositoryMock) CountBookingsByUserID(ctx context.Context, userID uint, query repositories.BookingQuery) (int64, error) {
+	args := m.Called(ctx, userID, query)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *BookingRepositoryMock) Search(ctx context.Context, criteria repositories.BookingCriteria) ([]models.Booking, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Booking), args.Error(1)
+}
+
+func (m *BookingRepositoryMock) CountSearch(ctx context.Context, criteria repositories.BookingCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *BookingRepositoryMock) ScanBookings(ctx context.Context, updatedBefore time.Time, batchSize int, fn func(bookings []models.Booking) error) error {
+	args := m.Called(ctx, batchSize)
+	if bookings := args.Get(0); bookings != nil {
+		if err := fn(bookings.([]models.Booking)); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
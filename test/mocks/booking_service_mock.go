@@ -2,6 +2,11 @@ package mocks
 
 import (
 	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/pkg/pagination"
+	"context"
+	"time"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,33 +14,44 @@ type BookingServiceMock struct {
 	mock.Mock
 }
 
-func (m *BookingServiceMock) CreateBooking(userID, eventID uint, ticketIDs []uint) (*models.Booking, error) {
-	args := m.Called(userID, eventID, ticketIDs)
+func (m *BookingServiceMock) CreateBooking(ctx context.Context, userID, eventID uint, ticketIDs []uint) (*models.Booking, error) {
+	args := m.Called(ctx, userID, eventID, ticketIDs)
 	return args.Get(0).(*models.Booking), args.Error(1)
 }
 
-func (m *BookingServiceMock) ConfirmBooking(bookingID uint) error {
-	args := m.Called(bookingID)
+func (m *BookingServiceMock) ConfirmBooking(ctx context.Context, bookingID uint) error {
+	args := m.Called(ctx, bookingID)
 	return args.Error(0)
 }
 
-func (m *BookingServiceMock) CancelBooking(bookingID uint) error {
-	args := m.Called(bookingID)
+func (m *BookingServiceMock) CancelBooking(ctx context.Context, bookingID uint) error {
+	args := m.Called(ctx, bookingID)
 	return args.Error(0)
 }
 
-func (m *BookingServiceMock) UpdateBookingStatus(bookingID uint, status models.BookingStatus) error {
-	args := m.Called(bookingID, status)
+func (m *BookingServiceMock) UpdateBookingStatus(ctx context.Context, bookingID uint, status models.BookingStatus) error {
+	args := m.Called(ctx, bookingID, status)
 	return args.Error(0)
 }
 
-func (m *BookingServiceMock) ListBookingsByUserID(userID uint, page, pageSize int) ([]models.Booking, error) {
-	args := m.Called(userID, page, pageSize)
-	return args.Get(0).([]models.Booking), args.Error(1)
+func (m *BookingServiceMock) ListBookingsByUserID(ctx context.Context, userID uint, query repositories.BookingQuery) (*pagination.Page[models.Booking], error) {
+	args := m.Called(ctx, userID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[models.Booking]), args.Error(1)
 }
 
-func (m *BookingServiceMock) GetBookingByID(bookingID uint) (*models.Booking, error) {
-	args := m.Called(bookingID)
+func (m *BookingServiceMock) SearchBookings(ctx context.Context, criteria repositories.BookingCriteria) (*pagination.Page[models.Booking], error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[models.Booking]), args.Error(1)
+}
+
+func (m *BookingServiceMock) GetBookingByID(ctx context.Context, bookingID uint) (*models.Booking, error) {
+	args := m.Called(ctx, bookingID)
 
 	// Check if the first argument is nil
 	if args.Get(0) == nil {
@@ -44,3 +60,8 @@ func (m *BookingServiceMock) GetBookingByID(bookingID uint) (*models.Booking, er
 
 	return args.Get(0).(*models.Booking), args.Error(1)
 }
+
+func (m *BookingServiceMock) ExpirePendingBookings(ctx context.Context, holdTTL time.Duration) (int, error) {
+	args := m.Called(ctx, holdTTL)
+	return args.Int(0), args.Error(1)
+}
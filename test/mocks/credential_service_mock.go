@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CredentialServiceMock struct {
+	mock.Mock
+}
+
+func (m *CredentialServiceMock) IssueCredential(ctx context.Context, ticketID uint) (string, error) {
+	args := m.Called(ctx, ticketID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *CredentialServiceMock) ReissueCredential(ctx context.Context, ticketID uint) (string, error) {
+	args := m.Called(ctx, ticketID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *CredentialServiceMock) VerifyCredential(ctx context.Context, credential string) (*models.Ticket, error) {
+	args := m.Called(ctx, credential)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Ticket), args.Error(1)
+}
@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventServiceMock struct {
+	mock.Mock
+}
+
+func (m *EventServiceMock) GetEventByID(ctx context.Context, eventID uint) (*models.Event, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Event), args.Error(1)
+}
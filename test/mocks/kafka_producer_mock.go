@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -8,7 +10,11 @@ type KafkaProducerMock struct {
 	mock.Mock
 }
 
-func (m *KafkaProducerMock) Publish(topic string, message interface{}) error {
-	args := m.Called(topic, message)
+func (m *KafkaProducerMock) Publish(ctx context.Context, topic string, message interface{}) error {
+	args := m.Called(ctx, topic, message)
 	return args.Error(0)
 }
+
+func (m *KafkaProducerMock) Close() error {
+	return nil
+}
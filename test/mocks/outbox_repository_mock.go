@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type OutboxRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *OutboxRepositoryMock) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *OutboxRepositoryMock) ProcessPending(ctx context.Context, limit, maxAttempts int, publish func(message models.OutboxMessage) error) (int, error) {
+	args := m.Called(ctx, limit, maxAttempts)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *OutboxRepositoryMock) List(ctx context.Context, status models.OutboxStatus, ids []uint, limit int) ([]models.OutboxMessage, error) {
+	args := m.Called(ctx, status, ids, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OutboxMessage), args.Error(1)
+}
+
+func (m *OutboxRepositoryMock) Requeue(ctx context.Context, ids []uint) (int64, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(int64), args.Error(1)
+}
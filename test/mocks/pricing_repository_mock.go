@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type PricingRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *PricingRepositoryMock) CreateTier(ctx context.Context, tier *models.PricingTier) error {
+	args := m.Called(ctx, tier)
+	return args.Error(0)
+}
+
+func (m *PricingRepositoryMock) GetTierByID(ctx context.Context, tierID uint) (*models.PricingTier, error) {
+	args := m.Called(ctx, tierID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PricingTier), args.Error(1)
+}
+
+func (m *PricingRepositoryMock) CountTierInventory(ctx context.Context, tierID uint) (int64, int64, error) {
+	args := m.Called(ctx, tierID)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *PricingRepositoryMock) ListPriceRecordsByBookingID(ctx context.Context, bookingID uint) ([]models.PriceRecord, error) {
+	args := m.Called(ctx, bookingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PriceRecord), args.Error(1)
+}
@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type SettingsRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *SettingsRepositoryMock) ListSettings(ctx context.Context) ([]models.RuntimeSetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RuntimeSetting), args.Error(1)
+}
@@ -2,6 +2,8 @@ package mocks
 
 import (
 	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"context"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,42 +11,68 @@ type TicketRepositoryMock struct {
 	mock.Mock
 }
 
-func (m *TicketRepositoryMock) CreateTicket(ticket *models.Ticket) error {
+func (m *TicketRepositoryMock) CreateTicket(ctx context.Context, ticket *models.Ticket) error {
 	panic("implement me")
 }
 
-func (m *TicketRepositoryMock) CreateTicketsBatch(tickets []models.Ticket) error {
-	args := m.Called(tickets)
+func (m *TicketRepositoryMock) CreateTicketsBatch(ctx context.Context, tickets []models.Ticket) error {
+	args := m.Called(ctx, tickets)
 	return args.Error(0)
 }
 
-func (m *TicketRepositoryMock) GetTicketByID(ticketID uint) (*models.Ticket, error) {
-	args := m.Called(ticketID)
+func (m *TicketRepositoryMock) GetTicketByID(ctx context.Context, ticketID uint) (*models.Ticket, error) {
+	args := m.Called(ctx, ticketID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Ticket), args.Error(1)
 }
 
-func (m *TicketRepositoryMock) ReserveTicket(ticketID, userID, bookingID uint) error {
-	args := m.Called(ticketID, userID, bookingID)
+func (m *TicketRepositoryMock) ReserveTicket(ctx context.Context, ticketID, userID, bookingID uint) error {
+	args := m.Called(ctx, ticketID, userID, bookingID)
 	return args.Error(0)
 }
 
-func (m *TicketRepositoryMock) UpdateTicketStatus(ticketID uint, status models.TicketStatus) error {
-	args := m.Called(ticketID, status)
+func (m *TicketRepositoryMock) UpdateTicketStatus(ctx context.Context, ticketID uint, status models.TicketStatus) error {
+	args := m.Called(ctx, ticketID, status)
 	return args.Error(0)
 }
 
-func (m *TicketRepositoryMock) ListAvailableTickets(eventID uint) ([]models.Ticket, error) {
-	args := m.Called(eventID)
+func (m *TicketRepositoryMock) ListAvailableTickets(ctx context.Context, eventID uint) ([]models.Ticket, error) {
+	args := m.Called(ctx, eventID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.Ticket), args.Error(1)
 }
 
-func (m *TicketRepositoryMock) DeleteTicket(ticketID uint) error {
-	args := m.Called(ticketID)
+func (m *TicketRepositoryMock) DeleteTicket(ctx context.Context, ticketID uint) error {
+	args := m.Called(ctx, ticketID)
 	return args.Error(0)
 }
+
+func (m *TicketRepositoryMock) CountAvailableByEvent(ctx context.Context) ([]repositories.EventAvailability, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]repositories.EventAvailability), args.Error(1)
+}
+
+func (m *TicketRepositoryMock) ListOrphanedTickets(ctx context.Context, eventID uint) ([]models.Ticket, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Ticket), args.Error(1)
+}
+
+func (m *TicketRepositoryMock) ReleaseOrphanedTickets(ctx context.Context, ticketIDs []uint) (int64, error) {
+	args := m.Called(ctx, ticketIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *TicketRepositoryMock) ReissueCredential(ctx context.Context, ticketID uint) (*models.Ticket, error) {
+	args := m.Called(ctx, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Ticket), args.Error(1)
+}
@@ -3,6 +3,8 @@ package mocks
 import (
 	"booking-service/internal/models"
 	kafkaModels "booking-service/pkg/kafka/models"
+	"booking-service/pkg/money"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -11,42 +13,42 @@ type TicketServiceMock struct {
 	mock.Mock
 }
 
-func (m *TicketServiceMock) CreateTicketsForEvent(eventID uint, numTickets int, price float64) ([]models.Ticket, error) {
-	args := m.Called(eventID, numTickets, price)
+func (m *TicketServiceMock) CreateTicketsForEvent(ctx context.Context, eventID uint, numTickets int, price money.Money) ([]models.Ticket, error) {
+	args := m.Called(ctx, eventID, numTickets, price)
 	return args.Get(0).([]models.Ticket), args.Error(1)
 }
 
-func (m *TicketServiceMock) ReserveTicket(ticketID, userID, bookingID uint) error {
-	args := m.Called(ticketID, userID, bookingID)
+func (m *TicketServiceMock) ReserveTicket(ctx context.Context, ticketID, userID, bookingID uint) error {
+	args := m.Called(ctx, ticketID, userID, bookingID)
 	return args.Error(0)
 }
 
-func (m *TicketServiceMock) ListAvailableTickets(eventID uint) ([]models.Ticket, error) {
-	args := m.Called(eventID)
+func (m *TicketServiceMock) ListAvailableTickets(ctx context.Context, eventID uint) ([]models.Ticket, error) {
+	args := m.Called(ctx, eventID)
 	return args.Get(0).([]models.Ticket), args.Error(1)
 }
 
-func (m *TicketServiceMock) ReleaseTicket(ticketID uint) error {
-	args := m.Called(ticketID)
+func (m *TicketServiceMock) ReleaseTicket(ctx context.Context, ticketID uint) error {
+	args := m.Called(ctx, ticketID)
 	return args.Error(0)
 }
 
-func (m *TicketServiceMock) MarkTicketAsSold(ticketID uint) error {
-	args := m.Called(ticketID)
+func (m *TicketServiceMock) MarkTicketAsSold(ctx context.Context, ticketID uint) error {
+	args := m.Called(ctx, ticketID)
 	return args.Error(0)
 }
 
-func (m *TicketServiceMock) HandleBookingEvent(eventType string, payload kafkaModels.BookingEvent) error {
-	args := m.Called(eventType, payload)
+func (m *TicketServiceMock) HandleBookingEvent(ctx context.Context, eventType string, payload kafkaModels.BookingEvent) error {
+	args := m.Called(ctx, eventType, payload)
 	return args.Error(0)
 }
 
-func (m *TicketServiceMock) GetTicketByID(ticketID uint) (*models.Ticket, error) {
-	args := m.Called(ticketID)
+func (m *TicketServiceMock) GetTicketByID(ctx context.Context, ticketID uint) (*models.Ticket, error) {
+	args := m.Called(ctx, ticketID)
 	return args.Get(0).(*models.Ticket), args.Error(1)
 }
 
-func (m *TicketServiceMock) UpdateTicketStatus(ticketID uint, status models.TicketStatus) error {
-	args := m.Called(ticketID, status)
+func (m *TicketServiceMock) UpdateTicketStatus(ctx context.Context, ticketID uint, status models.TicketStatus) error {
+	args := m.Called(ctx, ticketID, status)
 	return args.Error(0)
 }
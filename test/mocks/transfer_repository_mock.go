@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TransferRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TransferRepositoryMock) CreateTransfer(ctx context.Context, transfer *models.TicketTransfer) error {
+	args := m.Called(ctx, transfer)
+	return args.Error(0)
+}
+
+func (m *TransferRepositoryMock) GetTransferByID(ctx context.Context, id uint) (*models.TicketTransfer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TicketTransfer), args.Error(1)
+}
+
+func (m *TransferRepositoryMock) GetTransferByTokenHash(ctx context.Context, tokenHash string) (*models.TicketTransfer, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TicketTransfer), args.Error(1)
+}
+
+func (m *TransferRepositoryMock) ListTransfersByTicket(ctx context.Context, ticketID uint) ([]models.TicketTransfer, error) {
+	args := m.Called(ctx, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TicketTransfer), args.Error(1)
+}
+
+func (m *TransferRepositoryMock) SettleTransfer(ctx context.Context, id uint, status models.TicketTransferStatus) (bool, error) {
+	args := m.Called(ctx, id, status)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *TransferRepositoryMock) CompleteTransfer(ctx context.Context, transfer *models.TicketTransfer, toUserID uint) (*models.Ticket, error) {
+	args := m.Called(ctx, transfer, toUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Ticket), args.Error(1)
+}
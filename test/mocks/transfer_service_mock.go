@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TransferServiceMock struct {
+	mock.Mock
+}
+
+func (m *TransferServiceMock) InitiateTransfer(ctx context.Context, ticketID uint, toUserID *uint) (*models.TicketTransfer, string, error) {
+	args := m.Called(ctx, ticketID, toUserID)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*models.TicketTransfer), args.String(1), args.Error(2)
+}
+
+func (m *TransferServiceMock) AcceptTransfer(ctx context.Context, token string, userID uint) (*models.Ticket, error) {
+	args := m.Called(ctx, token, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Ticket), args.Error(1)
+}
+
+func (m *TransferServiceMock) CancelTransfer(ctx context.Context, transferID uint) error {
+	args := m.Called(ctx, transferID)
+	return args.Error(0)
+}
+
+func (m *TransferServiceMock) GetTransfer(ctx context.Context, transferID uint) (*models.TicketTransfer, error) {
+	args := m.Called(ctx, transferID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TicketTransfer), args.Error(1)
+}
+
+func (m *TransferServiceMock) ListTransfers(ctx context.Context, ticketID uint) ([]models.TicketTransfer, error) {
+	args := m.Called(ctx, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TicketTransfer), args.Error(1)
+}
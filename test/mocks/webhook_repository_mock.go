@@ -0,0 +1,73 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *WebhookRepositoryMock) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) GetSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookSubscription), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WebhookSubscription), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) DeleteSubscription(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) EnqueueDeliveries(ctx context.Context, eventType, payload string) (int, error) {
+	args := m.Called(ctx, eventType, payload)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	args := m.Called(ctx, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) ListDeliveries(ctx context.Context, subscriptionID uint, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, status, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) Redeliver(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookDelivery), args.Error(1)
+}
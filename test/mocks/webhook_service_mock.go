@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"booking-service/internal/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookServiceMock struct {
+	mock.Mock
+}
+
+func (m *WebhookServiceMock) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *WebhookServiceMock) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WebhookSubscription), args.Error(1)
+}
+
+func (m *WebhookServiceMock) DeleteSubscription(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *WebhookServiceMock) ListDeliveries(ctx context.Context, subscriptionID uint, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, status, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+func (m *WebhookServiceMock) Redeliver(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookDelivery), args.Error(1)
+}
@@ -0,0 +1,144 @@
+package admin_test
+
+import (
+	"booking-service/internal/admin"
+	"booking-service/internal/models"
+	"booking-service/internal/reconcile"
+	"booking-service/internal/services"
+	"booking-service/pkg/money"
+	"booking-service/test/mocks"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fixture struct {
+	bookings   *mocks.BookingServiceMock
+	tickets    *mocks.TicketServiceMock
+	events     *mocks.EventServiceMock
+	ticketRepo *mocks.TicketRepositoryMock
+	outboxRepo *mocks.OutboxRepositoryMock
+}
+
+func setupAdmin(dryRun bool) (*fixture, *admin.Admin) {
+	f := &fixture{
+		bookings:   new(mocks.BookingServiceMock),
+		tickets:    new(mocks.TicketServiceMock),
+		events:     new(mocks.EventServiceMock),
+		ticketRepo: new(mocks.TicketRepositoryMock),
+		outboxRepo: new(mocks.OutboxRepositoryMock),
+	}
+	pricingService := services.NewPricingService(new(mocks.PricingRepositoryMock), f.ticketRepo)
+	reconciler := reconcile.NewReconciler(new(mocks.BookingRepositoryMock), f.ticketRepo, reconcile.Config{BatchSize: 100})
+	return f, admin.New(f.bookings, f.tickets, f.events, pricingService, f.ticketRepo, f.outboxRepo, reconciler, dryRun)
+}
+
+func TestGenerateTickets_DryRunCreatesNothing(t *testing.T) {
+	f, a := setupAdmin(true)
+	price := money.Money{Amount: 1500, Currency: "USD"}
+	f.events.On("GetEventByID", mock.Anything, uint(1)).Return(&models.Event{ID: 1}, nil)
+
+	result, err := a.GenerateTickets(context.Background(), 1, 0, 3, price)
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 3, result.Count)
+	assert.Empty(t, result.Tickets)
+	f.tickets.AssertNotCalled(t, "CreateTicketsForEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenerateTickets_CreatesForEvent(t *testing.T) {
+	f, a := setupAdmin(false)
+	price := money.Money{Amount: 1500, Currency: "USD"}
+	created := []models.Ticket{{ID: 1}, {ID: 2}}
+	f.events.On("GetEventByID", mock.Anything, uint(1)).Return(&models.Event{ID: 1}, nil)
+	f.tickets.On("CreateTicketsForEvent", mock.Anything, uint(1), 2, price).Return(created, nil)
+
+	result, err := a.GenerateTickets(context.Background(), 1, 0, 2, price)
+
+	assert.NoError(t, err)
+	assert.Equal(t, created, result.Tickets)
+}
+
+func TestGenerateTickets_RejectsNonPositiveCount(t *testing.T) {
+	_, a := setupAdmin(false)
+
+	_, err := a.GenerateTickets(context.Background(), 1, 0, 0, money.Money{})
+
+	assert.Error(t, err)
+}
+
+func TestForceBookingStatus_Cancels(t *testing.T) {
+	f, a := setupAdmin(false)
+	f.bookings.On("GetBookingByID", mock.Anything, uint(5)).Return(&models.Booking{
+		ID:      5,
+		Status:  models.BookingStatusConfirmed,
+		Tickets: []models.Ticket{{ID: 8}, {ID: 9}},
+	}, nil)
+	f.bookings.On("CancelBooking", mock.Anything, uint(5)).Return(nil)
+
+	change, err := a.ForceBookingStatus(context.Background(), 5, models.BookingStatusCanceled)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BookingStatusConfirmed, change.From)
+	assert.Equal(t, models.BookingStatusCanceled, change.To)
+	assert.Equal(t, []uint{8, 9}, change.TicketIDs)
+	f.bookings.AssertExpectations(t)
+}
+
+func TestForceBookingStatus_DryRun(t *testing.T) {
+	f, a := setupAdmin(true)
+	f.bookings.On("GetBookingByID", mock.Anything, uint(5)).Return(&models.Booking{ID: 5, Status: models.BookingStatusPending}, nil)
+
+	change, err := a.ForceBookingStatus(context.Background(), 5, models.BookingStatusConfirmed)
+
+	assert.NoError(t, err)
+	assert.True(t, change.DryRun)
+	f.bookings.AssertNotCalled(t, "ConfirmBooking", mock.Anything, mock.Anything)
+}
+
+func TestForceBookingStatus_RejectsPending(t *testing.T) {
+	_, a := setupAdmin(false)
+
+	_, err := a.ForceBookingStatus(context.Background(), 5, models.BookingStatusPending)
+
+	assert.Error(t, err)
+}
+
+func TestReleaseOrphanedTickets(t *testing.T) {
+	f, a := setupAdmin(false)
+	f.ticketRepo.On("ListOrphanedTickets", mock.Anything, uint(0)).Return([]models.Ticket{{ID: 3}, {ID: 4}}, nil)
+	f.ticketRepo.On("ReleaseOrphanedTickets", mock.Anything, []uint{3, 4}).Return(int64(2), nil)
+
+	result, err := a.ReleaseOrphanedTickets(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Released)
+	f.ticketRepo.AssertExpectations(t)
+}
+
+func TestReplayOutbox_DefaultsToFailedMessages(t *testing.T) {
+	f, a := setupAdmin(false)
+	f.outboxRepo.On("List", mock.Anything, models.OutboxStatusFailed, []uint(nil), 50).Return([]models.OutboxMessage{{ID: 11}}, nil)
+	f.outboxRepo.On("Requeue", mock.Anything, []uint{11}).Return(int64(1), nil)
+
+	result, err := a.ReplayOutbox(context.Background(), nil, 50)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Requeued)
+	f.outboxRepo.AssertExpectations(t)
+}
+
+func TestReplayOutbox_DryRunWithIDs(t *testing.T) {
+	f, a := setupAdmin(true)
+	ids := []uint{11, 12}
+	f.outboxRepo.On("List", mock.Anything, models.OutboxStatus(""), ids, 2).Return([]models.OutboxMessage{{ID: 11}, {ID: 12}}, nil)
+
+	result, err := a.ReplayOutbox(context.Background(), ids, 50)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Messages, 2)
+	f.outboxRepo.AssertNotCalled(t, "Requeue", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,76 @@
+package apiversion_test
+
+import (
+	"booking-service/internal/apiversion"
+	"booking-service/internal/middlewares"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func respond(body string) gin.HandlerFunc {
+	return func(c *gin.Context) { c.String(http.StatusOK, body) }
+}
+
+func tag(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-Version", name)
+		c.Next()
+	}
+}
+
+func setupVersions() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	versions := apiversion.NewRouter(router.Group("/api"))
+	versions.Mount("v1", func(v1 *gin.RouterGroup) {
+		v1.GET("/bookings/:id", respond("v1 booking"))
+		v1.POST("/bookings", respond("v1 create"))
+		v1.GET("/legacy", respond("v1 legacy"))
+	}, tag("v1"))
+	versions.Mount("v2", func(v2 *gin.RouterGroup) {
+		v2.GET("/bookings/:id", respond("v2 booking"))
+		v2.GET("/legacy", apiversion.Removed)
+		v2.GET("/events", respond("v2 events"))
+	}, tag("v2"))
+	versions.Alias("", "v1", tag("alias"))
+	return router
+}
+
+func get(router *gin.Engine, method, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestMount_InheritsUnchangedRoutes(t *testing.T) {
+	router := setupVersions()
+
+	for target, want := range map[string]struct{ method, body, version string }{
+		"/api/v1/bookings/1": {http.MethodGet, "v1 booking", "v1"},
+		"/api/v2/bookings/1": {http.MethodGet, "v2 booking", "v2"},
+		"/api/v2/bookings":   {http.MethodPost, "v1 create", "v2"},
+		"/api/v2/events":     {http.MethodGet, "v2 events", "v2"},
+		"/api/bookings/1":    {http.MethodGet, "v1 booking", "alias"},
+	} {
+		w := get(router, want.method, target)
+		assert.Equal(t, http.StatusOK, w.Code, target)
+		assert.Equal(t, want.body, w.Body.String(), target)
+		assert.Equal(t, want.version, w.Header().Get("X-Version"), target)
+	}
+
+	assert.Equal(t, http.StatusNotFound, get(router, http.MethodGet, "/api/v1/events").Code)
+	assert.Equal(t, http.StatusGone, get(router, http.MethodGet, "/api/v2/legacy").Code)
+	assert.Equal(t, http.StatusOK, get(router, http.MethodGet, "/api/legacy").Code)
+}
+
+func TestAlias_PanicsForUnmountedVersion(t *testing.T) {
+	versions := apiversion.NewRouter(gin.New().Group("/api"))
+
+	assert.Panics(t, func() { versions.Alias("", "v1") })
+	assert.Empty(t, versions.Versions())
+}
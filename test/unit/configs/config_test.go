@@ -0,0 +1,219 @@
+package configs_test
+
+import (
+	"booking-service/configs"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const minimalConfig = `
+database:
+  host: "db"
+  password: "from-file"
+auth:
+  hmac_secret: "secret"
+credentials:
+  active_key: "k1"
+  keys: ["k1:a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s="]
+`
+
+func writeConfig(t *testing.T, content string) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0o600))
+	return dir
+}
+
+func env(vars map[string]string) configs.LookupEnv {
+	return func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+}
+
+func TestLoad_RepositoryConfig(t *testing.T) {
+	config, err := configs.Load("../../../configs", env(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, "db", config.Database.Host)
+	assert.Len(t, config.Tenants, 1)
+}
+
+func TestLoad_AppliesDefaults(t *testing.T) {
+	config, err := configs.Load(writeConfig(t, minimalConfig), env(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, "db", config.Database.Host)
+	assert.Equal(t, "5432", config.Database.Port)
+	assert.Equal(t, 15*time.Minute, config.Runtime.Settings.HoldTTL)
+	assert.Equal(t, 100, config.Kafka.Outbox.BatchSize)
+	assert.Equal(t, "default", config.Tenants[0].ID)
+}
+
+func TestLoad_EnvironmentOverridesFile(t *testing.T) {
+	config, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{
+		"DB_HOST":                 "postgres.internal",
+		"KAFKA_BROKER":            "kafka-1:9092",
+		"KAFKA_OUTBOX_BATCH_SIZE": "25",
+		"RUNTIME_HOLD_TTL":        "2m",
+		"RECONCILER_REPAIR":       "true",
+		"LOG_REDACT_KEYS":         "email,phone",
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "postgres.internal", config.Database.Host)
+	assert.Equal(t, "kafka-1:9092", config.Kafka.Broker)
+	assert.Equal(t, 25, config.Kafka.Outbox.BatchSize)
+	assert.Equal(t, 2*time.Minute, config.Runtime.Settings.HoldTTL)
+	assert.True(t, config.Reconciler.Repair)
+	assert.Equal(t, []string{"email", "phone"}, config.Log.RedactKeys)
+}
+
+func TestLoad_ReadsSecretFiles(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cr3t\n"), 0o600))
+
+	config, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{"DB_PASSWORD_FILE": secret}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", config.Database.Password)
+}
+
+func TestLoad_RejectsVariableAndFile(t *testing.T) {
+	_, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{
+		"DB_PASSWORD":      "a",
+		"DB_PASSWORD_FILE": "/run/secrets/db_password",
+	}))
+
+	assert.ErrorContains(t, err, "both DB_PASSWORD and DB_PASSWORD_FILE are set")
+}
+
+func TestLoad_MissingSecretFile(t *testing.T) {
+	_, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{"AUTH_HMAC_SECRET_FILE": "/does/not/exist"}))
+
+	assert.ErrorContains(t, err, "AUTH_HMAC_SECRET_FILE")
+}
+
+func TestLoad_ListsEveryProblem(t *testing.T) {
+	_, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{
+		"APP_PORT":           "http",
+		"LOG_FORMAT":         "xml",
+		"TRACING_EXPORTER":   "otlp",
+		"TRACING_ENDPOINT":   "",
+		"AUTH_HMAC_SECRET":   "",
+		"RECONCILER_MIN_AGE": "-1m",
+	}))
+
+	var validationErr *configs.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ElementsMatch(t, []string{
+		`app.port: must be a port number, got "http"`,
+		`log.format: must be one of json, text, got "xml"`,
+		"tracing.endpoint: is required",
+		"auth.hmac_secret: is required",
+		"reconciler.min_age: cannot be negative, got -1m0s",
+	}, validationErr.Problems)
+}
+
+func TestValidate_RejectsExampleSecretInProduction(t *testing.T) {
+	config := configs.Defaults()
+	config.App.Env = "production"
+	config.Auth.HMACSecret = "change-me-in-production"
+
+	assert.ErrorContains(t, config.Validate(), "auth.hmac_secret")
+}
+
+func TestLoad_RotatesCredentialKeys(t *testing.T) {
+	config, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{
+		"CREDENTIALS_ACTIVE_KEY": "k2",
+		"CREDENTIALS_KEYS":       "k1:a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s=,k2:bm5ubm5ubm5ubm5ubm5ubm5ubm5ubm5ubm5ubm5ubm4=",
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "k2", config.Credentials.ActiveKey)
+	assert.Len(t, config.Credentials.Keys, 2)
+}
+
+func TestLoad_RejectsUnknownActiveCredentialKey(t *testing.T) {
+	_, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{"CREDENTIALS_ACTIVE_KEY": "k2"}))
+
+	var validationErr *configs.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{`credentials.keys: active key "k2" is not among the keys`}, validationErr.Problems)
+}
+
+func TestLoad_ParsesTrustedProxies(t *testing.T) {
+	config, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{
+		"APP_TRUSTED_PROXIES": "10.0.0.1,172.16.0.0/12",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "172.16.0.0/12"}, config.App.TrustedProxies)
+
+	_, err = configs.Load(writeConfig(t, minimalConfig), env(map[string]string{"APP_TRUSTED_PROXIES": "proxy.internal"}))
+	var validationErr *configs.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{`app.trusted_proxies: must be IPs or CIDRs, got "proxy.internal"`}, validationErr.Problems)
+}
+
+func TestValidate_RejectsExampleCredentialKeyInProduction(t *testing.T) {
+	config, err := configs.Load("../../../configs", env(nil))
+	require.NoError(t, err)
+	config.App.Env = "production"
+	config.Auth.HMACSecret = "not-the-example"
+
+	assert.Equal(t, &configs.ValidationError{Problems: []string{
+		"credentials.keys: must be changed from the example value in production",
+	}}, config.Validate())
+}
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	config, err := configs.Load(writeConfig(t, minimalConfig), env(nil))
+	require.NoError(t, err)
+
+	var redacted, plain bytes.Buffer
+	require.NoError(t, config.Print(&redacted, true))
+	require.NoError(t, config.Print(&plain, false))
+
+	assert.NotContains(t, redacted.String(), "from-file")
+	assert.Contains(t, redacted.String(), "password: '[REDACTED]'")
+	assert.Contains(t, redacted.String(), "hold_ttl: 15m0s")
+	assert.Contains(t, plain.String(), "password: from-file")
+	assert.NotContains(t, redacted.String(), "a2tra2tr")
+}
+
+func TestLoad_ParsesLegacyRouteTimes(t *testing.T) {
+	config, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{
+		"API_LEGACY_ROUTES":        "true",
+		"API_LEGACY_DEPRECATED_AT": "2026-10-19T00:00:00Z",
+		"API_LEGACY_SUNSET":        "2027-04-19T00:00:00+07:00",
+	}))
+
+	require.NoError(t, err)
+	assert.True(t, config.API.LegacyRoutes)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), config.API.LegacyDeprecatedAt.UTC())
+	assert.Equal(t, time.Date(2027, 4, 18, 17, 0, 0, 0, time.UTC), config.API.LegacySunset.UTC())
+
+	var out bytes.Buffer
+	require.NoError(t, config.Print(&out, false))
+	assert.Contains(t, out.String(), `legacy_deprecated_at: "2026-10-19T00:00:00Z"`)
+}
+
+func TestLoad_RejectsLegacySunsetBeforeDeprecation(t *testing.T) {
+	_, err := configs.Load(writeConfig(t, minimalConfig), env(map[string]string{
+		"API_LEGACY_ROUTES":        "true",
+		"API_LEGACY_DEPRECATED_AT": "2026-10-19T00:00:00Z",
+		"API_LEGACY_SUNSET":        "2026-01-01T00:00:00Z",
+	}))
+
+	var validationErr *configs.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"api.legacy_sunset: must be after api.legacy_deprecated_at"}, validationErr.Problems)
+
+	_, err = configs.Load(writeConfig(t, minimalConfig), env(map[string]string{"API_LEGACY_SUNSET": "next spring"}))
+	assert.ErrorContains(t, err, "failed to decode config")
+}
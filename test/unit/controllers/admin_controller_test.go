@@ -0,0 +1,147 @@
+package controllers_test
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/controllers"
+	"booking-service/internal/middlewares"
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/money"
+	"booking-service/pkg/pagination"
+	"booking-service/test/mocks"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAdminRouterAs(controller controllers.AdminController, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorHandler(true))
+	apiRoutes := router.Group("/api",
+		authenticatedAs("1", role),
+		func(c *gin.Context) {
+			tenant := tenancy.Tenant{ID: "tenant-a", Currency: "EUR"}
+			c.Request = c.Request.WithContext(tenancy.WithTenant(c.Request.Context(), tenant))
+		},
+		middlewares.Authorize(authz.DefaultPolicy().Mount("/api")),
+	)
+	controllers.RegisterAdminRoutes(apiRoutes, controller)
+	return router
+}
+
+func TestSearchBookings_ParsesCriteria(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	router := setupAdminRouterAs(controllers.NewAdminController(mockBookingService), "support")
+
+	minTotal, maxTotal := int64(1050), int64(20000)
+	expected := repositories.BookingCriteria{
+		BookingID:     3,
+		UserID:        5,
+		EventID:       4,
+		TicketID:      9,
+		Statuses:      []models.BookingStatus{models.BookingStatusPending, models.BookingStatusConfirmed},
+		CreatedFrom:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		TotalCurrency: "EUR",
+		MinTotal:      &minTotal,
+		MaxTotal:      &maxTotal,
+		Descending:    true,
+		Limit:         5,
+	}
+	mockBookingService.On("SearchBookings", mock.Anything, expected).Return(&pagination.Page[models.Booking]{
+		Data:  []models.Booking{{ID: 3, UserID: 5}},
+		Total: 1,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/bookings?booking_id=3&user_id=5&event_id=4&ticket_id=9&status=pending,CONFIRMED&from=2026-01-01T00:00:00Z&min_total=10.50&max_total=200&limit=5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, float64(1), body["total"])
+	assert.Len(t, body["data"], 1)
+	mockBookingService.AssertExpectations(t)
+}
+
+func TestSearchBookings_InvalidCriteria(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	router := setupAdminRouterAs(controllers.NewAdminController(mockBookingService), "admin")
+
+	for _, query := range []string{"ticket_id=abc", "user_id=0", "min_total=1.005", "currency=XXX", "sort=total"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/bookings?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockBookingService.AssertNotCalled(t, "SearchBookings", mock.Anything, mock.Anything)
+}
+
+func TestSearchBookings_CustomerForbidden(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	router := setupAdminRouterAs(controllers.NewAdminController(mockBookingService), "customer")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/bookings", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockBookingService.AssertNotCalled(t, "SearchBookings", mock.Anything, mock.Anything)
+}
+
+func TestSearchBookings_ExportsCSVAcrossPages(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	router := setupAdminRouterAs(controllers.NewAdminController(mockBookingService), "support")
+
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cursor := pagination.Cursor{CreatedAt: createdAt, ID: 2}
+	first := repositories.BookingCriteria{Descending: true, Limit: pagination.MaxLimit}
+	next := first
+	next.After = &cursor
+	mockBookingService.On("SearchBookings", mock.Anything, first).Return(&pagination.Page[models.Booking]{
+		Data: []models.Booking{{
+			ID:          2,
+			UserID:      5,
+			EventID:     4,
+			Status:      models.BookingStatusConfirmed,
+			TotalAmount: money.Money{Amount: 10250, Currency: "EUR"},
+			FeeAmount:   250,
+			Tickets:     []models.Ticket{{ID: 7}, {ID: 8}},
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		}},
+		NextCursor: cursor.Encode(),
+		Total:      2,
+	}, nil)
+	mockBookingService.On("SearchBookings", mock.Anything, next).Return(&pagination.Page[models.Booking]{
+		Data:  []models.Booking{{ID: 1, UserID: 6, EventID: 4, Status: models.BookingStatusPending, TotalAmount: money.Money{Currency: "EUR"}, CreatedAt: createdAt, UpdatedAt: createdAt}},
+		Total: 2,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/bookings", nil)
+	req.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+	records, err := csv.NewReader(w.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "user_id", "event_id", "status", "total", "currency", "fee", "ticket_ids", "created_at", "updated_at"},
+		{"2", "5", "4", "CONFIRMED", "102.50", "EUR", "2.50", "7 8", "2026-03-01T12:00:00Z", "2026-03-01T12:00:00Z"},
+		{"1", "6", "4", "PENDING", "0.00", "EUR", "0.00", "", "2026-03-01T12:00:00Z", "2026-03-01T12:00:00Z"},
+	}, records)
+	mockBookingService.AssertExpectations(t)
+}
@@ -1,23 +1,48 @@
 package controllers_test
 
 import (
+	"booking-service/internal/authz"
 	"booking-service/internal/controllers"
+	"booking-service/internal/middlewares"
 	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/pkg/auth"
+	"booking-service/pkg/money"
+	"booking-service/pkg/pagination"
 	"booking-service/test/mocks"
 	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+// authenticatedAs stands in for middlewares.Auth with already verified claims.
+func authenticatedAs(userID, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		middlewares.SetClaims(c, &auth.Claims{
+			Role:             role,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: userID},
+		})
+		c.Next()
+	}
+}
+
 func setupRouter(controller controllers.BookingController) *gin.Engine {
+	return setupRouterAs(controller, "1", "customer")
+}
+
+func setupRouterAs(controller controllers.BookingController, userID, role string) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
-	apiRoutes := router.Group("/api")
+	router.Use(middlewares.ErrorHandler(true))
+	apiRoutes := router.Group("/api", authenticatedAs(userID, role), middlewares.Authorize(authz.DefaultPolicy().Mount("/api")))
 	controllers.RegisterBookingRoutes(apiRoutes, controller)
 	return router
 }
@@ -28,7 +53,7 @@ func TestCreateBooking_Success(t *testing.T) {
 	router := setupRouter(bookingController)
 
 	bookingRequest := map[string]interface{}{
-		"user_id":    uint(1),
+		"user_id":    uint(99), // ignored in favour of the token subject
 		"event_id":   uint(1),
 		"ticket_ids": []uint{1, 2},
 	}
@@ -37,11 +62,11 @@ func TestCreateBooking_Success(t *testing.T) {
 		ID:          123,
 		UserID:      1,
 		EventID:     1,
-		TotalAmount: 200.0,
+		TotalAmount: money.Money{Amount: 20000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 	}
 
-	mockBookingService.On("CreateBooking", uint(1), uint(1), []uint{1, 2}).Return(mockBooking, nil)
+	mockBookingService.On("CreateBooking", mock.Anything, uint(1), uint(1), []uint{1, 2}).Return(mockBooking, nil)
 
 	requestBody, _ := json.Marshal(bookingRequest)
 	req := httptest.NewRequest(http.MethodPost, "/api/bookings", bytes.NewBuffer(requestBody))
@@ -68,7 +93,6 @@ func TestCreateBooking_ValidationError(t *testing.T) {
 	router := setupRouter(bookingController)
 
 	invalidRequest := map[string]interface{}{
-		"event_id":   uint(1),
 		"ticket_ids": []uint{1, 2},
 	}
 
@@ -84,7 +108,8 @@ func TestCreateBooking_ValidationError(t *testing.T) {
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "Invalid request payload", response["error"])
+	assert.Equal(t, "Invalid request payload", response["title"])
+	assert.Equal(t, "invalid_request_payload", response["code"])
 }
 
 func TestGetBookingByID_Success(t *testing.T) {
@@ -96,11 +121,11 @@ func TestGetBookingByID_Success(t *testing.T) {
 		ID:          123,
 		UserID:      1,
 		EventID:     1,
-		TotalAmount: 200.0,
+		TotalAmount: money.Money{Amount: 20000, Currency: "USD"},
 		Status:      models.BookingStatusConfirmed,
 	}
 
-	mockBookingService.On("GetBookingByID", uint(123)).Return(mockBooking, nil)
+	mockBookingService.On("GetBookingByID", mock.Anything, uint(123)).Return(mockBooking, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/bookings/123", nil)
 	w := httptest.NewRecorder()
@@ -123,7 +148,7 @@ func TestGetBookingByID_NotFound(t *testing.T) {
 	bookingController := controllers.NewBookingController(mockBookingService)
 	router := setupRouter(bookingController)
 
-	mockBookingService.On("GetBookingByID", uint(123)).Return(nil, nil)
+	mockBookingService.On("GetBookingByID", mock.Anything, uint(123)).Return(nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/bookings/123", nil)
 	w := httptest.NewRecorder()
@@ -134,7 +159,8 @@ func TestGetBookingByID_NotFound(t *testing.T) {
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "Booking not found", response["error"])
+	assert.Equal(t, "Booking not found", response["title"])
+	assert.Equal(t, "booking_not_found", response["code"])
 }
 
 func TestCancelBooking_Success(t *testing.T) {
@@ -142,7 +168,8 @@ func TestCancelBooking_Success(t *testing.T) {
 	bookingController := controllers.NewBookingController(mockBookingService)
 	router := setupRouter(bookingController)
 
-	mockBookingService.On("CancelBooking", uint(123)).Return(nil)
+	mockBookingService.On("GetBookingByID", mock.Anything, uint(123)).Return(&models.Booking{ID: 123, UserID: 1}, nil)
+	mockBookingService.On("CancelBooking", mock.Anything, uint(123)).Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/api/bookings/123", nil)
 	w := httptest.NewRecorder()
@@ -161,7 +188,8 @@ func TestCancelBooking_Error(t *testing.T) {
 	bookingController := controllers.NewBookingController(mockBookingService)
 	router := setupRouter(bookingController)
 
-	mockBookingService.On("CancelBooking", uint(123)).Return(assert.AnError)
+	mockBookingService.On("GetBookingByID", mock.Anything, uint(123)).Return(&models.Booking{ID: 123, UserID: 1}, nil)
+	mockBookingService.On("CancelBooking", mock.Anything, uint(123)).Return(assert.AnError)
 
 	req := httptest.NewRequest(http.MethodDelete, "/api/bookings/123", nil)
 	w := httptest.NewRecorder()
@@ -172,5 +200,123 @@ func TestCancelBooking_Error(t *testing.T) {
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "Failed to cancel booking", response["error"])
+	assert.Equal(t, "Internal server error", response["title"])
+	assert.Equal(t, assert.AnError.Error(), response["detail"])
+}
+
+func TestGetBookingByID_OtherCustomerForbidden(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	bookingController := controllers.NewBookingController(mockBookingService)
+	router := setupRouterAs(bookingController, "2", "customer")
+
+	mockBookingService.On("GetBookingByID", mock.Anything, uint(123)).Return(&models.Booking{ID: 123, UserID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/bookings/123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGetBookingByID_SupportCanReadAnyBooking(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	bookingController := controllers.NewBookingController(mockBookingService)
+	router := setupRouterAs(bookingController, "50", "support")
+
+	mockBookingService.On("GetBookingByID", mock.Anything, uint(123)).Return(&models.Booking{ID: 123, UserID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/bookings/123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCancelBooking_OtherCustomerForbidden(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	bookingController := controllers.NewBookingController(mockBookingService)
+	router := setupRouterAs(bookingController, "2", "customer")
+
+	mockBookingService.On("GetBookingByID", mock.Anything, uint(123)).Return(&models.Booking{ID: 123, UserID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/bookings/123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockBookingService.AssertNotCalled(t, "CancelBooking", mock.Anything, uint(123))
+}
+
+func TestListBookingsByUserID_OtherCustomerForbidden(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	bookingController := controllers.NewBookingController(mockBookingService)
+	router := setupRouterAs(bookingController, "2", "customer")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/bookings/user/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestListBookingsByUserID_ParsesQuery(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	bookingController := controllers.NewBookingController(mockBookingService)
+	router := setupRouter(bookingController)
+
+	cursor := pagination.Cursor{CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), ID: 7}
+	expected := repositories.BookingQuery{
+		Status:      models.BookingStatusConfirmed,
+		EventID:     4,
+		CreatedFrom: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Descending:  false,
+		After:       &cursor,
+		Limit:       5,
+	}
+	mockBookingService.On("ListBookingsByUserID", mock.Anything, uint(1), expected).Return(&pagination.Page[models.Booking]{
+		Data:       []models.Booking{{ID: 8, UserID: 1}},
+		NextCursor: "next",
+		Total:      9,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/bookings/user/1?status=CONFIRMED&event_id=4&from=2026-01-01T00:00:00Z&sort=created_at&limit=5&cursor="+cursor.Encode(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "next", body["next_cursor"])
+	assert.Equal(t, float64(9), body["total"])
+	assert.Len(t, body["data"], 1)
+	mockBookingService.AssertExpectations(t)
+}
+
+func TestListBookingsByUserID_InvalidQuery(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	bookingController := controllers.NewBookingController(mockBookingService)
+	router := setupRouter(bookingController)
+
+	for _, query := range []string{"cursor=not-a-cursor", "limit=0", "sort=total", "from=yesterday", "event_id=-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/bookings/user/1?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockBookingService.AssertNotCalled(t, "ListBookingsByUserID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateBookingStatus_CustomerForbidden(t *testing.T) {
+	mockBookingService := new(mocks.BookingServiceMock)
+	bookingController := controllers.NewBookingController(mockBookingService)
+	router := setupRouter(bookingController)
+
+	requestBody, _ := json.Marshal(map[string]string{"status": "CONFIRMED"})
+	req := httptest.NewRequest(http.MethodPut, "/api/bookings/123/status", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
 }
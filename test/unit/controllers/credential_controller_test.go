@@ -0,0 +1,164 @@
+package controllers_test
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/controllers"
+	"booking-service/internal/middlewares"
+	"booking-service/internal/models"
+	"booking-service/test/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupCredentialRouterAs(controller controllers.CredentialController, userID, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	apiRoutes := router.Group("/api/v1", authenticatedAs(userID, role), middlewares.Authorize(authz.DefaultPolicy().Mount("/api/v1")))
+	controllers.RegisterCredentialRoutes(apiRoutes, controller)
+	return router
+}
+
+func setupCredentialMocks(owner uint) (*mocks.CredentialServiceMock, *mocks.TicketServiceMock) {
+	mockCredentialService := new(mocks.CredentialServiceMock)
+	mockTicketService := new(mocks.TicketServiceMock)
+	mockTicketService.On("GetTicketByID", mock.Anything, uint(7)).Return(&models.Ticket{ID: 7, UserID: &owner, Status: models.TicketStatusSold}, nil)
+	return mockCredentialService, mockTicketService
+}
+
+func TestTicketQR_OwnerGetsPNG(t *testing.T) {
+	mockCredentialService, mockTicketService := setupCredentialMocks(1)
+	router := setupCredentialRouterAs(controllers.NewCredentialController(mockCredentialService, mockTicketService, nil), "1", "customer")
+	mockCredentialService.On("IssueCredential", mock.Anything, uint(7)).Return("signed-credential", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/tickets/7/qr?size=128", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "\x89PNG"))
+}
+
+func TestTicketQR_SVG(t *testing.T) {
+	mockCredentialService, mockTicketService := setupCredentialMocks(1)
+	router := setupCredentialRouterAs(controllers.NewCredentialController(mockCredentialService, mockTicketService, nil), "1", "customer")
+	mockCredentialService.On("IssueCredential", mock.Anything, uint(7)).Return("signed-credential", nil)
+
+	for _, request := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/tickets/7/qr?format=svg", nil),
+		func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/tickets/7/qr", nil)
+			r.Header.Set("Accept", "image/svg+xml")
+			return r
+		}(),
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(w.Body.String(), "<svg"))
+	}
+}
+
+func TestTicketQR_ForbiddenForOtherCustomer(t *testing.T) {
+	mockCredentialService, mockTicketService := setupCredentialMocks(1)
+	router := setupCredentialRouterAs(controllers.NewCredentialController(mockCredentialService, mockTicketService, nil), "2", "customer")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/tickets/7/qr", nil))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockCredentialService.AssertNotCalled(t, "IssueCredential", mock.Anything, mock.Anything)
+}
+
+func TestTicketQR_ForbiddenForOrganizer(t *testing.T) {
+	mockCredentialService, mockTicketService := setupCredentialMocks(1)
+	router := setupCredentialRouterAs(controllers.NewCredentialController(mockCredentialService, mockTicketService, nil), "1", "organizer")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/tickets/7/qr", nil))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTicketQR_RejectsInvalidSize(t *testing.T) {
+	mockCredentialService, mockTicketService := setupCredentialMocks(1)
+	router := setupCredentialRouterAs(controllers.NewCredentialController(mockCredentialService, mockTicketService, nil), "1", "customer")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/tickets/7/qr?size=4096", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockCredentialService.AssertNotCalled(t, "IssueCredential", mock.Anything, mock.Anything)
+}
+
+func TestReissueCredential_ReturnsNewCredential(t *testing.T) {
+	mockCredentialService, mockTicketService := setupCredentialMocks(1)
+	router := setupCredentialRouterAs(controllers.NewCredentialController(mockCredentialService, mockTicketService, nil), "1", "customer")
+	mockCredentialService.On("ReissueCredential", mock.Anything, uint(7)).Return("new-credential", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/credentials/reissue", strings.NewReader(`{"ticket_id": 7}`)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"credential": "new-credential"}`, w.Body.String())
+	mockCredentialService.AssertExpectations(t)
+}
+
+func TestVerifyCredential_OrganizerScansOwnEvent(t *testing.T) {
+	mockCredentialService := new(mocks.CredentialServiceMock)
+	mockEventService := new(mocks.EventServiceMock)
+	organizerID, otherOrganizerID := uint(1), uint(2)
+	mockEventService.On("GetEventByID", mock.Anything, uint(3)).Return(&models.Event{ID: 3, OrganizerID: &organizerID}, nil)
+	mockEventService.On("GetEventByID", mock.Anything, uint(4)).Return(&models.Event{ID: 4, OrganizerID: &otherOrganizerID}, nil)
+	owner := uint(9)
+	mockCredentialService.On("VerifyCredential", mock.Anything, "signed-credential").Return(&models.Ticket{
+		ID: 7, EventID: 3, UserID: &owner, Status: models.TicketStatusSold, CredentialVersion: 2,
+	}, nil)
+	router := setupCredentialRouterAs(controllers.NewCredentialController(mockCredentialService, nil, mockEventService), "1", "organizer")
+
+	verify := func(eventID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/credentials/verify",
+			strings.NewReader(`{"credential": "signed-credential", "event_id": `+eventID+`}`)))
+		return w
+	}
+
+	w := verify("3")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"ticket_id": 7, "event_id": 3, "user_id": 9, "credential_version": 2}`, w.Body.String())
+
+	assert.Equal(t, http.StatusForbidden, verify("4").Code)
+}
+
+func TestVerifyCredential_RejectsTicketOfAnotherEvent(t *testing.T) {
+	mockCredentialService := new(mocks.CredentialServiceMock)
+	mockEventService := new(mocks.EventServiceMock)
+	mockEventService.On("GetEventByID", mock.Anything, uint(4)).Return(&models.Event{ID: 4}, nil)
+	mockCredentialService.On("VerifyCredential", mock.Anything, "signed-credential").Return(&models.Ticket{ID: 7, EventID: 3, Status: models.TicketStatusSold}, nil)
+	router := setupCredentialRouterAs(controllers.NewCredentialController(mockCredentialService, nil, mockEventService), "1", "support")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/credentials/verify",
+		strings.NewReader(`{"credential": "signed-credential", "event_id": 4}`)))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestVerifyCredential_CustomersCannotScan(t *testing.T) {
+	router := setupCredentialRouterAs(controllers.NewCredentialController(nil, nil, nil), "1", "customer")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/credentials/verify",
+		strings.NewReader(`{"credential": "signed-credential", "event_id": 3}`)))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
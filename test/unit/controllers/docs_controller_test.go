@@ -0,0 +1,47 @@
+package controllers_test
+
+import (
+	"booking-service/internal/controllers"
+	"booking-service/internal/openapi"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDocsRouter(t *testing.T) *gin.Engine {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	controllers.NewDocsController(doc).RegisterDocsRoutes(router)
+	return router
+}
+
+func TestDocs_ServesSpecAsJSON(t *testing.T) {
+	router := setupDocsRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "3.0.3", body["openapi"])
+	assert.Contains(t, body["paths"], "/bookings/{id}")
+}
+
+func TestDocs_ServesSwaggerUI(t *testing.T) {
+	router := setupDocsRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), `url: "/openapi.json"`)
+}
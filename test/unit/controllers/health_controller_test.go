@@ -0,0 +1,70 @@
+package controllers_test
+
+import (
+	"booking-service/internal/controllers"
+	"booking-service/internal/health"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupHealthRouter(check func(ctx context.Context) error, ready bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registry := health.NewRegistry(time.Second, 0)
+	registry.Register(health.CheckerFunc("postgres", check))
+	controllers.NewHealthController(registry, func() bool { return ready }).RegisterHealthRoutes(router)
+	return router
+}
+
+func TestHealthLive(t *testing.T) {
+	router := setupHealthRouter(func(ctx context.Context) error { return errors.New("down") }, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHealthReady_Up(t *testing.T) {
+	router := setupHealthRouter(func(ctx context.Context) error { return nil }, true)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Status string                   `json:"status"`
+		Checks map[string]health.Result `json:"checks"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, health.StatusUp, body.Status)
+	assert.Equal(t, health.StatusUp, body.Checks["postgres"].Status)
+}
+
+func TestHealthReady_DependencyDown(t *testing.T) {
+	router := setupHealthRouter(func(ctx context.Context) error { return errors.New("connection refused") }, true)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "connection refused")
+}
+
+func TestHealthReady_Draining(t *testing.T) {
+	router := setupHealthRouter(func(ctx context.Context) error { return nil }, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"draining":true`)
+}
@@ -0,0 +1,82 @@
+package controllers_test
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/controllers"
+	"booking-service/internal/middlewares"
+	"booking-service/internal/models"
+	"booking-service/pkg/money"
+	"booking-service/test/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTicketRouter(controller controllers.TicketController, userID, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorHandler(true))
+	apiRoutes := router.Group("/api", authenticatedAs(userID, role), middlewares.Authorize(authz.DefaultPolicy().Mount("/api")))
+	controllers.RegisterTicketRoutes(apiRoutes, controller)
+	return router
+}
+
+func createTicketsRequest() *http.Request {
+	requestBody, _ := json.Marshal(map[string]interface{}{
+		"num_tickets": 2,
+		"price":       money.Money{Amount: 500000, Currency: "VND"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/tickets/1", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestCreateTicketsForEvent_OrganizerOwnsEvent(t *testing.T) {
+	mockTicketService := new(mocks.TicketServiceMock)
+	mockEventService := new(mocks.EventServiceMock)
+	router := setupTicketRouter(controllers.NewTicketController(mockTicketService, mockEventService), "10", "organizer")
+
+	organizerID := uint(10)
+	price := money.Money{Amount: 500000, Currency: "VND"}
+	mockEventService.On("GetEventByID", mock.Anything, uint(1)).Return(&models.Event{ID: 1, OrganizerID: &organizerID}, nil)
+	mockTicketService.On("CreateTicketsForEvent", mock.Anything, uint(1), 2, price).Return([]models.Ticket{{ID: 1}, {ID: 2}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTicketsRequest())
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockTicketService.AssertExpectations(t)
+}
+
+func TestCreateTicketsForEvent_OtherOrganizerForbidden(t *testing.T) {
+	mockTicketService := new(mocks.TicketServiceMock)
+	mockEventService := new(mocks.EventServiceMock)
+	router := setupTicketRouter(controllers.NewTicketController(mockTicketService, mockEventService), "11", "organizer")
+
+	organizerID := uint(10)
+	mockEventService.On("GetEventByID", mock.Anything, uint(1)).Return(&models.Event{ID: 1, OrganizerID: &organizerID}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTicketsRequest())
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockTicketService.AssertNotCalled(t, "CreateTicketsForEvent", mock.Anything)
+}
+
+func TestCreateTicketsForEvent_CustomerForbidden(t *testing.T) {
+	mockTicketService := new(mocks.TicketServiceMock)
+	mockEventService := new(mocks.EventServiceMock)
+	router := setupTicketRouter(controllers.NewTicketController(mockTicketService, mockEventService), "1", "customer")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTicketsRequest())
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockEventService.AssertNotCalled(t, "GetEventByID", mock.Anything)
+}
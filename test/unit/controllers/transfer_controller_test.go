@@ -0,0 +1,115 @@
+package controllers_test
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/controllers"
+	"booking-service/internal/middlewares"
+	"booking-service/internal/models"
+	"booking-service/test/mocks"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTransferRouterAs(controller controllers.TransferController, userID, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	apiRoutes := router.Group("/api/v1", authenticatedAs(userID, role), middlewares.Authorize(authz.DefaultPolicy().Mount("/api/v1")))
+	controllers.RegisterTransferRoutes(apiRoutes, controller)
+	return router
+}
+
+func TestInitiateTransfer_OwnerGetsToken(t *testing.T) {
+	mockTransferService := new(mocks.TransferServiceMock)
+	mockTicketService := new(mocks.TicketServiceMock)
+	router := setupTransferRouterAs(controllers.NewTransferController(mockTransferService, mockTicketService), "1", "customer")
+
+	owner := uint(1)
+	mockTicketService.On("GetTicketByID", mock.Anything, uint(7)).Return(&models.Ticket{ID: 7, UserID: &owner}, nil)
+	mockTransferService.On("InitiateTransfer", mock.Anything, uint(7), (*uint)(nil)).
+		Return(&models.TicketTransfer{ID: 5, TicketID: 7, FromUserID: 1, TokenHash: "hash"}, "secret-token", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/transfers", strings.NewReader(`{"ticket_id": 7}`)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "secret-token", body["token"])
+	assert.NotContains(t, w.Body.String(), "hash")
+	mockTransferService.AssertExpectations(t)
+}
+
+func TestInitiateTransfer_ForbiddenForOtherCustomer(t *testing.T) {
+	mockTransferService := new(mocks.TransferServiceMock)
+	mockTicketService := new(mocks.TicketServiceMock)
+	router := setupTransferRouterAs(controllers.NewTransferController(mockTransferService, mockTicketService), "2", "customer")
+
+	owner := uint(1)
+	mockTicketService.On("GetTicketByID", mock.Anything, uint(7)).Return(&models.Ticket{ID: 7, UserID: &owner}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/transfers", strings.NewReader(`{"ticket_id": 7}`)))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockTransferService.AssertNotCalled(t, "InitiateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAcceptTransfer_AcceptsForCaller(t *testing.T) {
+	mockTransferService := new(mocks.TransferServiceMock)
+	router := setupTransferRouterAs(controllers.NewTransferController(mockTransferService, nil), "2", "customer")
+
+	recipient := uint(2)
+	mockTransferService.On("AcceptTransfer", mock.Anything, "secret-token", uint(2)).
+		Return(&models.Ticket{ID: 7, UserID: &recipient, CredentialVersion: 2}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/transfers/accept", strings.NewReader(`{"token": "secret-token"}`)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"credential_version":2`)
+	mockTransferService.AssertExpectations(t)
+}
+
+func TestCancelTransfer_OnlySender(t *testing.T) {
+	mockTransferService := new(mocks.TransferServiceMock)
+	mockTransferService.On("GetTransfer", mock.Anything, uint(5)).Return(&models.TicketTransfer{ID: 5, FromUserID: 1}, nil)
+	mockTransferService.On("CancelTransfer", mock.Anything, uint(5)).Return(nil)
+
+	w := httptest.NewRecorder()
+	router := setupTransferRouterAs(controllers.NewTransferController(mockTransferService, nil), "2", "customer")
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/transfers/5", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockTransferService.AssertNotCalled(t, "CancelTransfer", mock.Anything, mock.Anything)
+
+	w = httptest.NewRecorder()
+	router = setupTransferRouterAs(controllers.NewTransferController(mockTransferService, nil), "1", "customer")
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/transfers/5", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockTransferService.AssertExpectations(t)
+}
+
+func TestListTransfers_SupportSeesHistory(t *testing.T) {
+	mockTransferService := new(mocks.TransferServiceMock)
+	mockTicketService := new(mocks.TicketServiceMock)
+	router := setupTransferRouterAs(controllers.NewTransferController(mockTransferService, mockTicketService), "9", "support")
+
+	owner := uint(2)
+	mockTicketService.On("GetTicketByID", mock.Anything, uint(7)).Return(&models.Ticket{ID: 7, UserID: &owner}, nil)
+	mockTransferService.On("ListTransfers", mock.Anything, uint(7)).Return([]models.TicketTransfer{
+		{ID: 5, TicketID: 7, FromUserID: 1, ToUserID: &owner, Status: models.TicketTransferAccepted},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/tickets/7/transfers", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"ACCEPTED"`)
+}
@@ -0,0 +1,106 @@
+package controllers_test
+
+import (
+	"booking-service/internal/authz"
+	"booking-service/internal/controllers"
+	"booking-service/internal/middlewares"
+	"booking-service/internal/models"
+	"booking-service/test/mocks"
+	"booking-service/utils"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupWebhookRouterAs(controller controllers.WebhookController, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	apiRoutes := router.Group("/api/v1", authenticatedAs("1", role), middlewares.Authorize(authz.DefaultPolicy().Mount("/api/v1")))
+	controllers.RegisterWebhookRoutes(apiRoutes, controller)
+	return router
+}
+
+func TestCreateWebhookSubscription_ShowsSecretOnce(t *testing.T) {
+	mockWebhookService := new(mocks.WebhookServiceMock)
+	router := setupWebhookRouterAs(controllers.NewWebhookController(mockWebhookService), "admin")
+
+	mockWebhookService.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(s *models.WebhookSubscription) bool {
+		return s.URL == "https://partner.example/hooks" && len(s.Events) == 1 && s.Events[0] == models.EventBookingConfirmed
+	})).Run(func(args mock.Arguments) {
+		subscription := args.Get(1).(*models.WebhookSubscription)
+		subscription.ID = 3
+		subscription.Secret = "generated-secret"
+	}).Return(nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks",
+		strings.NewReader(`{"url": "https://partner.example/hooks", "events": ["booking.confirmed"]}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "generated-secret", body["secret"])
+	assert.Equal(t, float64(3), body["id"])
+	mockWebhookService.AssertExpectations(t)
+
+	mockWebhookService.On("ListSubscriptions", mock.Anything).Return([]models.WebhookSubscription{{ID: 3, Secret: "generated-secret"}}, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "generated-secret")
+}
+
+func TestWebhookRoutes_AdminOnly(t *testing.T) {
+	mockWebhookService := new(mocks.WebhookServiceMock)
+	router := setupWebhookRouterAs(controllers.NewWebhookController(mockWebhookService), "support")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks", nil))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockWebhookService.AssertNotCalled(t, "ListSubscriptions", mock.Anything)
+}
+
+func TestListWebhookDeliveries_ParsesFilters(t *testing.T) {
+	mockWebhookService := new(mocks.WebhookServiceMock)
+	router := setupWebhookRouterAs(controllers.NewWebhookController(mockWebhookService), "admin")
+
+	mockWebhookService.On("ListDeliveries", mock.Anything, uint(3), models.WebhookDeliveryFailed, 5).
+		Return([]models.WebhookDelivery{{ID: 9, SubscriptionID: 3, Status: models.WebhookDeliveryFailed}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/3/deliveries?status=failed&limit=5", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockWebhookService.AssertExpectations(t)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/3/deliveries?limit=500", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	mockWebhookService := new(mocks.WebhookServiceMock)
+	router := setupWebhookRouterAs(controllers.NewWebhookController(mockWebhookService), "admin")
+
+	mockWebhookService.On("Redeliver", mock.Anything, uint(3), uint(9)).
+		Return(&models.WebhookDelivery{ID: 9, SubscriptionID: 3, Status: models.WebhookDeliveryPending}, nil)
+	mockWebhookService.On("Redeliver", mock.Anything, uint(3), uint(10)).
+		Return(nil, utils.NewAppError(http.StatusNotFound, utils.CodeWebhookDeliveryNotFound, "Webhook delivery not found", ""))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/3/deliveries/9/redeliver", nil))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/3/deliveries/10/redeliver", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockWebhookService.AssertExpectations(t)
+}
@@ -0,0 +1,134 @@
+package credentials_test
+
+import (
+	"booking-service/internal/credentials"
+	"booking-service/internal/models"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	key1 = "k1:a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s="
+	key2 = "k2:bm5ubm5ubm5ubm5ubm5ubm5ubm5ubm5ubm5ubm5ubm4="
+)
+
+func newIssuer(t *testing.T, algorithm, activeKey string, keys ...string) *credentials.Issuer {
+	issuer, err := credentials.NewIssuer(credentials.Config{Algorithm: algorithm, ActiveKey: activeKey, Keys: keys})
+	require.NoError(t, err)
+	return issuer
+}
+
+func soldTicket() *models.Ticket {
+	return &models.Ticket{ID: 7, EventID: 3, TenantID: "acme", Status: models.TicketStatusSold, CredentialVersion: 2}
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	for _, algorithm := range []string{credentials.AlgorithmEdDSA, credentials.AlgorithmHS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			issuer := newIssuer(t, algorithm, "k1", key1)
+			ticket := soldTicket()
+
+			credential, err := issuer.Issue(ticket)
+			require.NoError(t, err)
+			again, err := issuer.Issue(ticket)
+			require.NoError(t, err)
+			assert.Equal(t, credential, again)
+
+			claims, err := issuer.Verify(credential)
+			require.NoError(t, err)
+			assert.Equal(t, uint(7), claims.TicketID)
+			assert.Equal(t, uint(3), claims.EventID)
+			assert.Equal(t, uint(2), claims.Version)
+			assert.True(t, claims.Admits(ticket))
+
+			_, err = issuer.Verify(credential[:len(credential)-2] + "AA")
+			assert.ErrorIs(t, err, credentials.ErrInvalidCredential)
+		})
+	}
+}
+
+func TestAdmits_RejectsReissuedOrUnsoldTicket(t *testing.T) {
+	issuer := newIssuer(t, credentials.AlgorithmEdDSA, "k1", key1)
+	ticket := soldTicket()
+	credential, err := issuer.Issue(ticket)
+	require.NoError(t, err)
+	claims, err := issuer.Verify(credential)
+	require.NoError(t, err)
+
+	reissued := soldTicket()
+	reissued.CredentialVersion++
+	assert.False(t, claims.Admits(reissued))
+
+	refunded := soldTicket()
+	refunded.Status = models.TicketStatusAvailable
+	assert.False(t, claims.Admits(refunded))
+
+	otherTenant := soldTicket()
+	otherTenant.TenantID = "globex"
+	assert.False(t, claims.Admits(otherTenant))
+}
+
+func TestVerify_KeyRotation(t *testing.T) {
+	before := newIssuer(t, credentials.AlgorithmEdDSA, "k1", key1)
+	credential, err := before.Issue(soldTicket())
+	require.NoError(t, err)
+
+	during := newIssuer(t, credentials.AlgorithmEdDSA, "k2", key1, key2)
+	_, err = during.Verify(credential)
+	assert.NoError(t, err)
+	rotated, err := during.Issue(soldTicket())
+	require.NoError(t, err)
+	assert.NotEqual(t, credential, rotated)
+
+	after := newIssuer(t, credentials.AlgorithmEdDSA, "k2", key2)
+	_, err = after.Verify(credential)
+	assert.ErrorIs(t, err, credentials.ErrInvalidCredential)
+	_, err = after.Verify(rotated)
+	assert.NoError(t, err)
+}
+
+func TestVerify_RejectsOtherAlgorithm(t *testing.T) {
+	hmac := newIssuer(t, credentials.AlgorithmHS256, "k1", key1)
+	credential, err := hmac.Issue(soldTicket())
+	require.NoError(t, err)
+
+	_, err = newIssuer(t, credentials.AlgorithmEdDSA, "k1", key1).Verify(credential)
+	assert.ErrorIs(t, err, credentials.ErrInvalidCredential)
+}
+
+func TestNewIssuer_RejectsInvalidKeys(t *testing.T) {
+	tests := map[string]credentials.Config{
+		"unknown algorithm": {Algorithm: "RS256", ActiveKey: "k1", Keys: []string{key1}},
+		"missing ID":        {Algorithm: credentials.AlgorithmEdDSA, ActiveKey: "k1", Keys: []string{"a2tra2tr"}},
+		"duplicate ID":      {Algorithm: credentials.AlgorithmEdDSA, ActiveKey: "k1", Keys: []string{key1, key1}},
+		"not base64":        {Algorithm: credentials.AlgorithmEdDSA, ActiveKey: "k1", Keys: []string{"k1:???"}},
+		"short seed":        {Algorithm: credentials.AlgorithmEdDSA, ActiveKey: "k1", Keys: []string{"k1:c2hvcnQ="}},
+		"short secret":      {Algorithm: credentials.AlgorithmHS256, ActiveKey: "k1", Keys: []string{"k1:c2hvcnQ="}},
+		"unknown active":    {Algorithm: credentials.AlgorithmEdDSA, ActiveKey: "k3", Keys: []string{key1, key2}},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := credentials.NewIssuer(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPNG(t *testing.T) {
+	image, err := credentials.PNG("credential", 128)
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(image, []byte("\x89PNG\r\n\x1a\n")))
+}
+
+func TestSVG(t *testing.T) {
+	image, err := credentials.SVG("credential")
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(image, []byte(`<svg xmlns="http://www.w3.org/2000/svg"`)))
+	assert.True(t, bytes.HasSuffix(image, []byte(`</svg>`)))
+	assert.Contains(t, string(image), `d="M`)
+}
@@ -0,0 +1,200 @@
+package grpcapi_test
+
+import (
+	"booking-service/internal/grpcapi"
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/auth"
+	"booking-service/pkg/money"
+	"booking-service/pkg/pagination"
+	bookingv1 "booking-service/pkg/pb/booking/v1"
+	"booking-service/test/mocks"
+	"booking-service/utils"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSecret = "test-secret"
+
+type fixture struct {
+	bookingService *mocks.BookingServiceMock
+	ticketService  *mocks.TicketServiceMock
+	eventService   *mocks.EventServiceMock
+	conn           *grpc.ClientConn
+}
+
+func setup(t *testing.T) *fixture {
+	verifier, err := auth.NewVerifier(auth.Config{Algorithm: auth.AlgorithmHS256, HMACSecret: testSecret})
+	require.NoError(t, err)
+	tenants, err := tenancy.NewRegistry([]tenancy.Tenant{{ID: tenancy.DefaultTenantID, Currency: "USD"}})
+	require.NoError(t, err)
+
+	f := &fixture{
+		bookingService: new(mocks.BookingServiceMock),
+		ticketService:  new(mocks.TicketServiceMock),
+		eventService:   new(mocks.EventServiceMock),
+	}
+	server := grpcapi.NewServer(grpcapi.Config{ExposeDetails: true}, grpcapi.Dependencies{
+		BookingService: f.bookingService,
+		TicketService:  f.ticketService,
+		EventService:   f.eventService,
+		Verifier:       verifier,
+		Tenants:        tenants,
+	})
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+
+	f.conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.conn.Close() })
+	return f
+}
+
+// as returns a context authenticated as the user with the given role.
+func as(t *testing.T, userID, role string) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestCreateBooking(t *testing.T) {
+	f := setup(t)
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	f.bookingService.On("CreateBooking", mock.Anything, uint(1), uint(2), []uint{3, 4}).Return(&models.Booking{
+		ID:          10,
+		UserID:      1,
+		EventID:     2,
+		Status:      models.BookingStatusPending,
+		TotalAmount: money.Money{Amount: 2500, Currency: "USD"},
+		Tickets:     []models.Ticket{{ID: 3, Status: models.TicketStatusReserved}},
+		CreatedAt:   createdAt,
+	}, nil)
+
+	booking, err := bookingv1.NewBookingServiceClient(f.conn).CreateBooking(as(t, "1", "customer"),
+		&bookingv1.CreateBookingRequest{EventId: 2, TicketIds: []uint64{3, 4}})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), booking.Id)
+	assert.Equal(t, bookingv1.BookingStatus_BOOKING_STATUS_PENDING, booking.Status)
+	assert.Equal(t, int64(2500), booking.TotalAmount.Amount)
+	assert.Equal(t, bookingv1.TicketStatus_TICKET_STATUS_RESERVED, booking.Tickets[0].Status)
+	assert.True(t, createdAt.Equal(booking.CreatedAt.AsTime()))
+}
+
+func TestMissingTokenIsUnauthenticated(t *testing.T) {
+	f := setup(t)
+
+	_, err := bookingv1.NewBookingServiceClient(f.conn).GetBooking(context.Background(), &bookingv1.GetBookingRequest{Id: 1})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	f.bookingService.AssertNotCalled(t, "GetBookingByID", mock.Anything, mock.Anything)
+}
+
+func TestMethodPermissionIsEnforced(t *testing.T) {
+	f := setup(t)
+
+	_, err := bookingv1.NewBookingServiceClient(f.conn).ConfirmBooking(as(t, "1", "customer"), &bookingv1.ConfirmBookingRequest{Id: 1})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	f.bookingService.AssertNotCalled(t, "ConfirmBooking", mock.Anything, mock.Anything)
+}
+
+func TestOwnershipIsEnforced(t *testing.T) {
+	f := setup(t)
+	f.bookingService.On("GetBookingByID", mock.Anything, uint(5)).Return(&models.Booking{ID: 5, UserID: 2}, nil)
+
+	_, err := bookingv1.NewBookingServiceClient(f.conn).GetBooking(as(t, "1", "customer"), &bookingv1.GetBookingRequest{Id: 5})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	booking, err := bookingv1.NewBookingServiceClient(f.conn).GetBooking(as(t, "9", "support"), &bookingv1.GetBookingRequest{Id: 5})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), booking.UserId)
+}
+
+func TestAppErrorsMapToStatusCodes(t *testing.T) {
+	for httpStatus, code := range map[int]codes.Code{
+		400: codes.InvalidArgument,
+		404: codes.NotFound,
+		409: codes.Aborted,
+		500: codes.Internal,
+		503: codes.Unavailable,
+	} {
+		f := setup(t)
+		f.ticketService.On("GetTicketByID", mock.Anything, uint(7)).Return((*models.Ticket)(nil), utils.NewAppError(httpStatus, utils.CodeTicketNotAvailable, "Ticket not available", "Ticket 7 is sold"))
+
+		_, err := bookingv1.NewTicketServiceClient(f.conn).GetTicket(as(t, "1", "customer"), &bookingv1.GetTicketRequest{Id: 7})
+
+		st := status.Convert(err)
+		assert.Equal(t, code, st.Code(), httpStatus)
+		assert.Equal(t, "Ticket not available: Ticket 7 is sold", st.Message())
+		if assert.Len(t, st.Details(), 1) {
+			assert.Equal(t, "ticket_not_available", st.Details()[0].(*errdetails.ErrorInfo).Reason)
+		}
+	}
+}
+
+func TestListBookings(t *testing.T) {
+	f := setup(t)
+	cursor := pagination.Cursor{CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), ID: 4}
+	f.bookingService.On("ListBookingsByUserID", mock.Anything, uint(1), repositories.BookingQuery{
+		Status:     models.BookingStatusConfirmed,
+		Descending: true,
+		After:      &cursor,
+		Limit:      2,
+	}).Return(&pagination.Page[models.Booking]{
+		Data:       []models.Booking{{ID: 3, UserID: 1}, {ID: 2, UserID: 1}},
+		NextCursor: "next",
+		Total:      5,
+	}, nil)
+
+	resp, err := bookingv1.NewBookingServiceClient(f.conn).ListBookings(as(t, "1", "customer"), &bookingv1.ListBookingsRequest{
+		UserId:    1,
+		Status:    bookingv1.BookingStatus_BOOKING_STATUS_CONFIRMED,
+		PageSize:  2,
+		PageToken: cursor.Encode(),
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Bookings, 2)
+	assert.Equal(t, "next", resp.NextPageToken)
+	assert.Equal(t, int64(5), resp.TotalSize)
+
+	_, err = bookingv1.NewBookingServiceClient(f.conn).ListBookings(as(t, "1", "customer"), &bookingv1.ListBookingsRequest{UserId: 2})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestHealthNeedsNoToken(t *testing.T) {
+	f := setup(t)
+
+	resp, err := healthpb.NewHealthClient(f.conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: "booking.v1.BookingService"})
+
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
@@ -0,0 +1,73 @@
+package health_test
+
+import (
+	"booking-service/internal/health"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_ReportsEachDependency(t *testing.T) {
+	registry := health.NewRegistry(time.Second, 0)
+	registry.Register(
+		health.CheckerFunc("postgres", func(ctx context.Context) error { return nil }),
+		health.CheckerFunc("kafka", func(ctx context.Context) error { return errors.New("connection refused") }),
+	)
+
+	report := registry.Check(context.Background())
+
+	assert.False(t, report.Up())
+	assert.Equal(t, health.StatusUp, report.Checks["postgres"].Status)
+	assert.Equal(t, health.StatusDown, report.Checks["kafka"].Status)
+	assert.Equal(t, "connection refused", report.Checks["kafka"].Error)
+	assert.GreaterOrEqual(t, report.Checks["postgres"].LatencyMs, 0.0)
+}
+
+func TestRegistry_TimesOutSlowChecks(t *testing.T) {
+	registry := health.NewRegistry(20*time.Millisecond, 0)
+	registry.Register(health.CheckerFunc("redis", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	start := time.Now()
+	report := registry.Check(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, health.StatusDown, report.Checks["redis"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["redis"].Error)
+}
+
+func TestRegistry_CachesResults(t *testing.T) {
+	var calls atomic.Int32
+	registry := health.NewRegistry(time.Second, time.Minute)
+	registry.Register(health.CheckerFunc("postgres", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}))
+
+	first := registry.Check(context.Background())
+	second := registry.Check(context.Background())
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, first.CheckedAt, second.CheckedAt)
+}
+
+func TestRegistry_RechecksAfterTTL(t *testing.T) {
+	var calls atomic.Int32
+	registry := health.NewRegistry(time.Second, time.Millisecond)
+	registry.Register(health.CheckerFunc("postgres", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}))
+
+	registry.Check(context.Background())
+	time.Sleep(5 * time.Millisecond)
+	registry.Check(context.Background())
+
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,213 @@
+package lifecycle_test
+
+import (
+	"booking-service/internal/lifecycle"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder collects the order in which components are stopped.
+type recorder struct {
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (r *recorder) closer(name string) lifecycle.Component {
+	return lifecycle.Closer(name, func() error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.stopped = append(r.stopped, name)
+		return nil
+	})
+}
+
+func waitUntil(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestManager_StopsComponentsInReverseOrder(t *testing.T) {
+	rec := &recorder{}
+	manager := lifecycle.NewManager(0, time.Second)
+	manager.Add(rec.closer("database"), rec.closer("kafka"), rec.closer("http"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- manager.Run(ctx) }()
+
+	waitUntil(t, manager.Ready)
+	cancel()
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, []string{"http", "kafka", "database"}, rec.stopped)
+	assert.False(t, manager.Ready())
+}
+
+func TestManager_NotReadyWhileDraining(t *testing.T) {
+	manager := lifecycle.NewManager(100*time.Millisecond, time.Second)
+	stopping := make(chan bool, 1)
+	manager.Add(lifecycle.Closer("probe", func() error {
+		stopping <- manager.Ready()
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- manager.Run(ctx) }()
+
+	waitUntil(t, manager.Ready)
+	cancel()
+	waitUntil(t, func() bool { return !manager.Ready() })
+
+	assert.False(t, <-stopping)
+	assert.NoError(t, <-done)
+}
+
+func TestManager_ShutsDownWhenComponentFails(t *testing.T) {
+	rec := &recorder{}
+	manager := lifecycle.NewManager(0, time.Second)
+	manager.Add(
+		rec.closer("database"),
+		lifecycle.Worker("broken", func(ctx context.Context) error { return errors.New("boom") }),
+	)
+
+	err := manager.Run(context.Background())
+
+	assert.ErrorContains(t, err, "broken: boom")
+	assert.Equal(t, []string{"database"}, rec.stopped)
+}
+
+func TestManager_DrainTimeout(t *testing.T) {
+	manager := lifecycle.NewManager(0, 50*time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	manager.Add(lifecycle.Worker("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := manager.Run(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWorker_ShutdownCancelsContext(t *testing.T) {
+	manager := lifecycle.NewManager(0, time.Second)
+	canceled := make(chan struct{})
+	manager.Add(lifecycle.Worker("loop", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(canceled)
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- manager.Run(ctx) }()
+
+	waitUntil(t, manager.Ready)
+	cancel()
+
+	assert.NoError(t, <-done)
+	<-canceled
+}
+
+// slowStarter is a component whose Start blocks until released.
+type slowStarter struct {
+	release chan struct{}
+	stopped chan struct{}
+}
+
+func (s *slowStarter) Name() string { return "slow" }
+
+func (s *slowStarter) Start(ctx context.Context) error {
+	<-s.release
+	return nil
+}
+
+func (s *slowStarter) Run(ctx context.Context) error {
+	<-s.stopped
+	return nil
+}
+
+func (s *slowStarter) Shutdown(ctx context.Context) error {
+	close(s.stopped)
+	return nil
+}
+
+func TestManager_ReadyOnlyAfterEveryComponentStarted(t *testing.T) {
+	manager := lifecycle.NewManager(0, time.Second)
+	starter := &slowStarter{release: make(chan struct{}), stopped: make(chan struct{})}
+	manager.Add(lifecycle.Closer("database", func() error { return nil }), starter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- manager.Run(ctx) }()
+
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, manager.Ready())
+
+	close(starter.release)
+	waitUntil(t, manager.Ready)
+	cancel()
+
+	assert.NoError(t, <-done)
+}
+
+func TestManager_HTTPServerFailingToBindIsNeverReady(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer taken.Close()
+
+	manager := lifecycle.NewManager(0, time.Second)
+	manager.Add(lifecycle.HTTPServer(&http.Server{Addr: taken.Addr().String()}))
+
+	err = manager.Run(context.Background())
+
+	assert.ErrorContains(t, err, "start http server")
+	assert.False(t, manager.Ready())
+}
+
+func TestManager_HTTPServerAcceptsRequestsOnceReady(t *testing.T) {
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := free.Addr().String()
+	free.Close()
+
+	manager := lifecycle.NewManager(0, time.Second)
+	manager.Add(lifecycle.HTTPServer(&http.Server{
+		Addr:    addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- manager.Run(ctx) }()
+
+	waitUntil(t, manager.Ready)
+	resp, err := http.Get("http://" + addr)
+	assert.NoError(t, err)
+	if err == nil {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	cancel()
+
+	assert.NoError(t, <-done)
+}
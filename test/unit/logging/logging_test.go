@@ -0,0 +1,80 @@
+package logging_test
+
+import (
+	"booking-service/pkg/logging"
+	"booking-service/utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return record
+}
+
+func TestLogger_AddsFieldsFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(logging.Config{Level: "info", Format: logging.FormatJSON}, &buf)
+	assert.NoError(t, err)
+
+	ctx := logging.WithRequestID(context.Background(), "req-123")
+	ctx = logging.WithUserID(ctx, 42)
+	logger.InfoContext(ctx, "Booking created", "booking_id", 7)
+
+	record := decode(t, &buf)
+	assert.Equal(t, "Booking created", record["msg"])
+	assert.Equal(t, "req-123", record["request_id"])
+	assert.Equal(t, float64(42), record["user_id"])
+	assert.Equal(t, float64(7), record["booking_id"])
+}
+
+func TestLogger_RedactsSensitiveKeys(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(logging.Config{RedactKeys: []string{"card_number"}}, &buf)
+	assert.NoError(t, err)
+
+	logger.Info("Login", "password", "hunter2", "Authorization", "Bearer abc", "card_number", "4111", "user", "alice")
+
+	record := decode(t, &buf)
+	assert.Equal(t, "[REDACTED]", record["password"])
+	assert.Equal(t, "[REDACTED]", record["Authorization"])
+	assert.Equal(t, "[REDACTED]", record["card_number"])
+	assert.Equal(t, "alice", record["user"])
+}
+
+func TestLogger_FiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(logging.Config{Level: "warn"}, &buf)
+	assert.NoError(t, err)
+
+	logger.Info("ignored")
+	assert.Zero(t, buf.Len())
+
+	logger.Warn("kept")
+	assert.Equal(t, "WARN", decode(t, &buf)["level"])
+}
+
+func TestLogger_InvalidConfig(t *testing.T) {
+	_, err := logging.New(logging.Config{Level: "loud"}, &bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, err = logging.New(logging.Config{Format: "xml"}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestLogger_AppErrorAsGroup(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(logging.Config{}, &buf)
+	assert.NoError(t, err)
+
+	logger.Error("Failed to create booking", "error", utils.NewAppError(400, utils.CodeTicketNotAvailable, "Ticket not available", "Ticket 3 is not available"))
+
+	group := decode(t, &buf)["error"].(map[string]interface{})
+	assert.Equal(t, float64(400), group["code"])
+	assert.Equal(t, "Ticket not available", group["message"])
+}
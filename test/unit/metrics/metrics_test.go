@@ -0,0 +1,107 @@
+package metrics_test
+
+import (
+	"booking-service/internal/metrics"
+	"booking-service/internal/models"
+	"booking-service/internal/repositories"
+	"booking-service/internal/services"
+	"booking-service/internal/settings"
+	"booking-service/internal/tenancy"
+	"booking-service/test/mocks"
+	"booking-service/utils"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMiddleware_LabelsByRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/api/bookings/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	before := testutil.CollectAndCount(metrics.HTTPRequestDuration)
+	for _, path := range []string{"/api/bookings/1", "/api/bookings/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Both requests land in a single series
+	assert.Equal(t, before+1, testutil.CollectAndCount(metrics.HTTPRequestDuration))
+}
+
+func TestInstrumentProducer_CountsResults(t *testing.T) {
+	producerMock := new(mocks.KafkaProducerMock)
+	producerMock.On("Publish", mock.Anything, "metrics.ok", mock.Anything).Return(nil)
+	producerMock.On("Publish", mock.Anything, "metrics.broken", mock.Anything).Return(errors.New("broker down"))
+	producer := metrics.InstrumentProducer(producerMock)
+
+	assert.NoError(t, producer.Publish(context.Background(), "metrics.ok", "payload"))
+	assert.Error(t, producer.Publish(context.Background(), "metrics.broken", "payload"))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.KafkaPublished.WithLabelValues("metrics.ok", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.KafkaPublished.WithLabelValues("metrics.broken", "failure")))
+}
+
+func TestBookingService_RecordsTransitions(t *testing.T) {
+	bookingRepoMock := new(mocks.BookingRepositoryMock)
+	ticketServiceMock := new(mocks.TicketServiceMock)
+	producerMock := new(mocks.KafkaProducerMock)
+	pricingService := services.NewPricingService(new(mocks.PricingRepositoryMock), new(mocks.TicketRepositoryMock))
+	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, pricingService, producerMock, settings.NewStore(&settings.Settings{HoldTTL: time.Minute}))
+
+	bookingRepoMock.On("TransitionBookingStatus", mock.Anything, uint(1), models.BookingStatusConfirmed.Sources(), models.BookingStatusConfirmed).Return(true, nil)
+	bookingRepoMock.On("GetBookingByID", mock.Anything, uint(1)).Return(&models.Booking{ID: 1}, nil)
+	producerMock.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	ticketServiceMock.On("GetTicketByID", mock.Anything, uint(9)).Return(&models.Ticket{ID: 9, Status: models.TicketStatusSold}, nil)
+
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "metrics-tenant"})
+	confirmed := metrics.BookingTransitions.WithLabelValues("metrics-tenant", string(models.BookingStatusConfirmed))
+	conflicts := metrics.ReservationConflicts.WithLabelValues("metrics-tenant")
+
+	assert.NoError(t, bookingService.ConfirmBooking(ctx, 1))
+	_, err := bookingService.CreateBooking(ctx, 1, 1, []uint{9})
+	assert.Error(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(confirmed))
+	assert.Equal(t, 1.0, testutil.ToFloat64(conflicts))
+}
+
+func TestTicketService_CountsLostReservationRace(t *testing.T) {
+	ticketRepoMock := new(mocks.TicketRepositoryMock)
+	ticketService := services.NewTicketService(ticketRepoMock)
+
+	ticketRepoMock.On("GetTicketByID", mock.Anything, uint(4)).Return(&models.Ticket{ID: 4, Status: models.TicketStatusAvailable}, nil)
+	ticketRepoMock.On("ReserveTicket", mock.Anything, uint(4), uint(1), uint(2)).Return(utils.NewAppError(409, utils.CodeTicketNotAvailable, "Ticket not available", ""))
+
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "race-tenant"})
+	assert.Error(t, ticketService.ReserveTicket(ctx, 4, 1, 2))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.ReservationConflicts.WithLabelValues("race-tenant")))
+}
+
+func TestAvailableTicketsCollector(t *testing.T) {
+	ticketRepoMock := new(mocks.TicketRepositoryMock)
+	ticketRepoMock.On("CountAvailableByEvent", mock.Anything).Return([]repositories.EventAvailability{
+		{TenantID: "tenant-a", EventID: 1, Available: 42},
+		{TenantID: "tenant-b", EventID: 7, Available: 3},
+	}, nil)
+
+	collector := metrics.NewAvailableTicketsCollector(ticketRepoMock, time.Second)
+
+	expected := `
+# HELP booking_service_available_tickets Tickets currently available for sale per event.
+# TYPE booking_service_available_tickets gauge
+booking_service_available_tickets{event_id="1",tenant="tenant-a"} 42
+booking_service_available_tickets{event_id="7",tenant="tenant-b"} 3
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
@@ -0,0 +1,152 @@
+package middlewares_test
+
+import (
+	"booking-service/internal/middlewares"
+	"booking-service/pkg/auth"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "test-secret"
+
+func setupRouter(verifier *auth.Verifier) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	router.GET("/me", middlewares.Auth(verifier), func(c *gin.Context) {
+		userID, _ := middlewares.GetUserID(c)
+		claims, _ := middlewares.GetClaims(c)
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "role": claims.Role})
+	})
+	return router
+}
+
+func newClaims(subject, audience string) auth.Claims {
+	return auth.Claims{
+		Role: "customer",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    "booking-service",
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+func request(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func hs256Verifier(t *testing.T) *auth.Verifier {
+	verifier, err := auth.NewVerifier(auth.Config{
+		Algorithm:  auth.AlgorithmHS256,
+		HMACSecret: testSecret,
+		Issuer:     "booking-service",
+		Audience:   "booking-api",
+	})
+	assert.NoError(t, err)
+	return verifier
+}
+
+func TestAuth_HS256ValidToken(t *testing.T) {
+	router := setupRouter(hs256Verifier(t))
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims("42", "booking-api")).SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+
+	w := request(router, token)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(42), response["user_id"])
+	assert.Equal(t, "customer", response["role"])
+}
+
+func TestAuth_MissingToken(t *testing.T) {
+	router := setupRouter(hs256Verifier(t))
+
+	w := request(router, "")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuth_WrongAudience(t *testing.T) {
+	router := setupRouter(hs256Verifier(t))
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims("42", "other-api")).SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+
+	w := request(router, token)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuth_IgnoresTrustedHeaders(t *testing.T) {
+	router := setupRouter(hs256Verifier(t))
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("X-User-ID", "1")
+	req.Header.Set("X-User-Role", "admin")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuth_RS256WithJWKSFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": "key-1",
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	assert.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+	verifier, err := auth.NewVerifier(auth.Config{
+		Algorithm: auth.AlgorithmRS256,
+		JWKSFile:  jwksFile,
+		Audience:  "booking-api",
+	})
+	assert.NoError(t, err)
+	router := setupRouter(verifier)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, newClaims("7", "booking-api"))
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, request(router, signed).Code)
+
+	// An HS256 token must not be accepted by an RS256 verifier
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims("7", "booking-api")).SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, request(router, forged).Code)
+}
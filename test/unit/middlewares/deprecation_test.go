@@ -0,0 +1,61 @@
+package middlewares_test
+
+import (
+	"booking-service/internal/middlewares"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupDeprecatedRouter(sunset time.Time) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	api := router.Group("/api", middlewares.Deprecated(middlewares.Deprecation{
+		Since:           time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		Sunset:          sunset,
+		Prefix:          "/api",
+		SuccessorPrefix: "/api/v1",
+	}))
+	api.GET("/bookings/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func TestDeprecated_AnnouncesSunset(t *testing.T) {
+	sunset := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	router := setupDeprecatedRouter(sunset)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/bookings/7", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "@1792368000", w.Header().Get("Deprecation"))
+	assert.Equal(t, sunset.UTC().Format(http.TimeFormat), w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1/bookings/7>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestDeprecated_WithoutSunsetKeepsServing(t *testing.T) {
+	router := setupDeprecatedRouter(time.Time{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/bookings/7", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+}
+
+func TestDeprecated_GoneAfterSunset(t *testing.T) {
+	router := setupDeprecatedRouter(time.Now().Add(-time.Hour))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/bookings/7", nil))
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Contains(t, w.Body.String(), "api_version_retired")
+	assert.Equal(t, `</api/v1/bookings/7>; rel="successor-version"`, w.Header().Get("Link"))
+}
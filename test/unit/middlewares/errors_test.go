@@ -0,0 +1,134 @@
+package middlewares_test
+
+import (
+	"booking-service/internal/middlewares"
+	"booking-service/internal/tenancy"
+	"booking-service/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupErrorRouter(exposeDetails bool, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.RequestID(), middlewares.Recovery(exposeDetails), middlewares.ErrorHandler(exposeDetails))
+	router.GET("/fail", handler)
+	return router
+}
+
+func requestProblem(t *testing.T, router *gin.Engine) (*httptest.ResponseRecorder, middlewares.Problem) {
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	req.Header.Set(middlewares.RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var problem middlewares.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return w, problem
+}
+
+func TestErrorHandler_RendersAppError(t *testing.T) {
+	router := setupErrorRouter(false, func(c *gin.Context) {
+		utils.AbortWithError(c, utils.NewAppError(http.StatusConflict, utils.CodeTicketNotAvailable, "Ticket not available", "Ticket 3 is not available"))
+	})
+
+	w, problem := requestProblem(t, router)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, middlewares.ProblemContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "Ticket not available", problem.Title)
+	assert.Equal(t, "ticket_not_available", problem.Code)
+	assert.Equal(t, "urn:booking-service:problem:ticket_not_available", problem.Type)
+	assert.Equal(t, "Ticket 3 is not available", problem.Detail)
+	assert.Equal(t, "/fail", problem.Instance)
+	assert.Equal(t, "req-123", problem.RequestID)
+}
+
+func TestErrorHandler_CodeDoesNotDependOnTitle(t *testing.T) {
+	router := setupErrorRouter(false, func(c *gin.Context) {
+		utils.AbortWithError(c, utils.NewAppError(http.StatusConflict, utils.CodeTicketNotAvailable, "Ticket sold out", ""))
+	})
+
+	_, problem := requestProblem(t, router)
+
+	assert.Equal(t, "Ticket sold out", problem.Title)
+	assert.Equal(t, "ticket_not_available", problem.Code)
+}
+
+func TestErrorHandler_ServerErrorsShareOneCode(t *testing.T) {
+	router := setupErrorRouter(false, func(c *gin.Context) {
+		utils.AbortWithError(c, utils.NewAppError(http.StatusInternalServerError, utils.CodeInternal, "Failed to release ticket 12", "pq: connection refused"))
+	})
+
+	_, problem := requestProblem(t, router)
+
+	assert.Equal(t, "Failed to release ticket 12", problem.Title)
+	assert.Equal(t, "internal_server_error", problem.Code)
+}
+
+func TestErrorHandler_MapsSentinelErrors(t *testing.T) {
+	router := setupErrorRouter(true, func(c *gin.Context) {
+		utils.AbortWithError(c, fmt.Errorf("%w: %q", tenancy.ErrUnknownTenant, "brand-z"))
+	})
+
+	w, problem := requestProblem(t, router)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "unknown_tenant", problem.Code)
+}
+
+func TestErrorHandler_HidesServerErrorDetails(t *testing.T) {
+	router := setupErrorRouter(false, func(c *gin.Context) {
+		utils.AbortWithError(c, errors.New("pq: connection refused"))
+	})
+
+	w, problem := requestProblem(t, router)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "internal_server_error", problem.Code)
+	assert.Empty(t, problem.Detail)
+}
+
+func TestErrorHandler_ExposesServerErrorDetailsOutsideProduction(t *testing.T) {
+	router := setupErrorRouter(true, func(c *gin.Context) {
+		utils.AbortWithError(c, errors.New("pq: connection refused"))
+	})
+
+	_, problem := requestProblem(t, router)
+
+	assert.Equal(t, "pq: connection refused", problem.Detail)
+}
+
+func TestErrorHandler_LeavesWrittenResponses(t *testing.T) {
+	router := setupErrorRouter(true, func(c *gin.Context) {
+		_ = c.Error(errors.New("logged only"))
+		c.JSON(http.StatusAccepted, gin.H{"status": "queued"})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.JSONEq(t, `{"status":"queued"}`, w.Body.String())
+}
+
+func TestRecovery_RendersPanicAsProblem(t *testing.T) {
+	router := setupErrorRouter(false, func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w, problem := requestProblem(t, router)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "internal_server_error", problem.Code)
+	assert.Empty(t, problem.Detail)
+	assert.Equal(t, "req-123", problem.RequestID)
+}
@@ -0,0 +1,84 @@
+package middlewares_test
+
+import (
+	"booking-service/internal/middlewares"
+	"booking-service/internal/settings"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRateLimitRouter(store *settings.Store) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true), middlewares.RateLimit(store))
+	router.GET("/limited", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func requestFrom(router *gin.Engine, addr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+	req.RemoteAddr = addr
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_RejectsAfterBurst(t *testing.T) {
+	store := settings.NewStore(&settings.Settings{
+		HoldTTL:   time.Minute,
+		RateLimit: settings.RateLimit{RequestsPerSecond: 0.1, Burst: 2},
+	})
+	router := setupRateLimitRouter(store)
+
+	assert.Equal(t, http.StatusOK, requestFrom(router, "10.0.0.1:1000").Code)
+	assert.Equal(t, http.StatusOK, requestFrom(router, "10.0.0.1:1000").Code)
+	w := requestFrom(router, "10.0.0.1:1000")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "10", w.Header().Get("Retry-After"))
+	// Other clients have their own bucket
+	assert.Equal(t, http.StatusOK, requestFrom(router, "10.0.0.2:1000").Code)
+}
+
+func TestRateLimit_DisabledWithoutRate(t *testing.T) {
+	store := settings.NewStore(&settings.Settings{HoldTTL: time.Minute})
+	router := setupRateLimitRouter(store)
+
+	for i := 0; i < 50; i++ {
+		assert.Equal(t, http.StatusOK, requestFrom(router, "10.0.0.1:1000").Code)
+	}
+}
+
+func TestRateLimit_IgnoresForwardedForFromUntrustedPeers(t *testing.T) {
+	store := settings.NewStore(&settings.Settings{
+		HoldTTL:   time.Minute,
+		RateLimit: settings.RateLimit{RequestsPerSecond: 0.1, Burst: 1},
+	})
+	router := setupRateLimitRouter(store)
+	assert.NoError(t, router.SetTrustedProxies([]string{"10.0.0.100"}))
+
+	forwardedFrom := func(addr, client string) int {
+		req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+		req.RemoteAddr = addr
+		req.Header.Set("X-Forwarded-For", client)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// A client cannot get a fresh bucket by forging the header
+	assert.Equal(t, http.StatusOK, forwardedFrom("10.0.0.1:1000", "192.0.2.1"))
+	assert.Equal(t, http.StatusTooManyRequests, forwardedFrom("10.0.0.1:1000", "192.0.2.2"))
+
+	// Clients behind the trusted proxy are told apart by the header
+	assert.Equal(t, http.StatusOK, forwardedFrom("10.0.0.100:1000", "192.0.2.3"))
+	assert.Equal(t, http.StatusOK, forwardedFrom("10.0.0.100:1000", "192.0.2.4"))
+	assert.Equal(t, http.StatusTooManyRequests, forwardedFrom("10.0.0.100:1000", "192.0.2.3"))
+}
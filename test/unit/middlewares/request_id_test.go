@@ -0,0 +1,56 @@
+package middlewares_test
+
+import (
+	"booking-service/internal/middlewares"
+	"booking-service/pkg/logging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRequestIDRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.RequestID())
+	router.GET("/id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"gin":     middlewares.GetRequestID(c),
+			"context": logging.RequestID(c.Request.Context()),
+		})
+	})
+	return router
+}
+
+func requestWithID(router *gin.Engine, requestID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/id", nil)
+	if requestID != "" {
+		req.Header.Set(middlewares.RequestIDHeader, requestID)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRequestID_EchoesValidHeader(t *testing.T) {
+	w := requestWithID(setupRequestIDRouter(), "abc-123")
+
+	assert.Equal(t, "abc-123", w.Header().Get(middlewares.RequestIDHeader))
+	assert.JSONEq(t, `{"gin":"abc-123","context":"abc-123"}`, w.Body.String())
+}
+
+func TestRequestID_GeneratesWhenMissing(t *testing.T) {
+	w := requestWithID(setupRequestIDRouter(), "")
+
+	assert.Len(t, w.Header().Get(middlewares.RequestIDHeader), 32)
+}
+
+func TestRequestID_ReplacesUnsafeHeader(t *testing.T) {
+	w := requestWithID(setupRequestIDRouter(), "bad id\nwith newline")
+
+	requestID := w.Header().Get(middlewares.RequestIDHeader)
+	assert.Len(t, requestID, 32)
+	assert.NotContains(t, requestID, " ")
+}
@@ -0,0 +1,119 @@
+package middlewares_test
+
+import (
+	"booking-service/internal/middlewares"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTenantRouter(t *testing.T, tenantClaim string) *gin.Engine {
+	registry, err := tenancy.NewRegistry([]tenancy.Tenant{
+		{ID: tenancy.DefaultTenantID},
+		{ID: "brand-a", Hosts: []string{"tickets.brand-a.test"}, Currency: "USD"},
+		{ID: "brand-b", Hosts: []string{"tickets.brand-b.test"}, Currency: "VND"},
+		{ID: "brand-c", Hosts: []string{"tickets.brand-c.test"}, Currency: "USD", ResolveByHost: true},
+	})
+	assert.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	router.GET("/tenant", func(c *gin.Context) {
+		middlewares.SetClaims(c, &auth.Claims{
+			Role:             "customer",
+			TenantID:         tenantClaim,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "1"},
+		})
+		c.Next()
+	}, middlewares.Tenant(registry), func(c *gin.Context) {
+		tenant, _ := tenancy.FromContext(c.Request.Context())
+		c.String(http.StatusOK, tenant.ID)
+	})
+	return router
+}
+
+func requestTenant(router *gin.Engine, host string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/tenant", nil)
+	req.Host = host
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTenant_FromClaim(t *testing.T) {
+	w := requestTenant(setupTenantRouter(t, "brand-b"), "api.internal:8080")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "brand-b", w.Body.String())
+}
+
+func TestTenant_ClaimlessTokenCannotPickTenantByHost(t *testing.T) {
+	w := requestTenant(setupTenantRouter(t, ""), "tickets.brand-a.test:443")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTenant_ClaimlessTokenResolvedByHostOfOptedInTenant(t *testing.T) {
+	w := requestTenant(setupTenantRouter(t, ""), "tickets.brand-c.test:443")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "brand-c", w.Body.String())
+}
+
+func TestTenant_ClaimMismatchRejectedOnOptedInHost(t *testing.T) {
+	w := requestTenant(setupTenantRouter(t, "brand-a"), "tickets.brand-c.test")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTenant_ClaimRequiredWithoutDefaultTenant(t *testing.T) {
+	registry, err := tenancy.NewRegistry([]tenancy.Tenant{
+		{ID: "brand-a", Hosts: []string{"tickets.brand-a.test"}},
+	})
+	assert.NoError(t, err)
+
+	_, err = registry.Resolve("", "tickets.brand-a.test")
+	assert.ErrorIs(t, err, tenancy.ErrTenantRequired)
+	tenant, err := registry.Resolve("brand-a", "tickets.brand-a.test")
+	assert.NoError(t, err)
+	assert.Equal(t, "brand-a", tenant.ID)
+}
+
+func TestTenant_ResolveByHostWithoutDefaultTenant(t *testing.T) {
+	registry, err := tenancy.NewRegistry([]tenancy.Tenant{
+		{ID: "brand-a", Hosts: []string{"tickets.brand-a.test"}, ResolveByHost: true},
+	})
+	assert.NoError(t, err)
+
+	tenant, err := registry.Resolve("", "tickets.brand-a.test")
+	assert.NoError(t, err)
+	assert.Equal(t, "brand-a", tenant.ID)
+	_, err = registry.Resolve("", "api.internal")
+	assert.ErrorIs(t, err, tenancy.ErrTenantRequired)
+}
+
+func TestTenant_DefaultTenant(t *testing.T) {
+	w := requestTenant(setupTenantRouter(t, ""), "localhost")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, tenancy.DefaultTenantID, w.Body.String())
+}
+
+func TestTenant_ClaimAndHostMismatch(t *testing.T) {
+	w := requestTenant(setupTenantRouter(t, "brand-a"), "tickets.brand-b.test")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTenant_UnknownClaim(t *testing.T) {
+	w := requestTenant(setupTenantRouter(t, "brand-z"), "localhost")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,45 @@
+package middlewares_test
+
+import (
+	"booking-service/internal/middlewares"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout_CancelsHandlerContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	router.GET("/slow", middlewares.Timeout(20*time.Millisecond), func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+		case <-time.After(time.Second):
+			c.Status(http.StatusOK)
+		}
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+}
+
+func TestTimeout_FastHandlerUnaffected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/fast", middlewares.Timeout(time.Second), func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		assert.True(t, hasDeadline)
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
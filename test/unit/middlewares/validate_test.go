@@ -0,0 +1,81 @@
+package middlewares_test
+
+import (
+	"booking-service/internal/middlewares"
+	"booking-service/internal/openapi"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupValidatedRouter(t *testing.T) *gin.Engine {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandler(true))
+	api := router.Group("/api", middlewares.ValidateRequest(doc, "/api"))
+	api.POST("/bookings", func(c *gin.Context) {
+		var body map[string]interface{}
+		assert.NoError(t, c.ShouldBindJSON(&body), "body must still be readable")
+		c.Status(http.StatusCreated)
+	})
+	api.GET("/bookings/user/:user_id", func(c *gin.Context) {
+		c.String(http.StatusOK, c.Request.URL.RawQuery)
+	})
+	api.GET("/undocumented", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return router
+}
+
+func TestValidateRequest_AcceptsValidRequests(t *testing.T) {
+	router := setupValidatedRouter(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/bookings", strings.NewReader(`{"event_id": 1, "ticket_ids": [1, 2]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/bookings/user/1?status=CONFIRMED", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "status=CONFIRMED", w.Body.String(), "defaults must not be added")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/undocumented?anything=1", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestValidateRequest_RejectsInvalidRequests(t *testing.T) {
+	router := setupValidatedRouter(t)
+
+	for name, tc := range map[string]struct {
+		method, target, body string
+		detail               string
+	}{
+		"missing field":    {http.MethodPost, "/api/bookings", `{"event_id": 1}`, "request body"},
+		"wrong type":       {http.MethodPost, "/api/bookings", `{"event_id": "one", "ticket_ids": [1]}`, "event_id"},
+		"empty ticket ids": {http.MethodPost, "/api/bookings", `{"event_id": 1, "ticket_ids": []}`, "ticket_ids"},
+		"path parameter":   {http.MethodGet, "/api/bookings/user/abc", "", "path parameter user_id"},
+		"query enum":       {http.MethodGet, "/api/bookings/user/1?status=LOST", "", "query parameter status"},
+		"query range":      {http.MethodGet, "/api/bookings/user/1?limit=500", "", "query parameter limit"},
+	} {
+		req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		var problem middlewares.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem), name)
+		assert.Equal(t, "invalid_request", problem.Code, name)
+		assert.Contains(t, problem.Detail, tc.detail, name)
+	}
+}
@@ -0,0 +1,52 @@
+package money_test
+
+import (
+	"booking-service/pkg/money"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_MinorUnits(t *testing.T) {
+	usd, err := money.Parse("12.5", "usd")
+	assert.NoError(t, err)
+	assert.Equal(t, money.Money{Amount: 1250, Currency: "USD"}, usd)
+
+	vnd, err := money.Parse("250000", "VND")
+	assert.NoError(t, err)
+	assert.Equal(t, money.Money{Amount: 250000, Currency: "VND"}, vnd)
+}
+
+func TestParse_TooManyFractionDigits(t *testing.T) {
+	_, err := money.Parse("12.345", "USD")
+	assert.True(t, errors.Is(err, money.ErrInvalidAmount))
+
+	_, err = money.Parse("1.5", "VND")
+	assert.True(t, errors.Is(err, money.ErrInvalidAmount))
+}
+
+func TestSum_ExactTotals(t *testing.T) {
+	prices := make([]money.Money, 0, 10)
+	for i := 0; i < 10; i++ {
+		prices = append(prices, money.Money{Amount: 10, Currency: "USD"})
+	}
+
+	total, err := money.Sum("", prices...)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), total.Amount)
+	assert.Equal(t, "1.00 USD", total.String())
+}
+
+func TestSum_CurrencyMismatch(t *testing.T) {
+	_, err := money.Sum("",
+		money.Money{Amount: 100, Currency: "USD"},
+		money.Money{Amount: 100, Currency: "VND"},
+	)
+	assert.True(t, errors.Is(err, money.ErrCurrencyMismatch))
+}
+
+func TestNew_UnsupportedCurrency(t *testing.T) {
+	_, err := money.New(100, "XYZ")
+	assert.True(t, errors.Is(err, money.ErrUnsupportedCurrency))
+}
@@ -0,0 +1,47 @@
+package openapi_test
+
+import (
+	"booking-service/internal/controllers"
+	"booking-service/internal/openapi"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	doc, err := openapi.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+}
+
+func TestPathTemplate(t *testing.T) {
+	assert.Equal(t, "/api/bookings/{id}/status", openapi.PathTemplate("/api/bookings/:id/status"))
+	assert.Equal(t, "/files/{path}", openapi.PathTemplate("/files/*path"))
+	assert.Equal(t, "/health/live", openapi.PathTemplate("/health/live"))
+}
+
+// TestSpecCoversRoutes fails when a booking, ticket, transfer or health route is
+// registered without being documented.
+func TestSpecCoversRoutes(t *testing.T) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Versioned routes are documented relative to the version root
+	controllers.RegisterBookingRoutes(&router.RouterGroup, controllers.NewBookingController(nil))
+	controllers.RegisterTicketRoutes(&router.RouterGroup, controllers.NewTicketController(nil, nil))
+	controllers.RegisterTransferRoutes(&router.RouterGroup, controllers.NewTransferController(nil, nil))
+	controllers.RegisterCredentialRoutes(&router.RouterGroup, controllers.NewCredentialController(nil, nil, nil))
+	controllers.NewHealthController(nil, nil).RegisterHealthRoutes(router)
+
+	routes := router.Routes()
+	require.NotEmpty(t, routes)
+	for _, route := range routes {
+		_, operation := openapi.Operation(doc, route.Method, route.Path)
+		assert.NotNil(t, operation, "%s %s is not in the OpenAPI document", route.Method, route.Path)
+	}
+}
@@ -0,0 +1,76 @@
+package pricing_test
+
+import (
+	"booking-service/internal/pricing"
+	"booking-service/pkg/money"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var base = money.Money{Amount: 10000, Currency: "USD"}
+
+func TestFixed_ChargesBasePrice(t *testing.T) {
+	strategy, err := pricing.Build(pricing.StrategyFixed, nil)
+	assert.NoError(t, err)
+
+	price, err := strategy.Price(pricing.Input{Base: base, Total: 10, Held: 9})
+	assert.NoError(t, err)
+	assert.Equal(t, base, price)
+}
+
+func TestSellThrough_AppliesHighestReachedStep(t *testing.T) {
+	strategy, err := pricing.Build(pricing.StrategySellThrough, []byte(`{"steps":[
+		{"threshold_percent":50,"multiplier_bps":12000},
+		{"threshold_percent":80,"multiplier_bps":15000}
+	]}`))
+	assert.NoError(t, err)
+
+	price, err := strategy.Price(pricing.Input{Base: base, Total: 100, Held: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), price.Amount)
+
+	price, err = strategy.Price(pricing.Input{Base: base, Total: 100, Held: 60})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12000), price.Amount)
+
+	price, err = strategy.Price(pricing.Input{Base: base, Total: 100, Held: 95})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15000), price.Amount)
+	assert.Equal(t, "USD", price.Currency)
+}
+
+func TestTimeToEvent_AppliesClosestWindow(t *testing.T) {
+	strategy, err := pricing.Build(pricing.StrategyTimeToEvent, []byte(`{"steps":[
+		{"within_hours":168,"multiplier_bps":11000},
+		{"within_hours":24,"multiplier_bps":13000}
+	]}`))
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	price, err := strategy.Price(pricing.Input{Base: base, Now: now, EventDate: now.Add(30 * 24 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), price.Amount)
+
+	price, err = strategy.Price(pricing.Input{Base: base, Now: now, EventDate: now.Add(72 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11000), price.Amount)
+
+	price, err = strategy.Price(pricing.Input{Base: base, Now: now, EventDate: now.Add(2 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13000), price.Amount)
+}
+
+func TestBuild_RejectsInvalidConfig(t *testing.T) {
+	_, err := pricing.Build("auction", nil)
+	assert.True(t, errors.Is(err, pricing.ErrUnknownStrategy))
+
+	_, err = pricing.Build(pricing.StrategySellThrough, []byte(`{"steps":[]}`))
+	assert.Error(t, err)
+
+	_, err = pricing.Build(pricing.StrategyTimeToEvent, []byte(`{"steps":[{"within_hours":24,"multiplier_bps":0}]}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,188 @@
+package reconcile_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/reconcile"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/money"
+	"booking-service/test/mocks"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "tenant-a"})
+
+func usd(amount int64) money.Money {
+	return money.Money{Amount: amount, Currency: "USD"}
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func setupReconciler(repair bool) (*mocks.BookingRepositoryMock, *mocks.TicketRepositoryMock, *reconcile.Reconciler) {
+	bookingRepo := new(mocks.BookingRepositoryMock)
+	ticketRepo := new(mocks.TicketRepositoryMock)
+	return bookingRepo, ticketRepo, reconcile.NewReconciler(bookingRepo, ticketRepo, reconcile.Config{BatchSize: 100, Repair: repair})
+}
+
+func TestRun_ConsistentBookingsReportNothing(t *testing.T) {
+	bookingRepo, ticketRepo, reconciler := setupReconciler(true)
+	bookingRepo.On("ScanBookings", mock.Anything, 100).Return([]models.Booking{{
+		ID:          1,
+		Status:      models.BookingStatusConfirmed,
+		TotalAmount: usd(1000),
+		Tickets:     []models.Ticket{{ID: 10, Status: models.TicketStatusSold, Price: usd(1000)}},
+	}}, nil)
+	ticketRepo.On("ListOrphanedTickets", mock.Anything, uint(0)).Return([]models.Ticket{}, nil)
+
+	report, err := reconciler.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-a", report.TenantID)
+	assert.Equal(t, 1, report.BookingsScanned)
+	assert.Empty(t, report.Issues)
+}
+
+func TestRun_ReportOnlyChangesNothing(t *testing.T) {
+	bookingRepo, ticketRepo, reconciler := setupReconciler(false)
+	bookingRepo.On("ScanBookings", mock.Anything, 100).Return([]models.Booking{
+		{
+			ID:          1,
+			Status:      models.BookingStatusConfirmed,
+			TotalAmount: usd(1000),
+			Tickets:     []models.Ticket{{ID: 10, Status: models.TicketStatusReserved, Price: usd(1000)}},
+		},
+		{
+			ID:          2,
+			Status:      models.BookingStatusCanceled,
+			TotalAmount: usd(1000),
+			Tickets:     []models.Ticket{{ID: 20, Status: models.TicketStatusSold, Price: usd(1000)}},
+		},
+	}, nil)
+	ticketRepo.On("ListOrphanedTickets", mock.Anything, uint(0)).Return([]models.Ticket{{ID: 20, BookingID: uintPtr(2), Status: models.TicketStatusSold}}, nil)
+
+	report, err := reconciler.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Issues, 2)
+	assert.Equal(t, 1, report.Counts[reconcile.KindConfirmedTicketNotSold])
+	assert.Equal(t, 1, report.Counts[reconcile.KindCanceledTicketHeld])
+	assert.Zero(t, report.Repaired)
+	ticketRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+	ticketRepo.AssertNotCalled(t, "ReleaseOrphanedTickets", mock.Anything, mock.Anything)
+}
+
+func TestRun_RepairsTicketStatuses(t *testing.T) {
+	bookingRepo, ticketRepo, reconciler := setupReconciler(true)
+	bookingRepo.On("ScanBookings", mock.Anything, 100).Return([]models.Booking{
+		{
+			ID:          1,
+			Status:      models.BookingStatusConfirmed,
+			TotalAmount: usd(1000),
+			Tickets:     []models.Ticket{{ID: 10, Status: models.TicketStatusReserved, Price: usd(1000)}},
+		},
+		{
+			ID:          2,
+			Status:      models.BookingStatusCanceled,
+			TotalAmount: usd(1000),
+			Tickets:     []models.Ticket{{ID: 20, Status: models.TicketStatusReserved, Price: usd(1000)}},
+		},
+	}, nil)
+	ticketRepo.On("ListOrphanedTickets", mock.Anything, uint(0)).Return([]models.Ticket{}, nil)
+	ticketRepo.On("UpdateTicketStatus", mock.Anything, uint(10), models.TicketStatusSold).Return(nil)
+	ticketRepo.On("ReleaseOrphanedTickets", mock.Anything, []uint{20}).Return(int64(1), nil)
+
+	report, err := reconciler.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Repaired)
+	for _, issue := range report.Issues {
+		assert.True(t, issue.Repaired, issue.Kind)
+	}
+	ticketRepo.AssertExpectations(t)
+}
+
+func TestRun_SoldTicketOfConfirmedBookingIsNotRepaired(t *testing.T) {
+	bookingRepo, ticketRepo, reconciler := setupReconciler(true)
+	bookingRepo.On("ScanBookings", mock.Anything, 100).Return([]models.Booking{{
+		ID:          1,
+		Status:      models.BookingStatusConfirmed,
+		TotalAmount: usd(1000),
+		Tickets:     []models.Ticket{{ID: 10, Status: models.TicketStatusAvailable, Price: usd(1000)}},
+	}}, nil)
+	ticketRepo.On("ListOrphanedTickets", mock.Anything, uint(0)).Return([]models.Ticket{}, nil)
+
+	report, err := reconciler.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Issues, 1)
+	assert.False(t, report.Issues[0].Repaired)
+	ticketRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRun_PendingTicketIsOnlyReported(t *testing.T) {
+	bookingRepo, ticketRepo, reconciler := setupReconciler(true)
+	bookingRepo.On("ScanBookings", mock.Anything, 100).Return([]models.Booking{{
+		ID:          1,
+		Status:      models.BookingStatusPending,
+		TotalAmount: usd(1000),
+		Tickets:     []models.Ticket{{ID: 10, Status: models.TicketStatusSold, Price: usd(1000)}},
+	}}, nil)
+	ticketRepo.On("ListOrphanedTickets", mock.Anything, uint(0)).Return([]models.Ticket{}, nil)
+
+	report, err := reconciler.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Counts[reconcile.KindPendingTicketNotReserved])
+	assert.Zero(t, report.Repaired)
+}
+
+func TestRun_ReportsTotalMismatch(t *testing.T) {
+	bookingRepo, ticketRepo, reconciler := setupReconciler(true)
+	bookingRepo.On("ScanBookings", mock.Anything, 100).Return([]models.Booking{{
+		ID:          1,
+		Status:      models.BookingStatusConfirmed,
+		TotalAmount: usd(2500),
+		FeeAmount:   100,
+		Tickets:     []models.Ticket{{ID: 10, Status: models.TicketStatusSold, Price: usd(1500)}},
+		PriceRecords: []models.PriceRecord{
+			{TicketID: 10, Price: usd(1200)},
+		},
+	}}, nil)
+	ticketRepo.On("ListOrphanedTickets", mock.Anything, uint(0)).Return([]models.Ticket{}, nil)
+
+	report, err := reconciler.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Issues, 1)
+	assert.Equal(t, reconcile.KindTotalMismatch, report.Issues[0].Kind)
+	assert.Contains(t, report.Issues[0].Detail, "13.00")
+}
+
+func TestRun_ReleasesOrphanedTickets(t *testing.T) {
+	bookingRepo, ticketRepo, reconciler := setupReconciler(true)
+	bookingRepo.On("ScanBookings", mock.Anything, 100).Return(nil, nil)
+	bookingRepo.On("GetBookingByID", mock.Anything, uint(7)).Return(nil, nil)
+	bookingRepo.On("GetBookingByID", mock.Anything, uint(8)).Return(&models.Booking{ID: 8}, nil)
+	ticketRepo.On("ListOrphanedTickets", mock.Anything, uint(0)).Return([]models.Ticket{
+		{ID: 30, Status: models.TicketStatusReserved},
+		{ID: 31, BookingID: uintPtr(7), Status: models.TicketStatusSold},
+		{ID: 32, BookingID: uintPtr(8), Status: models.TicketStatusReserved},
+	}, nil)
+	ticketRepo.On("ReleaseOrphanedTickets", mock.Anything, []uint{30}).Return(int64(1), nil)
+	ticketRepo.On("ReleaseOrphanedTickets", mock.Anything, []uint{31}).Return(int64(0), nil)
+
+	report, err := reconciler.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Issues, 2)
+	assert.Equal(t, 1, report.Counts[reconcile.KindHeldTicketWithoutBooking])
+	assert.Equal(t, 1, report.Counts[reconcile.KindTicketMissingBooking])
+	// Ticket 31 was booked again between the scan and the repair
+	assert.Equal(t, 1, report.Repaired)
+	ticketRepo.AssertNotCalled(t, "ReleaseOrphanedTickets", mock.Anything, []uint{32})
+}
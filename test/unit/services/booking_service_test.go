@@ -2,20 +2,39 @@ package services_test
 
 import (
 	"booking-service/internal/models"
+	"booking-service/internal/repositories"
 	"booking-service/internal/services"
+	"booking-service/internal/settings"
+	"booking-service/internal/tenancy"
+	"booking-service/pkg/kafka"
+	kafkaModels "booking-service/pkg/kafka/models"
+	"booking-service/pkg/money"
+	"booking-service/pkg/pagination"
 	"booking-service/test/mocks"
 	"booking-service/utils"
+	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+func newSettingsStore(maxTickets int, bookingsEnabled bool) *settings.Store {
+	return settings.NewStore(&settings.Settings{
+		HoldTTL:              15 * time.Minute,
+		MaxTicketsPerBooking: maxTickets,
+		Features:             map[string]bool{settings.FeatureBookings: bookingsEnabled},
+	})
+}
+
 func setupMocks() (*mocks.BookingRepositoryMock, *mocks.TicketServiceMock, *mocks.KafkaProducerMock, services.BookingService) {
 	bookingRepoMock := new(mocks.BookingRepositoryMock)
 	ticketServiceMock := new(mocks.TicketServiceMock)
 	kafkaProducerMock := new(mocks.KafkaProducerMock)
-	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, kafkaProducerMock)
+	pricingService := services.NewPricingService(new(mocks.PricingRepositoryMock), new(mocks.TicketRepositoryMock))
+	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, pricingService, kafkaProducerMock, newSettingsStore(10, true))
 	return bookingRepoMock, ticketServiceMock, kafkaProducerMock, bookingService
 }
 
@@ -26,36 +45,37 @@ func TestCreateBooking_Success(t *testing.T) {
 	eventID := uint(1)
 	ticketIDs := []uint{1, 2}
 	mockTickets := []models.Ticket{
-		{ID: 1, Price: 100, Status: models.TicketStatusAvailable},
-		{ID: 2, Price: 150, Status: models.TicketStatusAvailable},
+		{ID: 1, EventID: 1, Price: money.Money{Amount: 10000, Currency: "USD"}, Status: models.TicketStatusAvailable},
+		{ID: 2, EventID: 1, Price: money.Money{Amount: 15000, Currency: "USD"}, Status: models.TicketStatusAvailable},
 	}
 	mockBooking := &models.Booking{
 		ID:          1,
 		UserID:      userID,
 		EventID:     eventID,
-		TotalAmount: 250,
+		TotalAmount: money.Money{Amount: 25000, Currency: "USD"},
 		Status:      models.BookingStatusPending,
 		Tickets:     mockTickets,
 	}
 
 	for _, ticket := range mockTickets {
-		ticketServiceMock.On("GetTicketByID", ticket.ID).Return(&ticket, nil)
-		ticketServiceMock.On("ReserveTicket", ticket.ID, userID, mock.AnythingOfType("uint")).Return(nil)
+		ticketServiceMock.On("GetTicketByID", mock.Anything, ticket.ID).Return(&ticket, nil)
+		ticketServiceMock.On("ReserveTicket", mock.Anything, ticket.ID, userID, mock.AnythingOfType("uint")).Return(nil)
 	}
 
-	bookingRepoMock.On("CreateBooking", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
-		booking := args.Get(0).(*models.Booking)
+	bookingRepoMock.On("CreateBooking", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		booking := args.Get(1).(*models.Booking)
 		booking.ID = 1
 	})
-	kafkaProducerMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	kafkaProducerMock.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	result, err := bookingService.CreateBooking(userID, eventID, ticketIDs)
+	result, err := bookingService.CreateBooking(context.Background(), userID, eventID, ticketIDs)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, mockBooking.UserID, result.UserID)
 	assert.Equal(t, mockBooking.EventID, result.EventID)
 	assert.Equal(t, mockBooking.TotalAmount, result.TotalAmount)
+	assert.Len(t, result.PriceRecords, 2)
 
 	bookingRepoMock.AssertExpectations(t)
 	ticketServiceMock.AssertExpectations(t)
@@ -68,11 +88,11 @@ func TestCreateBooking_TicketNotAvailable(t *testing.T) {
 	userID := uint(1)
 	eventID := uint(1)
 	ticketIDs := []uint{1}
-	ticketServiceMock.On("GetTicketByID", uint(1)).Return(&models.Ticket{
+	ticketServiceMock.On("GetTicketByID", mock.Anything, uint(1)).Return(&models.Ticket{
 		ID: 1, Status: models.TicketStatusSold,
 	}, nil)
 
-	result, err := bookingService.CreateBooking(userID, eventID, ticketIDs)
+	result, err := bookingService.CreateBooking(context.Background(), userID, eventID, ticketIDs)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -81,11 +101,167 @@ func TestCreateBooking_TicketNotAvailable(t *testing.T) {
 	ticketServiceMock.AssertExpectations(t)
 }
 
+func TestCreateBooking_TicketNotFound(t *testing.T) {
+	_, ticketServiceMock, _, bookingService := setupMocks()
+	ticketServiceMock.On("GetTicketByID", mock.Anything, uint(1)).Return((*models.Ticket)(nil), utils.NewAppError(404, utils.CodeTicketNotFound, "Ticket not found", "Ticket 1 not found"))
+
+	result, err := bookingService.CreateBooking(context.Background(), 1, 1, []uint{1})
+
+	assert.Nil(t, result)
+	assert.Equal(t, 404, err.(*utils.AppError).Code)
+	assert.Equal(t, "Ticket not found", err.(*utils.AppError).Message)
+}
+
+func TestCreateBooking_TicketForOtherEvent(t *testing.T) {
+	bookingRepoMock, ticketServiceMock, _, bookingService := setupMocks()
+
+	ticketServiceMock.On("GetTicketByID", mock.Anything, uint(1)).Return(&models.Ticket{
+		ID: 1, EventID: 2, Price: money.Money{Amount: 10000, Currency: "USD"}, Status: models.TicketStatusAvailable,
+	}, nil)
+
+	result, err := bookingService.CreateBooking(context.Background(), 1, 1, []uint{1})
+
+	assert.Nil(t, result)
+	assert.Equal(t, 400, err.(*utils.AppError).Code)
+	bookingRepoMock.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything)
+}
+
+func TestCreateBooking_ReleasesHeldTicketsWhenReservationLosesRace(t *testing.T) {
+	bookingRepoMock, ticketServiceMock, kafkaProducerMock, bookingService := setupMocks()
+
+	for _, id := range []uint{1, 2} {
+		ticketServiceMock.On("GetTicketByID", mock.Anything, id).Return(&models.Ticket{
+			ID: id, EventID: 1, Price: money.Money{Amount: 10000, Currency: "USD"}, Status: models.TicketStatusAvailable,
+		}, nil)
+	}
+	bookingRepoMock.On("CreateBooking", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Booking).ID = 8
+	})
+	ticketServiceMock.On("ReserveTicket", mock.Anything, uint(1), uint(1), uint(8)).Return(nil)
+	ticketServiceMock.On("ReserveTicket", mock.Anything, uint(2), uint(1), uint(8)).Return(utils.NewAppError(409, utils.CodeTicketNotAvailable, "Ticket not available", ""))
+	ticketServiceMock.On("UpdateTicketStatus", mock.Anything, uint(1), models.TicketStatusAvailable).Return(nil)
+	bookingRepoMock.On("TransitionBookingStatus", mock.Anything, uint(8), []models.BookingStatus{models.BookingStatusPending}, models.BookingStatusCanceled).Return(true, nil)
+
+	result, err := bookingService.CreateBooking(context.Background(), 1, 1, []uint{1, 2})
+
+	assert.Nil(t, result)
+	assert.Equal(t, 409, err.(*utils.AppError).Code)
+	ticketServiceMock.AssertExpectations(t)
+	bookingRepoMock.AssertExpectations(t)
+	kafkaProducerMock.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateBooking_TooManyTickets(t *testing.T) {
+	bookingRepoMock := new(mocks.BookingRepositoryMock)
+	ticketServiceMock := new(mocks.TicketServiceMock)
+	pricingService := services.NewPricingService(new(mocks.PricingRepositoryMock), new(mocks.TicketRepositoryMock))
+	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, pricingService, new(mocks.KafkaProducerMock), newSettingsStore(2, true))
+
+	result, err := bookingService.CreateBooking(context.Background(), 1, 1, []uint{1, 2, 3})
+
+	assert.Nil(t, result)
+	assert.Equal(t, 400, err.(*utils.AppError).Code)
+	ticketServiceMock.AssertNotCalled(t, "GetTicketByID", mock.Anything, mock.Anything)
+}
+
+func TestCreateBooking_DisabledByFeatureToggle(t *testing.T) {
+	bookingRepoMock := new(mocks.BookingRepositoryMock)
+	ticketServiceMock := new(mocks.TicketServiceMock)
+	pricingService := services.NewPricingService(new(mocks.PricingRepositoryMock), new(mocks.TicketRepositoryMock))
+	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, pricingService, new(mocks.KafkaProducerMock), newSettingsStore(10, false))
+
+	result, err := bookingService.CreateBooking(context.Background(), 1, 1, []uint{1})
+
+	assert.Nil(t, result)
+	assert.Equal(t, 503, err.(*utils.AppError).Code)
+	bookingRepoMock.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything)
+}
+
+func TestCreateBooking_MixedCurrencies(t *testing.T) {
+	_, ticketServiceMock, _, bookingService := setupMocks()
+
+	ticketServiceMock.On("GetTicketByID", mock.Anything, uint(1)).Return(&models.Ticket{
+		ID: 1, EventID: 1, Price: money.Money{Amount: 10000, Currency: "USD"}, Status: models.TicketStatusAvailable,
+	}, nil)
+	ticketServiceMock.On("GetTicketByID", mock.Anything, uint(2)).Return(&models.Ticket{
+		ID: 2, EventID: 1, Price: money.Money{Amount: 250000, Currency: "VND"}, Status: models.TicketStatusAvailable,
+	}, nil)
+
+	result, err := bookingService.CreateBooking(context.Background(), 1, 1, []uint{1, 2})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	appErr, ok := err.(*utils.AppError)
+	assert.True(t, ok)
+	assert.Equal(t, 400, appErr.Code)
+	assert.Equal(t, "Mixed currencies", appErr.Message)
+
+	ticketServiceMock.AssertExpectations(t)
+}
+
+func TestCreateBooking_AppliesTenantFee(t *testing.T) {
+	bookingRepoMock, ticketServiceMock, kafkaProducerMock, bookingService := setupMocks()
+
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "brand-a", FeeBps: 500, TopicPrefix: "brand-a."})
+	ticket := &models.Ticket{ID: 1, EventID: 1, Price: money.Money{Amount: 10000, Currency: "USD"}, Status: models.TicketStatusAvailable}
+
+	ticketServiceMock.On("GetTicketByID", mock.Anything, uint(1)).Return(ticket, nil)
+	ticketServiceMock.On("ReserveTicket", mock.Anything, uint(1), uint(1), mock.AnythingOfType("uint")).Return(nil)
+	bookingRepoMock.On("CreateBooking", mock.Anything, mock.Anything).Return(nil)
+	requestCtx := mock.MatchedBy(func(published context.Context) bool {
+		tenant, _ := tenancy.FromContext(published)
+		return tenant.ID == "brand-a"
+	})
+	kafkaProducerMock.On("Publish", requestCtx, "brand-a.booking.created", mock.Anything).Return(nil)
+
+	result, err := bookingService.CreateBooking(ctx, 1, 1, []uint{1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), result.FeeAmount)
+	assert.Equal(t, money.Money{Amount: 10500, Currency: "USD"}, result.TotalAmount)
+	kafkaProducerMock.AssertExpectations(t)
+}
+
+func TestCreateBooking_PublishesEventConsumersCanParse(t *testing.T) {
+	bookingRepoMock, ticketServiceMock, kafkaProducerMock, bookingService := setupMocks()
+
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "brand-a"})
+	for _, id := range []uint{1, 2} {
+		ticket := &models.Ticket{ID: id, EventID: 4, Price: money.Money{Amount: 10000, Currency: "USD"}, Status: models.TicketStatusAvailable}
+		ticketServiceMock.On("GetTicketByID", mock.Anything, id).Return(ticket, nil)
+		ticketServiceMock.On("ReserveTicket", mock.Anything, id, uint(3), uint(9)).Return(nil)
+	}
+	bookingRepoMock.On("CreateBooking", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Booking).ID = 9
+	})
+	var message []byte
+	kafkaProducerMock.On("Publish", mock.Anything, "booking.created", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		// Serialize the message the way the Kafka producer does
+		var err error
+		message, err = json.Marshal(args.Get(2))
+		assert.NoError(t, err)
+	})
+
+	_, err := bookingService.CreateBooking(ctx, 3, 4, []uint{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, kafkaModels.BookingEvent{
+		TenantID:    "brand-a",
+		BookingID:   9,
+		UserID:      3,
+		EventID:     4,
+		TicketIDs:   []uint{1, 2},
+		TotalAmount: money.Money{Amount: 20000, Currency: "USD"},
+	}, kafka.ParseBookingEvent(message))
+}
+
 func TestConfirmBooking_Success(t *testing.T) {
 	bookingRepoMock := new(mocks.BookingRepositoryMock)
 	ticketServiceMock := new(mocks.TicketServiceMock)
 	kafkaProducerMock := new(mocks.KafkaProducerMock)
-	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, kafkaProducerMock)
+	pricingService := services.NewPricingService(new(mocks.PricingRepositoryMock), new(mocks.TicketRepositoryMock))
+	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, pricingService, kafkaProducerMock, newSettingsStore(10, true))
 
 	bookingID := uint(1)
 	mockBooking := &models.Booking{
@@ -97,18 +273,18 @@ func TestConfirmBooking_Success(t *testing.T) {
 	}
 
 	// Mock repository and service calls
-	bookingRepoMock.On("GetBookingByID", bookingID).Return(mockBooking, nil)
-	bookingRepoMock.On("UpdateBookingStatus", bookingID, models.BookingStatusConfirmed).Return(nil)
+	bookingRepoMock.On("GetBookingByID", mock.Anything, bookingID).Return(mockBooking, nil)
+	bookingRepoMock.On("TransitionBookingStatus", mock.Anything, bookingID, models.BookingStatusConfirmed.Sources(), models.BookingStatusConfirmed).Return(true, nil)
 
 	// Mock ticket service calls for UpdateTicketStatus
-	ticketServiceMock.On("UpdateTicketStatus", uint(1), models.TicketStatusSold).Return(nil)
-	ticketServiceMock.On("UpdateTicketStatus", uint(2), models.TicketStatusSold).Return(nil)
+	ticketServiceMock.On("UpdateTicketStatus", mock.Anything, uint(1), models.TicketStatusSold).Return(nil)
+	ticketServiceMock.On("UpdateTicketStatus", mock.Anything, uint(2), models.TicketStatusSold).Return(nil)
 
 	// Mock Kafka publish call
-	kafkaProducerMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	kafkaProducerMock.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Run the test
-	err := bookingService.ConfirmBooking(bookingID)
+	err := bookingService.ConfirmBooking(context.Background(), bookingID)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -121,7 +297,8 @@ func TestCancelBooking_Success(t *testing.T) {
 	bookingRepoMock := new(mocks.BookingRepositoryMock)
 	ticketServiceMock := new(mocks.TicketServiceMock)
 	kafkaProducerMock := new(mocks.KafkaProducerMock)
-	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, kafkaProducerMock)
+	pricingService := services.NewPricingService(new(mocks.PricingRepositoryMock), new(mocks.TicketRepositoryMock))
+	bookingService := services.NewBookingService(bookingRepoMock, ticketServiceMock, pricingService, kafkaProducerMock, newSettingsStore(10, true))
 
 	bookingID := uint(1)
 	mockBooking := &models.Booking{
@@ -133,18 +310,18 @@ func TestCancelBooking_Success(t *testing.T) {
 	}
 
 	// Mock repository and service calls
-	bookingRepoMock.On("GetBookingByID", bookingID).Return(mockBooking, nil)
-	bookingRepoMock.On("UpdateBookingStatus", bookingID, models.BookingStatusCanceled).Return(nil)
+	bookingRepoMock.On("GetBookingByID", mock.Anything, bookingID).Return(mockBooking, nil)
+	bookingRepoMock.On("TransitionBookingStatus", mock.Anything, bookingID, models.BookingStatusCanceled.Sources(), models.BookingStatusCanceled).Return(true, nil)
 
 	// Mock ticket service calls for UpdateTicketStatus
-	ticketServiceMock.On("UpdateTicketStatus", uint(1), models.TicketStatusAvailable).Return(nil)
-	ticketServiceMock.On("UpdateTicketStatus", uint(2), models.TicketStatusAvailable).Return(nil)
+	ticketServiceMock.On("UpdateTicketStatus", mock.Anything, uint(1), models.TicketStatusAvailable).Return(nil)
+	ticketServiceMock.On("UpdateTicketStatus", mock.Anything, uint(2), models.TicketStatusAvailable).Return(nil)
 
 	// Mock Kafka publish call
-	kafkaProducerMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	kafkaProducerMock.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Run the test
-	err := bookingService.CancelBooking(bookingID)
+	err := bookingService.CancelBooking(context.Background(), bookingID)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -153,6 +330,62 @@ func TestCancelBooking_Success(t *testing.T) {
 	kafkaProducerMock.AssertExpectations(t)
 }
 
+func TestUpdateBookingStatus_RejectsUnknownStatus(t *testing.T) {
+	bookingRepoMock, _, _, bookingService := setupMocks()
+
+	err := bookingService.UpdateBookingStatus(context.Background(), 1, "SHIPPED")
+
+	assert.Equal(t, 400, err.(*utils.AppError).Code)
+	bookingRepoMock.AssertNotCalled(t, "TransitionBookingStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExpirePendingBookings_CancelsStaleHolds(t *testing.T) {
+	bookingRepoMock, ticketServiceMock, kafkaProducerMock, bookingService := setupMocks()
+
+	stale := &models.Booking{ID: 7, Status: models.BookingStatusPending, Tickets: []models.Ticket{{ID: 3}}}
+	bookingRepoMock.On("GetPendingBookingsOlderThan", mock.Anything, 15*time.Minute).Return([]models.Booking{*stale}, nil)
+	bookingRepoMock.On("TransitionBookingStatus", mock.Anything, uint(7), []models.BookingStatus{models.BookingStatusPending}, models.BookingStatusCanceled).Return(true, nil)
+	bookingRepoMock.On("GetBookingByID", mock.Anything, uint(7)).Return(stale, nil)
+	ticketServiceMock.On("UpdateTicketStatus", mock.Anything, uint(3), models.TicketStatusAvailable).Return(nil)
+	kafkaProducerMock.On("Publish", mock.Anything, "booking.canceled", mock.Anything).Return(nil)
+
+	expired, err := bookingService.ExpirePendingBookings(context.Background(), 15*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	bookingRepoMock.AssertExpectations(t)
+	ticketServiceMock.AssertExpectations(t)
+	kafkaProducerMock.AssertExpectations(t)
+}
+
+func TestConfirmBooking_ConflictWhenNoLongerPending(t *testing.T) {
+	bookingRepoMock, ticketServiceMock, kafkaProducerMock, bookingService := setupMocks()
+
+	bookingRepoMock.On("TransitionBookingStatus", mock.Anything, uint(7), models.BookingStatusConfirmed.Sources(), models.BookingStatusConfirmed).Return(false, nil)
+	bookingRepoMock.On("GetBookingByID", mock.Anything, uint(7)).Return(&models.Booking{ID: 7, Status: models.BookingStatusCanceled}, nil)
+
+	err := bookingService.ConfirmBooking(context.Background(), 7)
+
+	assert.Equal(t, 409, err.(*utils.AppError).Code)
+	ticketServiceMock.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+	kafkaProducerMock.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExpirePendingBookings_SkipsBookingConfirmedMeanwhile(t *testing.T) {
+	bookingRepoMock, ticketServiceMock, _, bookingService := setupMocks()
+
+	pending := []models.BookingStatus{models.BookingStatusPending}
+	bookingRepoMock.On("GetPendingBookingsOlderThan", mock.Anything, 15*time.Minute).Return([]models.Booking{{ID: 7, Status: models.BookingStatusPending}}, nil)
+	bookingRepoMock.On("TransitionBookingStatus", mock.Anything, uint(7), pending, models.BookingStatusCanceled).Return(false, nil)
+	bookingRepoMock.On("GetBookingByID", mock.Anything, uint(7)).Return(&models.Booking{ID: 7, Status: models.BookingStatusConfirmed}, nil)
+
+	expired, err := bookingService.ExpirePendingBookings(context.Background(), 15*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expired)
+	ticketServiceMock.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestGetBookingByID_Success(t *testing.T) {
 	bookingRepoMock, _, _, bookingService := setupMocks()
 
@@ -161,9 +394,9 @@ func TestGetBookingByID_Success(t *testing.T) {
 		ID: bookingID,
 	}
 
-	bookingRepoMock.On("GetBookingByID", bookingID).Return(mockBooking, nil)
+	bookingRepoMock.On("GetBookingByID", mock.Anything, bookingID).Return(mockBooking, nil)
 
-	result, err := bookingService.GetBookingByID(bookingID)
+	result, err := bookingService.GetBookingByID(context.Background(), bookingID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -176,9 +409,9 @@ func TestGetBookingByID_NotFound(t *testing.T) {
 	bookingRepoMock, _, _, bookingService := setupMocks()
 
 	bookingID := uint(1)
-	bookingRepoMock.On("GetBookingByID", bookingID).Return(nil, nil)
+	bookingRepoMock.On("GetBookingByID", mock.Anything, bookingID).Return(nil, nil)
 
-	result, err := bookingService.GetBookingByID(bookingID)
+	result, err := bookingService.GetBookingByID(context.Background(), bookingID)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -190,3 +423,106 @@ func TestGetBookingByID_NotFound(t *testing.T) {
 
 	bookingRepoMock.AssertExpectations(t)
 }
+
+func TestListBookingsByUserID_ReturnsNextCursor(t *testing.T) {
+	bookingRepoMock, _, _, bookingService := setupMocks()
+
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	bookings := []models.Booking{{ID: 3, CreatedAt: createdAt}, {ID: 2, CreatedAt: createdAt}, {ID: 1, CreatedAt: createdAt}}
+	query := repositories.BookingQuery{Descending: true, Limit: 2}
+	bookingRepoMock.On("CountBookingsByUserID", mock.Anything, uint(1), query).Return(int64(3), nil)
+	bookingRepoMock.On("ListBookingsByUserID", mock.Anything, uint(1), repositories.BookingQuery{Descending: true, Limit: 3}).Return(bookings, nil)
+
+	page, err := bookingService.ListBookingsByUserID(context.Background(), 1, query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Len(t, page.Data, 2)
+	cursor, err := pagination.DecodeCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), cursor.ID)
+	assert.True(t, createdAt.Equal(cursor.CreatedAt))
+}
+
+func TestListBookingsByUserID_LastPageHasNoCursor(t *testing.T) {
+	bookingRepoMock, _, _, bookingService := setupMocks()
+
+	bookingRepoMock.On("CountBookingsByUserID", mock.Anything, uint(1), mock.Anything).Return(int64(0), nil)
+	bookingRepoMock.On("ListBookingsByUserID", mock.Anything, uint(1), repositories.BookingQuery{Limit: pagination.DefaultLimit + 1}).Return([]models.Booking(nil), nil)
+
+	page, err := bookingService.ListBookingsByUserID(context.Background(), 1, repositories.BookingQuery{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.NextCursor)
+	assert.NotNil(t, page.Data)
+}
+
+func TestListBookingsByUserID_RejectsInvalidQueries(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	for name, query := range map[string]repositories.BookingQuery{
+		"limit too large": {Limit: pagination.MaxLimit + 1},
+		"negative limit":  {Limit: -1},
+		"unknown status":  {Status: "LOST"},
+		"inverted range":  {CreatedFrom: from, CreatedTo: from.Add(-time.Hour)},
+	} {
+		bookingRepoMock, _, _, bookingService := setupMocks()
+
+		_, err := bookingService.ListBookingsByUserID(context.Background(), 1, query)
+
+		assert.Equal(t, 400, err.(*utils.AppError).Code, name)
+		bookingRepoMock.AssertNotCalled(t, "ListBookingsByUserID", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestSearchBookings_ReturnsNextCursor(t *testing.T) {
+	bookingRepoMock, _, _, bookingService := setupMocks()
+
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	bookings := []models.Booking{{ID: 3, CreatedAt: createdAt}, {ID: 2, CreatedAt: createdAt}, {ID: 1, CreatedAt: createdAt}}
+	criteria := repositories.BookingCriteria{TicketID: 7, Descending: true, Limit: 2}
+	bookingRepoMock.On("CountSearch", mock.Anything, criteria).Return(int64(3), nil)
+	bookingRepoMock.On("Search", mock.Anything, repositories.BookingCriteria{TicketID: 7, Descending: true, Limit: 3}).Return(bookings, nil)
+
+	page, err := bookingService.SearchBookings(context.Background(), criteria)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Len(t, page.Data, 2)
+	cursor, err := pagination.DecodeCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), cursor.ID)
+}
+
+func TestSearchBookings_DefaultsToTenantCurrency(t *testing.T) {
+	bookingRepoMock, _, _, bookingService := setupMocks()
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "tenant-a", Currency: "EUR"})
+
+	minTotal := int64(1000)
+	expected := repositories.BookingCriteria{TotalCurrency: "EUR", MinTotal: &minTotal, Limit: pagination.DefaultLimit}
+	bookingRepoMock.On("CountSearch", mock.Anything, expected).Return(int64(0), nil)
+	bookingRepoMock.On("Search", mock.Anything, mock.Anything).Return([]models.Booking(nil), nil)
+
+	page, err := bookingService.SearchBookings(ctx, repositories.BookingCriteria{MinTotal: &minTotal})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, page.Data)
+	bookingRepoMock.AssertExpectations(t)
+}
+
+func TestSearchBookings_RejectsInvalidCriteria(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	minTotal, maxTotal := int64(2000), int64(1000)
+	for name, criteria := range map[string]repositories.BookingCriteria{
+		"limit too large": {Limit: pagination.MaxLimit + 1},
+		"unknown status":  {Statuses: []models.BookingStatus{models.BookingStatusPending, "LOST"}},
+		"inverted range":  {CreatedFrom: from, CreatedTo: from.Add(-time.Hour)},
+		"inverted totals": {TotalCurrency: "USD", MinTotal: &minTotal, MaxTotal: &maxTotal},
+	} {
+		bookingRepoMock, _, _, bookingService := setupMocks()
+
+		_, err := bookingService.SearchBookings(context.Background(), criteria)
+
+		assert.Equal(t, 400, err.(*utils.AppError).Code, name)
+		bookingRepoMock.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	}
+}
@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"booking-service/internal/credentials"
+	"booking-service/internal/models"
+	"booking-service/internal/services"
+	"booking-service/test/mocks"
+	"booking-service/utils"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCredentialService(t *testing.T) (*mocks.TicketRepositoryMock, *credentials.Issuer, services.CredentialService) {
+	issuer, err := credentials.NewIssuer(credentials.Config{
+		Algorithm: credentials.AlgorithmEdDSA,
+		ActiveKey: "k1",
+		Keys:      []string{"k1:a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s="},
+	})
+	require.NoError(t, err)
+	ticketRepo := new(mocks.TicketRepositoryMock)
+	return ticketRepo, issuer, services.NewCredentialService(ticketRepo, issuer)
+}
+
+func TestIssueCredential_SignsCurrentVersion(t *testing.T) {
+	ticketRepo, issuer, credentialService := setupCredentialService(t)
+	ticketRepo.On("GetTicketByID", mock.Anything, uint(7)).Return(soldTicket(1), nil)
+
+	credential, err := credentialService.IssueCredential(context.Background(), 7)
+
+	require.NoError(t, err)
+	claims, err := issuer.Verify(credential)
+	require.NoError(t, err)
+	assert.True(t, claims.Admits(soldTicket(1)))
+}
+
+func TestIssueCredential_RejectsUnsoldTicket(t *testing.T) {
+	ticketRepo, _, credentialService := setupCredentialService(t)
+	ticketRepo.On("GetTicketByID", mock.Anything, uint(7)).Return(&models.Ticket{ID: 7, Status: models.TicketStatusReserved}, nil)
+	ticketRepo.On("GetTicketByID", mock.Anything, uint(8)).Return(nil, nil)
+
+	_, err := credentialService.IssueCredential(context.Background(), 7)
+	assert.Equal(t, 409, err.(*utils.AppError).Code)
+
+	_, err = credentialService.IssueCredential(context.Background(), 8)
+	assert.Equal(t, 404, err.(*utils.AppError).Code)
+}
+
+func TestIssueCredential_KeepsRepositoryErrorStatus(t *testing.T) {
+	ticketRepo, _, credentialService := setupCredentialService(t)
+	ticketRepo.On("GetTicketByID", mock.Anything, uint(7)).Return(nil, utils.NewAppError(503, utils.CodeInternal, "Database unavailable", ""))
+
+	_, err := credentialService.IssueCredential(context.Background(), 7)
+	assert.Equal(t, 503, err.(*utils.AppError).Code)
+}
+
+func TestReissueCredential_RevokesPreviousCredential(t *testing.T) {
+	ticketRepo, issuer, credentialService := setupCredentialService(t)
+	reissued := soldTicket(1)
+	reissued.CredentialVersion = 2
+	ticketRepo.On("GetTicketByID", mock.Anything, uint(7)).Return(soldTicket(1), nil)
+	ticketRepo.On("ReissueCredential", mock.Anything, uint(7)).Return(reissued, nil)
+
+	previous, err := credentialService.IssueCredential(context.Background(), 7)
+	require.NoError(t, err)
+	credential, err := credentialService.ReissueCredential(context.Background(), 7)
+	require.NoError(t, err)
+
+	claims, err := issuer.Verify(credential)
+	require.NoError(t, err)
+	assert.True(t, claims.Admits(reissued))
+	previousClaims, err := issuer.Verify(previous)
+	require.NoError(t, err)
+	assert.False(t, previousClaims.Admits(reissued))
+	// Only IssueCredential read the ticket
+	ticketRepo.AssertNumberOfCalls(t, "GetTicketByID", 1)
+}
+
+func TestReissueCredential_ConflictWhenNotSold(t *testing.T) {
+	ticketRepo, _, credentialService := setupCredentialService(t)
+	ticketRepo.On("ReissueCredential", mock.Anything, uint(7)).Return(nil, nil)
+
+	_, err := credentialService.ReissueCredential(context.Background(), 7)
+
+	assert.Equal(t, 409, err.(*utils.AppError).Code)
+	ticketRepo.AssertNotCalled(t, "GetTicketByID", mock.Anything, mock.Anything)
+}
+
+func TestVerifyCredential_AdmitsCurrentVersionOnly(t *testing.T) {
+	ticketRepo, issuer, credentialService := setupCredentialService(t)
+	ticket := soldTicket(1)
+	credential, err := issuer.Issue(ticket)
+	require.NoError(t, err)
+	ticketRepo.On("GetTicketByID", mock.Anything, uint(7)).Return(ticket, nil).Once()
+
+	admitted, err := credentialService.VerifyCredential(context.Background(), credential)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), admitted.ID)
+
+	// Released and sold again since the credential was issued
+	resold := soldTicket(2)
+	resold.CredentialVersion = 3
+	ticketRepo.On("GetTicketByID", mock.Anything, uint(7)).Return(resold, nil).Once()
+	_, err = credentialService.VerifyCredential(context.Background(), credential)
+	assert.Equal(t, 409, err.(*utils.AppError).Code)
+
+	_, err = credentialService.VerifyCredential(context.Background(), credential+"x")
+	assert.Equal(t, 400, err.(*utils.AppError).Code)
+}
@@ -0,0 +1,76 @@
+package services_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/pricing"
+	"booking-service/internal/services"
+	"booking-service/pkg/money"
+	"booking-service/test/mocks"
+	"booking-service/utils"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuoteTicket_WithoutTierUsesStoredPrice(t *testing.T) {
+	pricingRepoMock := new(mocks.PricingRepositoryMock)
+	pricingService := services.NewPricingService(pricingRepoMock, new(mocks.TicketRepositoryMock))
+
+	ticket := &models.Ticket{ID: 1, Price: money.Money{Amount: 5000, Currency: "USD"}}
+
+	record, err := pricingService.QuoteTicket(context.Background(), ticket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pricing.StrategyFixed, record.Strategy)
+	assert.Equal(t, ticket.Price, record.Price)
+	pricingRepoMock.AssertExpectations(t)
+}
+
+func TestQuoteTicket_SellThroughTier(t *testing.T) {
+	pricingRepoMock := new(mocks.PricingRepositoryMock)
+	pricingService := services.NewPricingService(pricingRepoMock, new(mocks.TicketRepositoryMock))
+
+	tierID := uint(7)
+	tier := &models.PricingTier{
+		ID:             tierID,
+		EventID:        1,
+		BasePrice:      money.Money{Amount: 5000, Currency: "USD"},
+		Strategy:       pricing.StrategySellThrough,
+		StrategyConfig: `{"steps":[{"threshold_percent":50,"multiplier_bps":15000}]}`,
+		Event:          models.Event{ID: 1, Date: time.Now().Add(30 * 24 * time.Hour)},
+	}
+	pricingRepoMock.On("GetTierByID", mock.Anything, tierID).Return(tier, nil)
+	pricingRepoMock.On("CountTierInventory", mock.Anything, tierID).Return(int64(10), int64(6), nil)
+
+	ticket := &models.Ticket{ID: 1, TierID: &tierID, Price: tier.BasePrice}
+
+	record, err := pricingService.QuoteTicket(context.Background(), ticket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pricing.StrategySellThrough, record.Strategy)
+	assert.Equal(t, money.Money{Amount: 7500, Currency: "USD"}, record.Price)
+	assert.Equal(t, tier.BasePrice, record.BasePrice)
+	assert.Equal(t, int64(6), record.TierHeld)
+	pricingRepoMock.AssertExpectations(t)
+}
+
+func TestCreateTier_InvalidStrategy(t *testing.T) {
+	pricingRepoMock := new(mocks.PricingRepositoryMock)
+	pricingService := services.NewPricingService(pricingRepoMock, new(mocks.TicketRepositoryMock))
+
+	err := pricingService.CreateTier(context.Background(), &models.PricingTier{
+		EventID:   1,
+		Name:      "VIP",
+		BasePrice: money.Money{Amount: 5000, Currency: "USD"},
+		Strategy:  "auction",
+	})
+
+	var appErr *utils.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, 400, appErr.Code)
+	pricingRepoMock.AssertExpectations(t)
+}
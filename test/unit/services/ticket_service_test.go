@@ -4,8 +4,10 @@ import (
 	"booking-service/internal/models"
 	"booking-service/internal/services"
 	kafkaModels "booking-service/pkg/kafka/models"
+	"booking-service/pkg/money"
 	"booking-service/test/mocks"
 	"booking-service/utils"
+	"context"
 	"errors"
 	"github.com/stretchr/testify/mock"
 	"testing"
@@ -19,7 +21,7 @@ func TestCreateTicketsForEvent_Success(t *testing.T) {
 
 	eventID := uint(1)
 	numTickets := 10
-	price := 50.0
+	price := money.Money{Amount: 5000, Currency: "USD"}
 
 	tickets := make([]models.Ticket, numTickets)
 	for i := 0; i < numTickets; i++ {
@@ -30,9 +32,9 @@ func TestCreateTicketsForEvent_Success(t *testing.T) {
 		}
 	}
 
-	ticketRepoMock.On("CreateTicketsBatch", mock.Anything).Return(nil)
+	ticketRepoMock.On("CreateTicketsBatch", mock.Anything, mock.Anything).Return(nil)
 
-	result, err := ticketService.CreateTicketsForEvent(eventID, numTickets, price)
+	result, err := ticketService.CreateTicketsForEvent(context.Background(), eventID, numTickets, price)
 
 	assert.NoError(t, err)
 	assert.Len(t, result, numTickets)
@@ -43,7 +45,21 @@ func TestCreateTicketsForEvent_InvalidInput(t *testing.T) {
 	ticketRepoMock := new(mocks.TicketRepositoryMock)
 	ticketService := services.NewTicketService(ticketRepoMock)
 
-	_, err := ticketService.CreateTicketsForEvent(1, -1, 50.0)
+	_, err := ticketService.CreateTicketsForEvent(context.Background(), 1, -1, money.Money{Amount: 5000, Currency: "USD"})
+
+	assert.Error(t, err)
+	var appErr *utils.AppError
+	ok := errors.As(err, &appErr)
+	assert.True(t, ok)
+	assert.Equal(t, 400, appErr.Code)
+	ticketRepoMock.AssertExpectations(t)
+}
+
+func TestCreateTicketsForEvent_UnsupportedCurrency(t *testing.T) {
+	ticketRepoMock := new(mocks.TicketRepositoryMock)
+	ticketService := services.NewTicketService(ticketRepoMock)
+
+	_, err := ticketService.CreateTicketsForEvent(context.Background(), 1, 10, money.Money{Amount: 5000, Currency: "XYZ"})
 
 	assert.Error(t, err)
 	var appErr *utils.AppError
@@ -65,10 +81,10 @@ func TestReserveTicket_Success(t *testing.T) {
 		Status: models.TicketStatusAvailable,
 	}
 
-	ticketRepoMock.On("GetTicketByID", ticketID).Return(ticket, nil)
-	ticketRepoMock.On("ReserveTicket", ticketID, userID, bookingID).Return(nil)
+	ticketRepoMock.On("GetTicketByID", mock.Anything, ticketID).Return(ticket, nil)
+	ticketRepoMock.On("ReserveTicket", mock.Anything, ticketID, userID, bookingID).Return(nil)
 
-	err := ticketService.ReserveTicket(ticketID, userID, bookingID)
+	err := ticketService.ReserveTicket(context.Background(), ticketID, userID, bookingID)
 
 	assert.NoError(t, err)
 	ticketRepoMock.AssertExpectations(t)
@@ -86,9 +102,9 @@ func TestReserveTicket_NotAvailable(t *testing.T) {
 		Status: models.TicketStatusSold,
 	}
 
-	ticketRepoMock.On("GetTicketByID", ticketID).Return(ticket, nil)
+	ticketRepoMock.On("GetTicketByID", mock.Anything, ticketID).Return(ticket, nil)
 
-	err := ticketService.ReserveTicket(ticketID, userID, bookingID)
+	err := ticketService.ReserveTicket(context.Background(), ticketID, userID, bookingID)
 
 	assert.Error(t, err)
 	var appErr *utils.AppError
@@ -108,21 +124,21 @@ func TestHandleBookingEvent_BookingCanceled(t *testing.T) {
 	}
 
 	// Mock GetTicketByID for each ticket
-	ticketRepoMock.On("GetTicketByID", uint(1)).Return(&models.Ticket{
+	ticketRepoMock.On("GetTicketByID", mock.Anything, uint(1)).Return(&models.Ticket{
 		ID:     1,
 		Status: models.TicketStatusReserved,
 	}, nil)
-	ticketRepoMock.On("GetTicketByID", uint(2)).Return(&models.Ticket{
+	ticketRepoMock.On("GetTicketByID", mock.Anything, uint(2)).Return(&models.Ticket{
 		ID:     2,
 		Status: models.TicketStatusReserved,
 	}, nil)
 
 	// Mock UpdateTicketStatus for each ticket
-	ticketRepoMock.On("UpdateTicketStatus", uint(1), models.TicketStatusAvailable).Return(nil)
-	ticketRepoMock.On("UpdateTicketStatus", uint(2), models.TicketStatusAvailable).Return(nil)
+	ticketRepoMock.On("UpdateTicketStatus", mock.Anything, uint(1), models.TicketStatusAvailable).Return(nil)
+	ticketRepoMock.On("UpdateTicketStatus", mock.Anything, uint(2), models.TicketStatusAvailable).Return(nil)
 
 	// Execute the test
-	err := ticketService.HandleBookingEvent(eventType, payload)
+	err := ticketService.HandleBookingEvent(context.Background(), eventType, payload)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -139,27 +155,51 @@ func TestHandleBookingEvent_BookingConfirmed(t *testing.T) {
 	}
 
 	// Mock GetTicketByID for each ticket
-	ticketRepoMock.On("GetTicketByID", uint(1)).Return(&models.Ticket{
+	ticketRepoMock.On("GetTicketByID", mock.Anything, uint(1)).Return(&models.Ticket{
 		ID:     1,
 		Status: models.TicketStatusReserved,
 	}, nil)
-	ticketRepoMock.On("GetTicketByID", uint(2)).Return(&models.Ticket{
+	ticketRepoMock.On("GetTicketByID", mock.Anything, uint(2)).Return(&models.Ticket{
 		ID:     2,
 		Status: models.TicketStatusReserved,
 	}, nil)
 
 	// Mock UpdateTicketStatus for each ticket
-	ticketRepoMock.On("UpdateTicketStatus", uint(1), models.TicketStatusSold).Return(nil)
-	ticketRepoMock.On("UpdateTicketStatus", uint(2), models.TicketStatusSold).Return(nil)
+	ticketRepoMock.On("UpdateTicketStatus", mock.Anything, uint(1), models.TicketStatusSold).Return(nil)
+	ticketRepoMock.On("UpdateTicketStatus", mock.Anything, uint(2), models.TicketStatusSold).Return(nil)
 
 	// Execute the test
-	err := ticketService.HandleBookingEvent(eventType, payload)
+	err := ticketService.HandleBookingEvent(context.Background(), eventType, payload)
 
 	// Assertions
 	assert.NoError(t, err)
 	ticketRepoMock.AssertExpectations(t)
 }
 
+func TestHandleBookingEvent_SkipsTicketsAlreadyApplied(t *testing.T) {
+	ticketRepoMock := new(mocks.TicketRepositoryMock)
+	ticketService := services.NewTicketService(ticketRepoMock)
+
+	bookingID, otherBookingID := uint(5), uint(6)
+	payload := kafkaModels.BookingEvent{BookingID: bookingID, TicketIDs: []uint{1, 2}}
+
+	// Confirming the booking marked its ticket sold before the event was consumed
+	ticketRepoMock.On("GetTicketByID", mock.Anything, uint(1)).Return(&models.Ticket{
+		ID: 1, Status: models.TicketStatusSold, BookingID: &bookingID,
+	}, nil).Once()
+	ticketRepoMock.On("GetTicketByID", mock.Anything, uint(2)).Return(&models.Ticket{
+		ID: 2, Status: models.TicketStatusReserved, BookingID: &otherBookingID,
+	}, nil)
+	assert.NoError(t, ticketService.HandleBookingEvent(context.Background(), "booking.confirmed", payload))
+
+	ticketRepoMock.On("GetTicketByID", mock.Anything, uint(1)).Return(&models.Ticket{
+		ID: 1, Status: models.TicketStatusAvailable,
+	}, nil)
+	assert.NoError(t, ticketService.HandleBookingEvent(context.Background(), "booking.canceled", payload))
+
+	ticketRepoMock.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestHandleBookingEvent_UnhandledEvent(t *testing.T) {
 	ticketRepoMock := new(mocks.TicketRepositoryMock)
 	ticketService := services.NewTicketService(ticketRepoMock)
@@ -169,7 +209,7 @@ func TestHandleBookingEvent_UnhandledEvent(t *testing.T) {
 		TicketIDs: []uint{1, 2},
 	}
 
-	err := ticketService.HandleBookingEvent(eventType, payload)
+	err := ticketService.HandleBookingEvent(context.Background(), eventType, payload)
 
 	assert.NoError(t, err)
 	ticketRepoMock.AssertExpectations(t)
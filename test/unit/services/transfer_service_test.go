@@ -0,0 +1,236 @@
+package services_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/services"
+	"booking-service/internal/settings"
+	kafkaModels "booking-service/pkg/kafka/models"
+	"booking-service/test/mocks"
+	"booking-service/utils"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type transferMocks struct {
+	transferRepo  *mocks.TransferRepositoryMock
+	ticketService *mocks.TicketServiceMock
+	eventService  *mocks.EventServiceMock
+	producer      *mocks.KafkaProducerMock
+}
+
+func setupTransferMocks() (transferMocks, services.TransferService) {
+	m := transferMocks{
+		transferRepo:  new(mocks.TransferRepositoryMock),
+		ticketService: new(mocks.TicketServiceMock),
+		eventService:  new(mocks.EventServiceMock),
+		producer:      new(mocks.KafkaProducerMock),
+	}
+	store := settings.NewStore(&settings.Settings{
+		HoldTTL:        15 * time.Minute,
+		TransferTTL:    48 * time.Hour,
+		TransferCutoff: 24 * time.Hour,
+	})
+	return m, services.NewTransferService(m.transferRepo, m.ticketService, m.eventService, m.producer, store)
+}
+
+func soldTicket(ownerID uint) *models.Ticket {
+	return &models.Ticket{ID: 7, EventID: 3, Status: models.TicketStatusSold, UserID: &ownerID, CredentialVersion: 1}
+}
+
+func eventIn(d time.Duration) *models.Event {
+	return &models.Event{ID: 3, Date: time.Now().Add(d)}
+}
+
+func TestInitiateTransfer_StoresTokenHash(t *testing.T) {
+	m, transferService := setupTransferMocks()
+	m.ticketService.On("GetTicketByID", mock.Anything, uint(7)).Return(soldTicket(1), nil)
+	m.eventService.On("GetEventByID", mock.Anything, uint(3)).Return(eventIn(10*24*time.Hour), nil)
+	var stored *models.TicketTransfer
+	m.transferRepo.On("CreateTransfer", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*models.TicketTransfer)
+	}).Return(nil)
+
+	transfer, token, err := transferService.InitiateTransfer(context.Background(), 7, nil)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	sum := sha256.Sum256([]byte(token))
+	assert.Equal(t, hex.EncodeToString(sum[:]), stored.TokenHash)
+	assert.Equal(t, uint(1), transfer.FromUserID)
+	assert.Equal(t, models.TicketTransferPending, transfer.Status)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), transfer.ExpiresAt, time.Minute)
+}
+
+func TestInitiateTransfer_ExpiresAtCutoff(t *testing.T) {
+	m, transferService := setupTransferMocks()
+	m.ticketService.On("GetTicketByID", mock.Anything, uint(7)).Return(soldTicket(1), nil)
+	event := eventIn(30 * time.Hour)
+	m.eventService.On("GetEventByID", mock.Anything, uint(3)).Return(event, nil)
+	m.transferRepo.On("CreateTransfer", mock.Anything, mock.Anything).Return(nil)
+
+	transfer, _, err := transferService.InitiateTransfer(context.Background(), 7, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, event.Date.Add(-24*time.Hour), transfer.ExpiresAt)
+}
+
+func TestInitiateTransfer_Rejected(t *testing.T) {
+	reserved := soldTicket(1)
+	reserved.Status = models.TicketStatusReserved
+	owner := uint(1)
+	for name, tc := range map[string]struct {
+		ticket   *models.Ticket
+		event    *models.Event
+		toUserID *uint
+		code     int
+	}{
+		"not sold":       {ticket: reserved, event: eventIn(10 * 24 * time.Hour), code: 409},
+		"within cutoff":  {ticket: soldTicket(1), event: eventIn(2 * time.Hour), code: 409},
+		"event over":     {ticket: soldTicket(1), event: eventIn(-time.Hour), code: 409},
+		"to their owner": {ticket: soldTicket(1), event: eventIn(10 * 24 * time.Hour), toUserID: &owner, code: 400},
+	} {
+		m, transferService := setupTransferMocks()
+		m.ticketService.On("GetTicketByID", mock.Anything, uint(7)).Return(tc.ticket, nil)
+		m.eventService.On("GetEventByID", mock.Anything, uint(3)).Return(tc.event, nil)
+
+		_, _, err := transferService.InitiateTransfer(context.Background(), 7, tc.toUserID)
+
+		var appErr *utils.AppError
+		if assert.ErrorAs(t, err, &appErr, name) {
+			assert.Equal(t, tc.code, appErr.Code, name)
+		}
+		m.transferRepo.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything)
+	}
+}
+
+func pendingTransfer() *models.TicketTransfer {
+	return &models.TicketTransfer{
+		ID:         5,
+		TicketID:   7,
+		FromUserID: 1,
+		Status:     models.TicketTransferPending,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func TestAcceptTransfer_MovesOwnershipAndPublishes(t *testing.T) {
+	m, transferService := setupTransferMocks()
+	transfer := pendingTransfer()
+	m.transferRepo.On("GetTransferByTokenHash", mock.Anything, mock.Anything).Return(transfer, nil)
+	m.ticketService.On("GetTicketByID", mock.Anything, uint(7)).Return(soldTicket(1), nil)
+	m.eventService.On("GetEventByID", mock.Anything, uint(3)).Return(eventIn(10*24*time.Hour), nil)
+	transferred := soldTicket(2)
+	transferred.CredentialVersion = 2
+	m.transferRepo.On("CompleteTransfer", mock.Anything, transfer, uint(2)).Return(transferred, nil)
+	m.producer.On("Publish", mock.Anything, models.EventTicketTransferred, kafkaModels.TicketTransferredEvent{
+		TicketID:          7,
+		EventID:           3,
+		TransferID:        5,
+		FromUserID:        1,
+		ToUserID:          2,
+		CredentialVersion: 2,
+	}).Return(nil)
+
+	ticket, err := transferService.AcceptTransfer(context.Background(), "token", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), *ticket.UserID)
+	m.transferRepo.AssertExpectations(t)
+	m.producer.AssertExpectations(t)
+}
+
+func TestAcceptTransfer_SenderCannotCancelBookingAfterwards(t *testing.T) {
+	m, transferService := setupTransferMocks()
+	transfer := pendingTransfer()
+	m.transferRepo.On("GetTransferByTokenHash", mock.Anything, mock.Anything).Return(transfer, nil)
+	m.ticketService.On("GetTicketByID", mock.Anything, uint(7)).Return(soldTicket(1), nil)
+	m.eventService.On("GetEventByID", mock.Anything, uint(3)).Return(eventIn(10*24*time.Hour), nil)
+	m.transferRepo.On("CompleteTransfer", mock.Anything, transfer, uint(2)).Return(soldTicket(2), nil)
+	m.producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ticket, err := transferService.AcceptTransfer(context.Background(), "token", 2)
+	assert.NoError(t, err)
+
+	// The sender's booking still lists the ticket, now owned by the recipient
+	bookingRepoMock := new(mocks.BookingRepositoryMock)
+	bookingService := services.NewBookingService(bookingRepoMock, m.ticketService, nil, m.producer, newSettingsStore(10, true))
+	bookingID := uint(4)
+	ticket.BookingID = &bookingID
+	bookingRepoMock.On("GetBookingByID", mock.Anything, bookingID).Return(&models.Booking{
+		ID: bookingID, UserID: 1, Status: models.BookingStatusConfirmed, Tickets: []models.Ticket{*ticket},
+	}, nil)
+
+	err = bookingService.CancelBooking(context.Background(), bookingID)
+
+	var appErr *utils.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 409, appErr.Code)
+	bookingRepoMock.AssertNotCalled(t, "TransitionBookingStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.ticketService.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAcceptTransfer_Expired(t *testing.T) {
+	m, transferService := setupTransferMocks()
+	transfer := pendingTransfer()
+	transfer.ExpiresAt = time.Now().Add(-time.Minute)
+	m.transferRepo.On("GetTransferByTokenHash", mock.Anything, mock.Anything).Return(transfer, nil)
+	m.transferRepo.On("SettleTransfer", mock.Anything, uint(5), models.TicketTransferExpired).Return(true, nil)
+
+	_, err := transferService.AcceptTransfer(context.Background(), "token", 2)
+
+	var appErr *utils.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 410, appErr.Code)
+	m.transferRepo.AssertExpectations(t)
+	m.transferRepo.AssertNotCalled(t, "CompleteTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAcceptTransfer_AddressedToAnotherUser(t *testing.T) {
+	m, transferService := setupTransferMocks()
+	transfer := pendingTransfer()
+	recipient := uint(3)
+	transfer.ToUserID = &recipient
+	m.transferRepo.On("GetTransferByTokenHash", mock.Anything, mock.Anything).Return(transfer, nil)
+
+	_, err := transferService.AcceptTransfer(context.Background(), "token", 2)
+
+	var appErr *utils.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 403, appErr.Code)
+	m.transferRepo.AssertNotCalled(t, "CompleteTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAcceptTransfer_SenderNoLongerOwnsTicket(t *testing.T) {
+	m, transferService := setupTransferMocks()
+	transfer := pendingTransfer()
+	m.transferRepo.On("GetTransferByTokenHash", mock.Anything, mock.Anything).Return(transfer, nil)
+	m.ticketService.On("GetTicketByID", mock.Anything, uint(7)).Return(soldTicket(4), nil)
+	m.eventService.On("GetEventByID", mock.Anything, uint(3)).Return(eventIn(10*24*time.Hour), nil)
+	m.transferRepo.On("CompleteTransfer", mock.Anything, transfer, uint(2)).Return(nil, nil)
+	m.transferRepo.On("SettleTransfer", mock.Anything, uint(5), models.TicketTransferCanceled).Return(true, nil)
+
+	_, err := transferService.AcceptTransfer(context.Background(), "token", 2)
+
+	var appErr *utils.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 409, appErr.Code)
+	m.transferRepo.AssertExpectations(t)
+	m.producer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelTransfer_NotPending(t *testing.T) {
+	m, transferService := setupTransferMocks()
+	m.transferRepo.On("SettleTransfer", mock.Anything, uint(5), models.TicketTransferCanceled).Return(false, nil)
+
+	err := transferService.CancelTransfer(context.Background(), 5)
+
+	var appErr *utils.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 409, appErr.Code)
+}
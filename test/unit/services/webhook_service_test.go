@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/services"
+	"booking-service/internal/webhooks"
+	"booking-service/test/mocks"
+	"booking-service/utils"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// staticResolver answers lookups from a fixed table instead of DNS.
+type staticResolver map[string][]string
+
+func (r staticResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	addrs := make([]net.IPAddr, len(ips))
+	for i, ip := range ips {
+		addrs[i] = net.IPAddr{IP: net.ParseIP(ip)}
+	}
+	return addrs, nil
+}
+
+func newWebhookService(webhookRepo *mocks.WebhookRepositoryMock) services.WebhookService {
+	return services.NewWebhookService(webhookRepo, webhooks.TargetPolicy{Resolver: staticResolver{
+		"partner.example":  {"203.0.113.10"},
+		"internal.example": {"203.0.113.11", "10.0.0.5"},
+	}})
+}
+
+func TestCreateSubscription_GeneratesSecret(t *testing.T) {
+	webhookRepoMock := new(mocks.WebhookRepositoryMock)
+	webhookService := newWebhookService(webhookRepoMock)
+	webhookRepoMock.On("CreateSubscription", mock.Anything, mock.Anything).Return(nil)
+
+	subscription := &models.WebhookSubscription{URL: "https://partner.example/hooks"}
+	err := webhookService.CreateSubscription(context.Background(), subscription)
+
+	assert.NoError(t, err)
+	assert.Len(t, subscription.Secret, 64)
+	assert.True(t, subscription.Active)
+	webhookRepoMock.AssertExpectations(t)
+}
+
+func TestCreateSubscription_Validates(t *testing.T) {
+	for name, subscription := range map[string]models.WebhookSubscription{
+		"relative url":  {URL: "/hooks"},
+		"other scheme":  {URL: "ftp://partner.example/hooks"},
+		"unknown event": {URL: "https://partner.example/hooks", Events: models.EventFilter{"booking.exploded"}},
+		"short secret":  {URL: "https://partner.example/hooks", Secret: "hunter2"},
+		"loopback":      {URL: "http://127.0.0.1:8080/hooks"},
+		"metadata":      {URL: "http://169.254.169.254/latest/meta-data"},
+		"private ipv6":  {URL: "http://[fd00::1]/hooks"},
+		"private host":  {URL: "https://internal.example/hooks"},
+		"unknown host":  {URL: "https://missing.example/hooks"},
+	} {
+		webhookRepoMock := new(mocks.WebhookRepositoryMock)
+		webhookService := newWebhookService(webhookRepoMock)
+
+		err := webhookService.CreateSubscription(context.Background(), &subscription)
+
+		var appErr *utils.AppError
+		if assert.ErrorAs(t, err, &appErr, name) {
+			assert.Equal(t, 400, appErr.Code, name)
+		}
+		webhookRepoMock.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+	}
+}
+
+func TestListDeliveries_UnknownSubscription(t *testing.T) {
+	webhookRepoMock := new(mocks.WebhookRepositoryMock)
+	webhookService := newWebhookService(webhookRepoMock)
+	webhookRepoMock.On("GetSubscription", mock.Anything, uint(3)).Return(nil, nil)
+
+	_, err := webhookService.ListDeliveries(context.Background(), 3, "", 20)
+
+	var appErr *utils.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 404, appErr.Code)
+
+	_, err = webhookService.ListDeliveries(context.Background(), 3, "LOST", 20)
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 400, appErr.Code)
+}
+
+func TestRedeliver_NotFound(t *testing.T) {
+	webhookRepoMock := new(mocks.WebhookRepositoryMock)
+	webhookService := newWebhookService(webhookRepoMock)
+	webhookRepoMock.On("Redeliver", mock.Anything, uint(3), uint(9)).Return(nil, nil)
+
+	_, err := webhookService.Redeliver(context.Background(), 3, 9)
+
+	var appErr *utils.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, 404, appErr.Code)
+}
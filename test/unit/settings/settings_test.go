@@ -0,0 +1,120 @@
+package settings_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/settings"
+	"booking-service/test/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var ctx = context.Background()
+
+func baseSettings() *settings.Settings {
+	return &settings.Settings{
+		HoldTTL:              15 * time.Minute,
+		MaxTicketsPerBooking: 10,
+		TransferTTL:          48 * time.Hour,
+		RateLimit:            settings.RateLimit{RequestsPerSecond: 20, Burst: 40},
+		Features:             map[string]bool{settings.FeatureBookings: true},
+	}
+}
+
+// staticSource returns fixed settings, like a config file that was edited.
+func staticSource(s *settings.Settings) settings.Source {
+	return func(context.Context, *settings.Settings) (*settings.Settings, error) {
+		return s.Clone(), nil
+	}
+}
+
+func TestStore_ReloadSwapsValidSettings(t *testing.T) {
+	edited := baseSettings()
+	edited.HoldTTL = 5 * time.Minute
+	store := settings.NewStore(baseSettings(), staticSource(edited))
+	before := store.Current()
+
+	assert.NoError(t, store.Reload(ctx))
+
+	assert.Equal(t, 5*time.Minute, store.Current().HoldTTL)
+	// Snapshots already handed out are not modified
+	assert.Equal(t, 15*time.Minute, before.HoldTTL)
+}
+
+func TestStore_ReloadRejectsInvalidSettings(t *testing.T) {
+	edited := baseSettings()
+	edited.HoldTTL = 0
+	edited.RateLimit.Burst = 0
+	store := settings.NewStore(baseSettings(), staticSource(edited))
+
+	err := store.Reload(ctx)
+
+	assert.ErrorContains(t, err, "hold_ttl: must be positive")
+	assert.ErrorContains(t, err, "rate_limit.burst")
+	assert.Equal(t, 15*time.Minute, store.Current().HoldTTL)
+}
+
+func TestStore_ReloadKeepsSettingsWhenSourceFails(t *testing.T) {
+	failing := func(context.Context, *settings.Settings) (*settings.Settings, error) {
+		return nil, errors.New("file is gone")
+	}
+	store := settings.NewStore(baseSettings(), failing)
+
+	assert.ErrorContains(t, store.Reload(ctx), "file is gone")
+	assert.Equal(t, 10, store.Current().MaxTicketsPerBooking)
+}
+
+func TestDatabaseSource_OverridesSettings(t *testing.T) {
+	repo := new(mocks.SettingsRepositoryMock)
+	repo.On("ListSettings", mock.Anything).Return([]models.RuntimeSetting{
+		{Key: "max_tickets_per_booking", Value: "4"},
+		{Key: "features.bookings", Value: "false"},
+	}, nil)
+	store := settings.NewStore(baseSettings(), staticSource(baseSettings()), settings.DatabaseSource(repo))
+
+	assert.NoError(t, store.Reload(ctx))
+
+	assert.Equal(t, 4, store.Current().MaxTicketsPerBooking)
+	assert.False(t, store.Current().Enabled(settings.FeatureBookings))
+	assert.Equal(t, 15*time.Minute, store.Current().HoldTTL)
+}
+
+func TestDatabaseSource_RejectsUnknownKeys(t *testing.T) {
+	repo := new(mocks.SettingsRepositoryMock)
+	repo.On("ListSettings", mock.Anything).Return([]models.RuntimeSetting{{Key: "hold_time", Value: "1m"}}, nil)
+	store := settings.NewStore(baseSettings(), settings.DatabaseSource(repo))
+
+	assert.ErrorContains(t, store.Reload(ctx), `unknown runtime setting "hold_time"`)
+}
+
+func TestSettings_Set(t *testing.T) {
+	s := baseSettings()
+
+	assert.NoError(t, s.Set("hold_ttl", "90s"))
+	assert.NoError(t, s.Set("transfer_cutoff", "0s"))
+	assert.NoError(t, s.Set("rate_limit.requests_per_second", "2.5"))
+	assert.NoError(t, s.Set("features.waitlist", "true"))
+	assert.Error(t, s.Set("rate_limit.burst", "many"))
+
+	assert.Equal(t, 90*time.Second, s.HoldTTL)
+	assert.Zero(t, s.TransferCutoff)
+	assert.Equal(t, 2.5, s.RateLimit.RequestsPerSecond)
+	assert.True(t, s.Enabled("waitlist"))
+	assert.True(t, s.Enabled("unlisted"))
+}
+
+func TestDiff_ListsChangedKeys(t *testing.T) {
+	old := baseSettings()
+	next := old.Clone()
+	next.MaxTicketsPerBooking = 6
+	next.Features["waitlist"] = true
+
+	assert.Equal(t, []settings.Change{
+		{Key: "features.waitlist", From: "", To: "true"},
+		{Key: "max_tickets_per_booking", From: "10", To: "6"},
+	}, settings.Diff(old, next))
+}
@@ -0,0 +1,114 @@
+package tracing_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/outbox"
+	"booking-service/internal/repositories"
+	"booking-service/pkg/tracing"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupRecorder() (*tracetest.SpanRecorder, trace.TracerProvider) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder, provider
+}
+
+func TestSetup_StdoutExporter(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: "booking-service",
+		Exporter:    tracing.ExporterStdout,
+		SampleRatio: 1,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "zipkin"})
+
+	assert.Error(t, err)
+}
+
+func TestChildOnly_SkipsRootSpans(t *testing.T) {
+	recorder, provider := setupRecorder()
+	tracer := tracing.ChildOnly(provider).Tracer("test")
+
+	_, root := tracer.Start(context.Background(), "poll")
+	root.End()
+	assert.False(t, root.IsRecording())
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	_, child := tracer.Start(ctx, "query")
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "query", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+}
+
+// fakeOutboxRepository keeps messages in memory.
+type fakeOutboxRepository struct {
+	repositories.OutboxRepository // methods the relay does not use
+	messages                      []models.OutboxMessage
+}
+
+func (r *fakeOutboxRepository) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	r.messages = append(r.messages, *message)
+	return nil
+}
+
+func (r *fakeOutboxRepository) ProcessPending(ctx context.Context, limit, maxAttempts int, publish func(message models.OutboxMessage) error) (int, error) {
+	sent := 0
+	for _, message := range r.messages {
+		if err := publish(message); err == nil {
+			sent++
+		}
+	}
+	r.messages = nil
+	return sent, nil
+}
+
+// contextProducer records the context each message was published with.
+type contextProducer struct {
+	contexts []context.Context
+}
+
+func (p *contextProducer) Publish(ctx context.Context, topic string, message interface{}) error {
+	p.contexts = append(p.contexts, ctx)
+	return nil
+}
+
+func (p *contextProducer) Close() error { return nil }
+
+func TestOutbox_PropagatesTraceToRelay(t *testing.T) {
+	_, provider := setupRecorder()
+	repo := &fakeOutboxRepository{}
+	kafkaProducer := &contextProducer{}
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "POST /api/bookings")
+	assert.NoError(t, outbox.NewProducer(repo).Publish(ctx, "booking.created", map[string]uint{"id": 1}))
+	span.End()
+
+	relay := outbox.NewRelay(repo, kafkaProducer, outbox.RelayConfig{Interval: time.Second, BatchSize: 10, MaxAttempts: 3})
+	sent, err := relay.Flush(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	relayed := trace.SpanContextFromContext(kafkaProducer.contexts[0])
+	assert.Equal(t, span.SpanContext().TraceID(), relayed.TraceID())
+	assert.True(t, relayed.IsRemote())
+}
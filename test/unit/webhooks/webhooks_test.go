@@ -0,0 +1,247 @@
+package webhooks_test
+
+import (
+	"booking-service/internal/models"
+	"booking-service/internal/tenancy"
+	"booking-service/internal/webhooks"
+	"booking-service/test/mocks"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"booking.created"}`)
+	now := time.Unix(1792368000, 0)
+	signature := webhooks.Sign("secret", now, body)
+
+	assert.Regexp(t, `^t=1792368000,v1=[0-9a-f]{64}$`, signature)
+	assert.NoError(t, webhooks.Verify("secret", signature, body, 5*time.Minute, now.Add(time.Minute)))
+	assert.ErrorIs(t, webhooks.Verify("other", signature, body, 5*time.Minute, now), webhooks.ErrInvalidSignature)
+	assert.ErrorIs(t, webhooks.Verify("secret", signature, []byte(`{}`), 5*time.Minute, now), webhooks.ErrInvalidSignature)
+	assert.ErrorIs(t, webhooks.Verify("secret", signature, body, 5*time.Minute, now.Add(time.Hour)), webhooks.ErrInvalidSignature, "replays are rejected")
+	assert.ErrorIs(t, webhooks.Verify("secret", "garbage", body, 5*time.Minute, now), webhooks.ErrInvalidSignature)
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, want := range map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		3: 2 * time.Minute,
+		8: 10 * time.Minute,
+	} {
+		assert.Equal(t, want, webhooks.Backoff(attempt, 30*time.Second, 10*time.Minute), "attempt %d", attempt)
+	}
+}
+
+func newDispatcher(repo *mocks.WebhookRepositoryMock) *webhooks.Dispatcher {
+	return webhooks.NewDispatcher(repo, webhooks.DispatcherConfig{
+		Workers:     2,
+		Interval:    time.Second,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		Timeout:     time.Second,
+		MinBackoff:  time.Minute,
+		MaxBackoff:  time.Hour,
+
+		// The test servers listen on loopback
+		AllowPrivateTargets: true,
+	})
+}
+
+func TestDispatch_SignsAndRecordsOutcomes(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received = map[string]*http.Request{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, webhooks.Verify("partner-secret", r.Header.Get(webhooks.HeaderSignature), body, time.Minute, time.Now()))
+		mu.Lock()
+		received[r.URL.Path] = r
+		mu.Unlock()
+		if r.URL.Path == "/broken" {
+			http.Error(w, "database is down", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subscription := func(path string) models.WebhookSubscription {
+		return models.WebhookSubscription{ID: 1, URL: server.URL + path, Secret: "partner-secret"}
+	}
+	repo := new(mocks.WebhookRepositoryMock)
+	repo.On("ClaimDue", mock.Anything, 10, 6*time.Second).Return([]models.WebhookDelivery{
+		{ID: 1, EventType: models.EventBookingCreated, Payload: `{"type":"booking.created"}`, Subscription: subscription("/ok")},
+		{ID: 2, EventType: models.EventBookingCanceled, Payload: `{}`, Attempts: 1, Subscription: subscription("/broken")},
+		{ID: 3, EventType: models.EventBookingCanceled, Payload: `{}`, Attempts: 2, Subscription: subscription("/broken")},
+	}, nil).Once()
+
+	recorded := map[uint]models.WebhookDelivery{}
+	repo.On("RecordAttempt", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		delivery := args.Get(1).(*models.WebhookDelivery)
+		mu.Lock()
+		recorded[delivery.ID] = *delivery
+		mu.Unlock()
+	}).Return(nil)
+
+	start := time.Now()
+	delivered, err := newDispatcher(repo).Dispatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, models.EventBookingCreated, received["/ok"].Header.Get(webhooks.HeaderEvent))
+	assert.Equal(t, "1", received["/ok"].Header.Get(webhooks.HeaderDelivery))
+
+	assert.Equal(t, models.WebhookDeliveryDelivered, recorded[1].Status)
+	assert.Equal(t, http.StatusNoContent, recorded[1].ResponseStatus)
+	assert.NotNil(t, recorded[1].DeliveredAt)
+
+	retry := recorded[2]
+	assert.Equal(t, models.WebhookDeliveryStatus(""), retry.Status, "status is left pending")
+	assert.Equal(t, 2, retry.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, retry.ResponseStatus)
+	assert.Equal(t, "endpoint answered 500 Internal Server Error", retry.LastError, "bodies of private endpoints are not kept")
+	assert.WithinDuration(t, start.Add(2*time.Minute), retry.NextAttemptAt, 5*time.Second, "second failure waits twice the minimum")
+
+	assert.Equal(t, models.WebhookDeliveryFailed, recorded[3].Status, "gives up after max attempts")
+	repo.AssertExpectations(t)
+}
+
+func TestDispatch_RefusesPrivateTargets(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	repo := new(mocks.WebhookRepositoryMock)
+	repo.On("ClaimDue", mock.Anything, 10, mock.Anything).Return([]models.WebhookDelivery{
+		{ID: 1, Payload: `{}`, Subscription: models.WebhookSubscription{URL: server.URL}},
+	}, nil).Once()
+	var recorded models.WebhookDelivery
+	repo.On("RecordAttempt", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = *args.Get(1).(*models.WebhookDelivery)
+	}).Return(nil)
+
+	dispatcher := webhooks.NewDispatcher(repo, webhooks.DispatcherConfig{
+		Workers: 1, Interval: time.Second, BatchSize: 10, MaxAttempts: 3,
+		Timeout: time.Second, MinBackoff: time.Minute, MaxBackoff: time.Hour,
+	})
+	delivered, err := dispatcher.Dispatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.False(t, called)
+	assert.Contains(t, recorded.LastError, webhooks.ErrPrivateTarget.Error())
+}
+
+func TestDispatch_DoesNotFollowRedirects(t *testing.T) {
+	redirected := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			redirected = true
+			return
+		}
+		http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	repo := new(mocks.WebhookRepositoryMock)
+	repo.On("ClaimDue", mock.Anything, 10, 6*time.Second).Return([]models.WebhookDelivery{
+		{ID: 1, Payload: `{}`, Subscription: models.WebhookSubscription{URL: server.URL + "/hook"}},
+	}, nil).Once()
+	var recorded models.WebhookDelivery
+	repo.On("RecordAttempt", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = *args.Get(1).(*models.WebhookDelivery)
+	}).Return(nil)
+
+	delivered, err := newDispatcher(repo).Dispatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.False(t, redirected)
+	assert.Equal(t, http.StatusTemporaryRedirect, recorded.ResponseStatus)
+}
+
+func TestIsPublic(t *testing.T) {
+	for ip, want := range map[string]bool{
+		"203.0.113.10":    true,
+		"2001:db8::1":     true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"::ffff:10.0.0.1": false,
+	} {
+		assert.Equal(t, want, webhooks.IsPublic(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestDispatch_ReturnsClaimErrors(t *testing.T) {
+	repo := new(mocks.WebhookRepositoryMock)
+	repo.On("ClaimDue", mock.Anything, 10, 6*time.Second).Return(nil, errors.New("database unavailable"))
+
+	_, err := newDispatcher(repo).Dispatch(context.Background())
+
+	assert.EqualError(t, err, "database unavailable")
+}
+
+func TestProducer_QueuesPublishedEvents(t *testing.T) {
+	next := new(mocks.KafkaProducerMock)
+	repo := new(mocks.WebhookRepositoryMock)
+	producer := webhooks.NewProducer(next, repo)
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{ID: "tenant-a", TopicPrefix: "tenant-a."})
+	booking := &models.Booking{ID: 7, Status: models.BookingStatusConfirmed}
+
+	next.On("Publish", mock.Anything, "tenant-a.booking.confirmed", booking).Return(nil)
+	var payload string
+	repo.On("EnqueueDeliveries", mock.Anything, models.EventBookingConfirmed, mock.Anything).Run(func(args mock.Arguments) {
+		payload = args.String(2)
+	}).Return(1, nil)
+
+	require.NoError(t, producer.Publish(ctx, "tenant-a.booking.confirmed", booking))
+
+	var event webhooks.Event
+	require.NoError(t, json.Unmarshal([]byte(payload), &event))
+	assert.Equal(t, models.EventBookingConfirmed, event.Type)
+	assert.Equal(t, "tenant-a", event.TenantID)
+	assert.JSONEq(t, `7`, string(mustField(t, event.Data, "id")))
+	next.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestProducer_SkipsWebhooksWhenPublishFails(t *testing.T) {
+	next := new(mocks.KafkaProducerMock)
+	repo := new(mocks.WebhookRepositoryMock)
+	next.On("Publish", mock.Anything, "booking.created", mock.Anything).Return(errors.New("outbox unavailable"))
+
+	err := webhooks.NewProducer(next, repo).Publish(context.Background(), "booking.created", &models.Booking{})
+
+	assert.EqualError(t, err, "outbox unavailable")
+	repo.AssertNotCalled(t, "EnqueueDeliveries", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func mustField(t *testing.T, data json.RawMessage, key string) json.RawMessage {
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+	return fields[key]
+}
@@ -0,0 +1,89 @@
+package utils
+
+// ErrorCode is the stable machine-readable code of an error, rendered as the
+// code of problem responses and the reason of gRPC ErrorInfo details. Titles
+// are for humans and may be reworded; codes are part of the API contract.
+type ErrorCode string
+
+// Authentication and tenancy
+const (
+	CodeUnauthorized       ErrorCode = "unauthorized"
+	CodeMissingBearerToken ErrorCode = "missing_bearer_token"
+	CodeInvalidToken       ErrorCode = "invalid_token"
+	CodeForbidden          ErrorCode = "forbidden"
+	CodeTenantRequired     ErrorCode = "tenant_required"
+	CodeTenantMismatch     ErrorCode = "tenant_mismatch"
+	CodeUnknownTenant      ErrorCode = "unknown_tenant"
+)
+
+// Malformed or invalid requests
+const (
+	CodeInvalidRequest          ErrorCode = "invalid_request"
+	CodeInvalidRequestPayload   ErrorCode = "invalid_request_payload"
+	CodeInvalidInput            ErrorCode = "invalid_input"
+	CodeInvalidQueryParameter   ErrorCode = "invalid_query_parameter"
+	CodeInvalidSearchParameters ErrorCode = "invalid_search_parameters"
+	CodeInvalidBookingID        ErrorCode = "invalid_booking_id"
+	CodeInvalidEventID          ErrorCode = "invalid_event_id"
+	CodeInvalidTicketID         ErrorCode = "invalid_ticket_id"
+	CodeInvalidTierID           ErrorCode = "invalid_tier_id"
+	CodeInvalidTransferID       ErrorCode = "invalid_transfer_id"
+	CodeInvalidUserID           ErrorCode = "invalid_user_id"
+	CodeInvalidSubscriptionID   ErrorCode = "invalid_subscription_id"
+	CodeInvalidDeliveryID       ErrorCode = "invalid_delivery_id"
+	CodeInvalidTicketCount      ErrorCode = "invalid_ticket_count"
+	CodeInvalidLimit            ErrorCode = "invalid_limit"
+	CodeInvalidDateRange        ErrorCode = "invalid_date_range"
+	CodeInvalidAmountRange      ErrorCode = "invalid_amount_range"
+	CodeInvalidAmount           ErrorCode = "invalid_amount"
+	CodeInvalidCurrency         ErrorCode = "invalid_currency"
+	CodeUnsupportedCurrency     ErrorCode = "unsupported_currency"
+	CodeCurrencyMismatch        ErrorCode = "currency_mismatch"
+	CodeMixedCurrencies         ErrorCode = "mixed_currencies"
+	CodeInvalidBookingStatus    ErrorCode = "invalid_booking_status"
+	CodeInvalidBookingAmount    ErrorCode = "invalid_booking_amount"
+	CodeInvalidPricingStrategy  ErrorCode = "invalid_pricing_strategy"
+	CodeUnknownPricingStrategy  ErrorCode = "unknown_pricing_strategy"
+	CodeInvalidCredential       ErrorCode = "invalid_credential"
+	CodeNoTickets               ErrorCode = "no_tickets"
+	CodeTooManyTickets          ErrorCode = "too_many_tickets"
+	CodeTicketNotForEvent       ErrorCode = "ticket_not_for_event"
+)
+
+// Missing resources
+const (
+	CodeNotFound                    ErrorCode = "not_found"
+	CodeBookingNotFound             ErrorCode = "booking_not_found"
+	CodeTicketNotFound              ErrorCode = "ticket_not_found"
+	CodeEventNotFound               ErrorCode = "event_not_found"
+	CodePricingTierNotFound         ErrorCode = "pricing_tier_not_found"
+	CodeTransferNotFound            ErrorCode = "transfer_not_found"
+	CodeWebhookSubscriptionNotFound ErrorCode = "webhook_subscription_not_found"
+	CodeWebhookDeliveryNotFound     ErrorCode = "webhook_delivery_not_found"
+)
+
+// Conflicts with the current state of a resource
+const (
+	CodeTicketNotAvailable           ErrorCode = "ticket_not_available"
+	CodeTicketNotReserved            ErrorCode = "ticket_not_reserved"
+	CodeTicketNotTransferable        ErrorCode = "ticket_not_transferable"
+	CodeTicketHasNoCredential        ErrorCode = "ticket_has_no_credential"
+	CodeCredentialNotAdmitted        ErrorCode = "credential_not_admitted"
+	CodeBookingStatusConflict        ErrorCode = "booking_status_conflict"
+	CodeBookingHasTransferredTickets ErrorCode = "booking_has_transferred_tickets"
+	CodeTransferNoLongerPending      ErrorCode = "transfer_no_longer_pending"
+	CodeTransferExpired              ErrorCode = "transfer_expired"
+	CodeTransfersClosed              ErrorCode = "transfers_closed"
+)
+
+// Availability of the service
+const (
+	CodeTooManyRequests   ErrorCode = "too_many_requests"
+	CodeBookingsDisabled  ErrorCode = "bookings_are_temporarily_disabled"
+	CodeEndpointRemoved   ErrorCode = "endpoint_removed_in_this_api_version"
+	CodeAPIVersionRetired ErrorCode = "api_version_retired"
+	CodeRequestTimedOut   ErrorCode = "request_timed_out"
+	// CodeInternal is the code of every server error; their titles say what
+	// failed but clients cannot act on the difference.
+	CodeInternal ErrorCode = "internal_server_error"
+)
@@ -4,49 +4,61 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"log/slog"
 )
 
 type AppError struct {
-	Code    int    `json:"code"`    // HTTP status code
-	Message string `json:"message"` // Human-readable error message
-	Details string `json:"details"` // Detailed error for debugging (optional)
+	Code      int       `json:"code"`       // HTTP status code
+	ErrorCode ErrorCode `json:"error_code"` // Stable machine-readable code
+	Message   string    `json:"message"`    // Human-readable error message
+	Details   string    `json:"details"`    // Detailed error for debugging (optional)
 }
 
-func NewAppError(code int, message string, details string) *AppError {
+func NewAppError(code int, errorCode ErrorCode, message string, details string) *AppError {
 	return &AppError{
-		Code:    code,
-		Message: message,
-		Details: details,
+		Code:      code,
+		ErrorCode: errorCode,
+		Message:   message,
+		Details:   details,
 	}
 }
 
+// WrapError returns err unchanged when it already is an AppError, so the
+// status chosen where it was raised survives, and otherwise wraps it in a new
+// AppError with the given status, code and message.
+func WrapError(err error, code int, errorCode ErrorCode, message string) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return NewAppError(code, errorCode, message, err.Error())
+}
+
 func (e *AppError) Error() string {
 	return fmt.Sprintf("Code: %d, Message: %s, Details: %s", e.Code, e.Message, e.Details)
 }
 
-func ErrorHandlerMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Next()
-
-		if len(c.Errors) > 0 {
-			err := c.Errors[0].Err
-			var appErr *AppError
-			if errors.As(err, &appErr) {
-				// Handle custom application errors
-				c.JSON(appErr.Code, gin.H{
-					"error": gin.H{
-						"message": appErr.Message,
-						"details": appErr.Details,
-					},
-				})
-			}
-		}
-	}
+// LogValue logs the error as a group of its fields.
+func (e *AppError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("code", e.Code),
+		slog.String("error_code", string(e.ErrorCode)),
+		slog.String("message", e.Message),
+		slog.String("details", e.Details),
+	)
 }
 
-func HandleError(c *gin.Context, err error, code int, message string) {
+// HandleError wraps err in an AppError with the given status, code and
+// message, attaches it to the request and stops further processing.
+func HandleError(c *gin.Context, err error, code int, errorCode ErrorCode, message string) {
 	if err != nil {
-		c.Error(NewAppError(code, message, err.Error())) // Attach error to Gin context
-		c.Abort()                                        // Stop further processing
+		AbortWithError(c, NewAppError(code, errorCode, message, err.Error()))
 	}
 }
+
+// AbortWithError attaches err to the request for the error handling
+// middleware to render and stops further processing.
+func AbortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}